@@ -0,0 +1,82 @@
+-- +goose Up
+CREATE TABLE task_status (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    status_name VARCHAR(10) NOT NULL,
+    status_category VARCHAR(11) NOT NULL,
+    sort_order int NOT NULL DEFAULT 0,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE KEY (company_id, status_name),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+CREATE TABLE task_status_transition (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    from_status_id int NOT NULL,
+    to_status_id int NOT NULL,
+    user_role VARCHAR(6) NULL,
+    user_type VARCHAR(6) NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (from_status_id) REFERENCES task_status (id),
+    CONSTRAINT FOREIGN KEY (to_status_id) REFERENCES task_status (id)
+);
+
+-- 既存の企業に既定のワークフローを設定する
+INSERT INTO task_status (company_id, status_name, status_category, sort_order)
+SELECT c.id, s.status_name, s.status_category, s.sort_order
+FROM company c
+CROSS JOIN (
+    SELECT 'NEW' AS status_name, 'OPEN' AS status_category, 1 AS sort_order
+    UNION ALL SELECT 'PROCESSING', 'IN_PROGRESS', 2
+    UNION ALL SELECT 'DONE', 'CLOSED', 3
+) s;
+
+INSERT INTO task_status_transition (company_id, from_status_id, to_status_id)
+SELECT f.company_id, f.id, t.id
+FROM task_status f
+JOIN task_status t ON t.company_id = f.company_id
+JOIN (
+    SELECT 'NEW' AS from_name, 'PROCESSING' AS to_name
+    UNION ALL SELECT 'NEW', 'DONE'
+    UNION ALL SELECT 'PROCESSING', 'NEW'
+    UNION ALL SELECT 'PROCESSING', 'DONE'
+    UNION ALL SELECT 'DONE', 'PROCESSING'
+) d ON d.from_name = f.status_name AND d.to_name = t.status_name;
+
+-- タスクのステータスを参照テーブルへ移行する
+ALTER TABLE task ADD COLUMN task_status_id int NULL AFTER detail;
+
+UPDATE task
+JOIN user ON user.id = task.creator_id
+JOIN task_status ON task_status.company_id = user.company_id AND task_status.status_name = task.task_status
+SET task.task_status_id = task_status.id;
+
+ALTER TABLE task
+    MODIFY task_status_id int NOT NULL,
+    ADD CONSTRAINT fk_task_task_status FOREIGN KEY (task_status_id) REFERENCES task_status (id),
+    DROP COLUMN task_status;
+
+-- +goose Down
+ALTER TABLE task ADD COLUMN task_status VARCHAR(10) NULL AFTER detail;
+
+-- 企業独自のステータスは分類を元に既定のステータスへ戻す
+UPDATE task
+JOIN task_status ON task_status.id = task.task_status_id
+SET task.task_status = CASE task_status.status_category
+    WHEN 'OPEN' THEN 'NEW'
+    WHEN 'IN_PROGRESS' THEN 'PROCESSING'
+    ELSE 'DONE'
+END;
+
+ALTER TABLE task MODIFY task_status VARCHAR(10) NOT NULL;
+ALTER TABLE task DROP FOREIGN KEY fk_task_task_status;
+ALTER TABLE task DROP COLUMN task_status_id;
+
+DROP TABLE IF EXISTS task_status_transition;
+DROP TABLE IF EXISTS task_status;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE task_priority (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    priority_name VARCHAR(10) NOT NULL,
+    priority_level int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE KEY (company_id, priority_name),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+-- 既存の企業に既定の優先度を設定する
+INSERT INTO task_priority (company_id, priority_name, priority_level)
+SELECT c.id, p.priority_name, p.priority_level
+FROM company c
+CROSS JOIN (
+    SELECT 'LOW' AS priority_name, 1 AS priority_level
+    UNION ALL SELECT 'MEDIUM', 2
+    UNION ALL SELECT 'HIGH', 3
+    UNION ALL SELECT 'URGENT', 4
+) p;
+
+ALTER TABLE task
+    ADD COLUMN task_priority_id int NULL AFTER person_in_charge_id,
+    ADD COLUMN start_date TIMESTAMP NULL AFTER task_priority_id,
+    ADD CONSTRAINT fk_task_task_priority FOREIGN KEY (task_priority_id) REFERENCES task_priority (id);
+
+-- +goose Down
+ALTER TABLE task DROP FOREIGN KEY fk_task_task_priority;
+ALTER TABLE task
+    DROP COLUMN task_priority_id,
+    DROP COLUMN start_date;
+
+DROP TABLE IF EXISTS task_priority;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE label (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    label_name VARCHAR(20) NOT NULL,
+    label_color VARCHAR(7) NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE KEY (company_id, label_name),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+CREATE TABLE task_label (
+    task_id int NOT NULL,
+    label_id int NOT NULL,
+    PRIMARY KEY(task_id, label_id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (label_id) REFERENCES label (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_label;
+DROP TABLE IF EXISTS label;
@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE company_setting (
+    company_id int NOT NULL,
+    max_task_depth int NOT NULL DEFAULT 3,
+    require_closed_subtasks BOOLEAN NOT NULL DEFAULT FALSE,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(company_id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+-- 既存の企業に既定の設定を登録する
+INSERT INTO company_setting (company_id)
+SELECT id FROM company;
+
+ALTER TABLE task
+    ADD COLUMN parent_id int NULL AFTER limit_date,
+    ADD CONSTRAINT fk_task_parent FOREIGN KEY (parent_id) REFERENCES task (id) ON DELETE SET NULL;
+
+CREATE TABLE task_checklist_item (
+    id int NOT NULL AUTO_INCREMENT,
+    task_id int NOT NULL,
+    item_text VARCHAR(100) NOT NULL,
+    is_done BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order int NOT NULL,
+    PRIMARY KEY(id),
+    INDEX (task_id, sort_order),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_checklist_item;
+
+ALTER TABLE task DROP FOREIGN KEY fk_task_parent;
+ALTER TABLE task DROP COLUMN parent_id;
+
+DROP TABLE IF EXISTS company_setting;
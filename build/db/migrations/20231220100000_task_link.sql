@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE task_link (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    link_type VARCHAR(20) NOT NULL,
+    source_task_id int NOT NULL,
+    target_task_id int NOT NULL,
+    creator_id int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE KEY (link_type, source_task_id, target_task_id),
+    INDEX (company_id),
+    INDEX (target_task_id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (source_task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (target_task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (creator_id) REFERENCES user (id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_link;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE comment (
+    id int NOT NULL AUTO_INCREMENT,
+    task_id int NOT NULL,
+    author_id int NOT NULL,
+    comment_body TEXT NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edit_at TIMESTAMP NULL,
+    delete_at TIMESTAMP NULL,
+    PRIMARY KEY(id),
+    INDEX (task_id, delete_at),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (author_id) REFERENCES user (id)
+);
+
+CREATE TABLE comment_mention (
+    comment_id int NOT NULL,
+    user_id int NOT NULL,
+    PRIMARY KEY(comment_id, user_id),
+    CONSTRAINT FOREIGN KEY (comment_id) REFERENCES comment (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_mention;
+DROP TABLE IF EXISTS comment;
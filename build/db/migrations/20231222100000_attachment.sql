@@ -0,0 +1,32 @@
+-- +goose Up
+ALTER TABLE company_setting
+    ADD COLUMN max_attachment_size BIGINT NOT NULL DEFAULT 10485760 AFTER require_closed_subtasks,
+    ADD COLUMN allowed_mime_types VARCHAR(2000) NOT NULL DEFAULT 'image/png,image/jpeg,image/gif,application/pdf,text/plain' AFTER max_attachment_size,
+    ADD COLUMN storage_quota BIGINT NOT NULL DEFAULT 1073741824 AFTER allowed_mime_types;
+
+CREATE TABLE attachment (
+    id int NOT NULL AUTO_INCREMENT,
+    task_id int NOT NULL,
+    company_id int NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    creator_id int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE (storage_key),
+    INDEX (task_id),
+    INDEX (company_id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (creator_id) REFERENCES user (id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS attachment;
+
+ALTER TABLE company_setting
+    DROP COLUMN storage_quota,
+    DROP COLUMN allowed_mime_types,
+    DROP COLUMN max_attachment_size;
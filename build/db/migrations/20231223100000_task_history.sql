@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE task_history (
+    id int NOT NULL AUTO_INCREMENT,
+    task_id int NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    field_name VARCHAR(30) NOT NULL,
+    old_value TEXT NULL,
+    new_value TEXT NULL,
+    actor_id int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    INDEX (task_id, id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id),
+    CONSTRAINT FOREIGN KEY (actor_id) REFERENCES user (id)
+);
+
+-- 履歴は追記のみとし、更新・削除を禁止する
+-- +goose StatementBegin
+CREATE TRIGGER task_history_no_update BEFORE UPDATE ON task_history
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'task_history is append-only';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER task_history_no_delete BEFORE DELETE ON task_history
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'task_history is append-only';
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS task_history_no_delete;
+DROP TRIGGER IF EXISTS task_history_no_update;
+DROP TABLE IF EXISTS task_history;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE activity (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    activity_type VARCHAR(20) NOT NULL,
+    task_id int NULL,
+    user_id int NULL,
+    actor_id int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    INDEX (company_id, id),
+    INDEX (task_id),
+    INDEX (actor_id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (actor_id) REFERENCES user (id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS activity;
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE company
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 0;
+
+ALTER TABLE user
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 0;
+
+ALTER TABLE task
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE task
+    DROP COLUMN version;
+
+ALTER TABLE user
+    DROP COLUMN version;
+
+ALTER TABLE company
+    DROP COLUMN version;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE idempotency_record (
+    user_id int NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code int NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body MEDIUMBLOB NULL,
+    create_at TIMESTAMP(3) NOT NULL,
+    expire_at TIMESTAMP(3) NOT NULL,
+    PRIMARY KEY(user_id, idempotency_key),
+    INDEX (expire_at),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_record;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE task
+    ADD COLUMN delete_at TIMESTAMP NULL;
+
+-- +goose Down
+ALTER TABLE task
+    DROP COLUMN delete_at;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE task_assignee (
+    task_id int NOT NULL,
+    user_id int NOT NULL,
+    PRIMARY KEY(task_id, user_id),
+    INDEX (user_id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+CREATE TABLE task_watcher (
+    task_id int NOT NULL,
+    user_id int NOT NULL,
+    PRIMARY KEY(task_id, user_id),
+    INDEX (user_id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- 既存の担当者を主担当者として移行する
+INSERT INTO task_assignee (task_id, user_id)
+    SELECT id, person_in_charge_id FROM task WHERE person_in_charge_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS task_watcher;
+DROP TABLE IF EXISTS task_assignee;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE task_series (
+    id int NOT NULL AUTO_INCREMENT,
+    frequency VARCHAR(10) NOT NULL,
+    recurrence_interval int NOT NULL DEFAULT 1,
+    weekdays VARCHAR(20) NOT NULL DEFAULT '',
+    until_date TIMESTAMP NULL,
+    max_count int NULL,
+    anchor_date TIMESTAMP NOT NULL,
+    template_task_id int NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    CONSTRAINT FOREIGN KEY (template_task_id) REFERENCES task (id) ON DELETE SET NULL
+);
+
+-- 同じ系列の同じ回は一つのみ生成できる
+ALTER TABLE task
+    ADD COLUMN task_series_id int NULL AFTER parent_id,
+    ADD COLUMN occurrence int NULL AFTER task_series_id,
+    ADD UNIQUE KEY uk_task_series_occurrence (task_series_id, occurrence),
+    ADD CONSTRAINT fk_task_task_series FOREIGN KEY (task_series_id) REFERENCES task_series (id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE task DROP FOREIGN KEY fk_task_task_series;
+ALTER TABLE task DROP INDEX uk_task_series_occurrence;
+ALTER TABLE task DROP COLUMN occurrence, DROP COLUMN task_series_id;
+
+DROP TABLE IF EXISTS task_series;
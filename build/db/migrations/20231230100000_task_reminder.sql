@@ -0,0 +1,22 @@
+-- +goose Up
+-- 送信済みのリマインダー。期限が変わった場合は改めて送る。
+CREATE TABLE task_reminder (
+    id int NOT NULL AUTO_INCREMENT,
+    task_id int NOT NULL,
+    user_id int NOT NULL,
+    offset_seconds int NOT NULL,
+    limit_date TIMESTAMP NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    UNIQUE KEY uk_task_reminder (task_id, user_id, offset_seconds, limit_date),
+    INDEX (user_id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+ALTER TABLE task ADD INDEX idx_task_limit_date (limit_date);
+
+-- +goose Down
+ALTER TABLE task DROP INDEX idx_task_limit_date;
+
+DROP TABLE IF EXISTS task_reminder;
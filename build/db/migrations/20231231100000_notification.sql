@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE notification (
+    id int NOT NULL AUTO_INCREMENT,
+    user_id int NOT NULL,
+    notification_type VARCHAR(20) NOT NULL,
+    task_id int NOT NULL,
+    actor_id int NULL,
+    comment_id int NULL,
+    status_name VARCHAR(255) NULL,
+    limit_date TIMESTAMP NULL,
+    read_at TIMESTAMP NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    INDEX (user_id, read_at, id),
+    INDEX (task_id),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (actor_id) REFERENCES user (id) ON DELETE SET NULL,
+    CONSTRAINT FOREIGN KEY (comment_id) REFERENCES comment (id) ON DELETE CASCADE
+);
+
+-- 設定のない種類は通知する
+CREATE TABLE notification_preference (
+    user_id int NOT NULL,
+    notification_type VARCHAR(20) NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY(user_id, notification_type),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_preference;
+DROP TABLE IF EXISTS notification;
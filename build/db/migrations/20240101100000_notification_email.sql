@@ -0,0 +1,42 @@
+-- +goose Up
+ALTER TABLE notification_preference
+    ADD COLUMN email BOOLEAN NOT NULL DEFAULT TRUE AFTER in_app;
+
+-- ユーザごとのメールの設定。設定のないユーザにはメールを送らない。
+CREATE TABLE notification_email (
+    user_id int NOT NULL,
+    email_address VARCHAR(254) NULL,
+    email_language VARCHAR(2) NOT NULL DEFAULT 'ja',
+    email_mode VARCHAR(10) NOT NULL DEFAULT 'OFF',
+    unsubscribe_token VARCHAR(64) NULL,
+    last_digest_at TIMESTAMP NULL,
+    PRIMARY KEY(user_id),
+    UNIQUE KEY uk_notification_email_unsubscribe_token (unsubscribe_token),
+    INDEX (email_mode),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- 送信待ちのメール
+CREATE TABLE mail_outbox (
+    id int NOT NULL AUTO_INCREMENT,
+    user_id int NULL,
+    to_address VARCHAR(254) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    unsubscribe_url VARCHAR(255) NOT NULL DEFAULT '',
+    mail_status VARCHAR(7) NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error VARCHAR(1000) NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    PRIMARY KEY(id),
+    INDEX (mail_status, next_attempt_at),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE SET NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS mail_outbox;
+DROP TABLE IF EXISTS notification_email;
+
+ALTER TABLE notification_preference DROP COLUMN email;
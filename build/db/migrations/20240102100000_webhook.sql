@@ -0,0 +1,44 @@
+-- +goose Up
+-- 企業の出来事を通知する Webhook
+CREATE TABLE webhook (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    -- 購読する出来事の種類のカンマ区切りの一覧
+    event_types VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failures int NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    INDEX (company_id, active),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+-- Webhook の配信のアウトボックス。送信後も配信の記録として残す。
+CREATE TABLE webhook_delivery (
+    id int NOT NULL AUTO_INCREMENT,
+    webhook_id int NOT NULL,
+    event_id VARCHAR(32) NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    payload MEDIUMTEXT NOT NULL,
+    delivery_status VARCHAR(9) NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    response_code int NULL,
+    last_error VARCHAR(1000) NULL,
+    redelivery_of int NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    PRIMARY KEY(id),
+    INDEX (webhook_id, id),
+    INDEX (delivery_status, next_attempt_at),
+    CONSTRAINT FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE,
+    CONSTRAINT FOREIGN KEY (redelivery_of) REFERENCES webhook_delivery (id) ON DELETE SET NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- 集約の変更の出来事のアウトボックス。集約の保存と同じトランザクションで記録する。
+CREATE TABLE domain_event (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    event_type VARCHAR(30) NOT NULL,
+    aggregate_type VARCHAR(10) NOT NULL,
+    aggregate_id int NOT NULL,
+    company_id int NOT NULL,
+    actor_id int NULL,
+    -- 変更された項目の JSON の配列
+    changes TEXT NOT NULL,
+    occurred_at TIMESTAMP(6) NOT NULL,
+    event_status VARCHAR(10) NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP(6) NOT NULL,
+    -- 配信済みの購読者の名前のカンマ区切りの一覧
+    delivered_to VARCHAR(255) NOT NULL DEFAULT '',
+    last_error VARCHAR(1000) NULL,
+    dispatched_at TIMESTAMP NULL,
+    PRIMARY KEY(id),
+    INDEX (event_status, id),
+    INDEX (aggregate_type, aggregate_id, id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS domain_event;
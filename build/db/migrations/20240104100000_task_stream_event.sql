@@ -0,0 +1,19 @@
+-- +goose Up
+-- リアルタイムに送るタスクの変更。サーバ間で共有し、再接続時の再送のために直近の一定の件数だけ保持する。
+CREATE TABLE task_stream_event (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    task_id int NOT NULL,
+    creator_id int NOT NULL,
+    visibility VARCHAR(10) NOT NULL,
+    actor_id int NULL,
+    -- 変更された項目の JSON の配列
+    changes TEXT NOT NULL,
+    occurred_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY(id),
+    INDEX (company_id, id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_stream_event;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- 企業ごとの変更の通し番号。タスクの保存のたびに行をロックして採番する。
+CREATE TABLE company_change_seq (
+    company_id int NOT NULL,
+    seq BIGINT NOT NULL,
+    PRIMARY KEY(company_id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id)
+);
+
+ALTER TABLE task ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0 AFTER version;
+ALTER TABLE task ADD INDEX idx_task_change_seq (change_seq);
+
+-- 既存のタスクは ID を通し番号とする
+UPDATE task SET change_seq = id;
+INSERT INTO company_change_seq (company_id, seq)
+    SELECT user.company_id, MAX(task.id) FROM task JOIN user ON user.id = task.creator_id GROUP BY user.company_id;
+
+-- +goose Down
+ALTER TABLE task DROP INDEX idx_task_change_seq;
+ALTER TABLE task DROP COLUMN change_seq;
+DROP TABLE IF EXISTS company_change_seq;
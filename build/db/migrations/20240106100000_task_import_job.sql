@@ -0,0 +1,28 @@
+-- +goose Up
+-- ファイルからのタスクの取り込み。件数が多い場合は非同期に処理し、進捗を記録する。
+CREATE TABLE task_import_job (
+    id int NOT NULL AUTO_INCREMENT,
+    company_id int NOT NULL,
+    user_id int NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    job_status VARCHAR(10) NOT NULL,
+    -- 取り込む行の JSON の配列
+    job_rows MEDIUMTEXT NOT NULL,
+    total int NOT NULL,
+    processed int NOT NULL DEFAULT 0,
+    succeeded int NOT NULL DEFAULT 0,
+    failed int NOT NULL DEFAULT 0,
+    -- 行ごとの誤りの JSON の配列
+    errors MEDIUMTEXT NOT NULL,
+    last_error VARCHAR(1000) NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    PRIMARY KEY(id),
+    INDEX (job_status, id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_import_job;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- 他のツールから取り込んだ課題とタスクの対応。同じ課題を重ねて取り込まないために使う。
+CREATE TABLE task_external_ref (
+    company_id int NOT NULL,
+    source VARCHAR(10) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    task_id int NOT NULL,
+    create_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(company_id, source, external_id),
+    INDEX (task_id),
+    CONSTRAINT FOREIGN KEY (company_id) REFERENCES company (id),
+    CONSTRAINT FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE
+);
+
+ALTER TABLE task_import_job
+    ADD source VARCHAR(10) NOT NULL DEFAULT 'FILE' AFTER user_id,
+    ADD skipped int NOT NULL DEFAULT 0 AFTER failed,
+    -- 企業のユーザと一致しなかったユーザの JSON の配列
+    ADD unmatched_users MEDIUMTEXT NULL AFTER errors;
+
+-- +goose Down
+ALTER TABLE task_import_job
+    DROP COLUMN unmatched_users,
+    DROP COLUMN skipped,
+    DROP COLUMN source;
+
+DROP TABLE IF EXISTS task_external_ref;
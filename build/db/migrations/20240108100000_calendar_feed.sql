@@ -0,0 +1,13 @@
+-- +goose Up
+-- ユーザごとのカレンダーの購読。トークンを発行し直すと以前の URL は使えなくなる。
+CREATE TABLE calendar_feed (
+    user_id int NOT NULL,
+    token VARCHAR(64) NOT NULL,
+    create_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(user_id),
+    UNIQUE (token),
+    CONSTRAINT FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS calendar_feed;
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE domain_event
+    -- 他のツールからの取り込みによる出来事。通知しない。
+    ADD imported BOOLEAN NOT NULL DEFAULT FALSE AFTER occurred_at;
+
+-- +goose Down
+ALTER TABLE domain_event
+    DROP COLUMN imported;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- 同じ出来事が再び配信された場合に、通知、メール、タイムラインの出来事を重複して記録しないための元の出来事
+ALTER TABLE notification
+    ADD domain_event_id BIGINT NULL AFTER limit_date,
+    ADD UNIQUE KEY uk_notification_domain_event (domain_event_id, user_id, notification_type);
+
+ALTER TABLE mail_outbox
+    ADD domain_event_id BIGINT NULL AFTER unsubscribe_url,
+    ADD notification_type VARCHAR(20) NULL AFTER domain_event_id,
+    ADD UNIQUE KEY uk_mail_outbox_domain_event (domain_event_id, user_id, notification_type);
+
+-- user_id は NULL となる種類があり一意キーで重複を判定できないため、出来事ごとに一件となる種類で判定する
+ALTER TABLE activity
+    ADD domain_event_id BIGINT NULL AFTER user_id,
+    ADD UNIQUE KEY uk_activity_domain_event (domain_event_id, activity_type);
+
+-- +goose Down
+ALTER TABLE activity
+    DROP INDEX uk_activity_domain_event,
+    DROP COLUMN domain_event_id;
+
+ALTER TABLE mail_outbox
+    DROP INDEX uk_mail_outbox_domain_event,
+    DROP COLUMN notification_type,
+    DROP COLUMN domain_event_id;
+
+ALTER TABLE notification
+    DROP INDEX uk_notification_domain_event,
+    DROP COLUMN domain_event_id;
@@ -76,20 +76,86 @@ func seeds(db *gorm.DB) error {
 		},
 	}
 
+	taskStatuses := []model.TaskStatus{
+		{ID: 1, CompanyID: 1, Name: "NEW", Category: "OPEN", SortOrder: 1},
+		{ID: 2, CompanyID: 1, Name: "PROCESSING", Category: "IN_PROGRESS", SortOrder: 2},
+		{ID: 3, CompanyID: 1, Name: "DONE", Category: "CLOSED", SortOrder: 3},
+		{ID: 4, CompanyID: 2, Name: "NEW", Category: "OPEN", SortOrder: 1},
+		{ID: 5, CompanyID: 2, Name: "PROCESSING", Category: "IN_PROGRESS", SortOrder: 2},
+		{ID: 6, CompanyID: 2, Name: "DONE", Category: "CLOSED", SortOrder: 3},
+	}
+
+	var taskStatusTransitions []model.TaskStatusTransition
+	for _, companyID := range []uint64{1, 2} {
+		// NEW, PROCESSING, DONE の順に登録されている
+		offset := (companyID - 1) * 3
+		newID, processingID, doneID := offset+1, offset+2, offset+3
+		taskStatusTransitions = append(taskStatusTransitions,
+			model.TaskStatusTransition{CompanyID: companyID, FromStatusID: newID, ToStatusID: processingID},
+			model.TaskStatusTransition{CompanyID: companyID, FromStatusID: newID, ToStatusID: doneID},
+			model.TaskStatusTransition{CompanyID: companyID, FromStatusID: processingID, ToStatusID: newID},
+			model.TaskStatusTransition{CompanyID: companyID, FromStatusID: processingID, ToStatusID: doneID},
+			model.TaskStatusTransition{CompanyID: companyID, FromStatusID: doneID, ToStatusID: processingID},
+		)
+	}
+
+	var taskPriorities []model.TaskPriority
+	for _, companyID := range []uint64{1, 2} {
+		taskPriorities = append(taskPriorities,
+			model.TaskPriority{CompanyID: companyID, Name: "LOW", Level: 1},
+			model.TaskPriority{CompanyID: companyID, Name: "MEDIUM", Level: 2},
+			model.TaskPriority{CompanyID: companyID, Name: "HIGH", Level: 3},
+			model.TaskPriority{CompanyID: companyID, Name: "URGENT", Level: 4},
+		)
+	}
+
+	var companySettings []model.CompanySetting
+	for _, companyID := range []uint64{1, 2} {
+		companySettings = append(companySettings, model.CompanySetting{
+			CompanyID:         companyID,
+			MaxTaskDepth:      3,
+			MaxAttachmentSize: 10 << 20,
+			AllowedMimeTypes:  "image/png,image/jpeg,image/gif,application/pdf,text/plain",
+			StorageQuota:      1 << 30,
+		})
+	}
+
+	labels := []model.Label{
+		{ID: 1, CompanyID: 2, Name: "backend", Color: "#1E88E5"},
+		{ID: 2, CompanyID: 2, Name: "infra", Color: "#43A047"},
+	}
+
 	tasks := []model.Task{
 		{
 			ID:               1,
 			Title:            "最初のタスク",
 			Detail:           p("タスク詳細"),
-			Status:           "NEW",
+			StatusID:         4,
 			Visibility:       "COMPANY",
 			PersonInChargeID: p(uint64(4)),
+			PriorityID:       p(uint64(6)), // 利用会社の MEDIUM
+			StartDate:        p(time.Now()),
 			LimitDate:        p(time.Now().AddDate(0, 1, 0)),
 			CreatorID:        3,
 			UpdatorID:        3,
 			CreateAt:         time.Now(),
 			UpdateAt:         time.Now(),
 		},
+		{
+			ID:         2,
+			Title:      "最初のタスクのサブタスク",
+			StatusID:   4,
+			Visibility: "COMPANY",
+			ParentID:   p(uint64(1)),
+			Checklist: []*model.TaskChecklistItem{
+				{Text: "調査する", Done: true, SortOrder: 1},
+				{Text: "実装する", SortOrder: 2},
+			},
+			CreatorID: 3,
+			UpdatorID: 3,
+			CreateAt:  time.Now(),
+			UpdateAt:  time.Now(),
+		},
 	}
 
 	if err := db.Create(&companies).Error; err != nil {
@@ -100,10 +166,54 @@ func seeds(db *gorm.DB) error {
 		fmt.Printf("%+v", err)
 	}
 
+	if err := db.Create(&taskStatuses).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	if err := db.Create(&taskStatusTransitions).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	if err := db.Create(&taskPriorities).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	if err := db.Create(&companySettings).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	if err := db.Create(&labels).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
 	if err := db.Create(&tasks).Error; err != nil {
 		fmt.Printf("%+v", err)
 	}
 
+	taskLinks := []model.TaskLink{
+		{CompanyID: 2, LinkType: "BLOCKS", SourceTaskID: 2, TargetTaskID: 1, CreatorID: 3},
+	}
+
+	if err := db.Create(&taskLinks).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	comments := []model.Comment{
+		{TaskID: 1, AuthorID: 3, Body: "@利用会社の一般編集者 確認をお願いします", Mentions: []*model.User{{ID: 4}}},
+	}
+
+	if err := db.Omit("Mentions.*").Create(&comments).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	taskLabels := []model.TaskLabel{
+		{TaskID: 1, LabelID: 1},
+	}
+
+	if err := db.Create(&taskLabels).Error; err != nil {
+		fmt.Printf("%+v", err)
+	}
+
 	return nil
 }
 
@@ -60,6 +60,125 @@ const docTemplate = `{
                 }
             }
         },
+        "/calendar/{token}": {
+            "get": {
+                "description": "トークンのユーザのタスクを iCalendar（RFC 5545）の形式で取得する。認証は不要で、トークンの末尾の .ics は省略できる。\n期限を DUE（vevent の場合は DTSTART）とし、ステータスの分類を STATUS に対応させる（vevent の場合、完了したタスクは CANCELLED）。トークンのユーザが閲覧できないタスクは含めない。\n絞り込みと並び順はタスク一覧と同じ指定ができ、URL に含めることで保存した絞り込みとして使える。",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "カレンダーの購読",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "購読のトークン",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "assigned",
+                            "company"
+                        ],
+                        "type": "string",
+                        "description": "範囲（assigned: 自身が担当するタスク（既定）, company: 企業の閲覧できるタスク）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "vtodo",
+                            "vevent"
+                        ],
+                        "type": "string",
+                        "description": "タスクの表し方（vtodo: ToDo（既定）, vevent: 期限の予定。期限のないタスクは含めない）",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ステータス名（カンマ区切りで複数指定）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "優先度名（カンマ区切りで複数指定）",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の下限（RFC3339）",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の上限（RFC3339）",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の下限（RFC3339）",
+                        "name": "limit_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の上限（RFC3339）",
+                        "name": "limit_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ラベルID（カンマ区切りで複数指定）",
+                        "name": "label_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ラベルの一致方法（any: いずれか, all: すべて）",
+                        "name": "label_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "親タスクID（指定した親タスクの子タスクのみ）",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/company/create": {
             "post": {
                 "description": "企業を作成する。管理会社の管理者のみ実行可能。",
@@ -82,6 +201,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "企業作成用リクエスト",
                         "name": "body",
@@ -107,6 +232,12 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
@@ -115,7 +246,7 @@ const docTemplate = `{
         },
         "/company/{company_id}": {
             "get": {
-                "description": "企業の情報をIDから取得する。管理会社のユーザのみ実行可能。",
+                "description": "企業の情報をIDから取得する。管理会社のユーザのみ実行可能。企業の版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,6 +266,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "取得済みの ETag",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
                     {
                         "type": "integer",
                         "description": "企業ID",
@@ -147,8 +284,17 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Company"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "企業の版数"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
@@ -162,11 +308,9 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/company/{company_id}/task/create": {
-            "post": {
-                "description": "タスクを作成する。編集者のみ可能。",
+            },
+            "patch": {
+                "description": "JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名は企業更新用リクエストと同じで、含まれない項目は変更しない。企業名は消去できない。管理会社の管理者のみ実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
                 "consumes": [
                     "application/json"
                 ],
@@ -174,9 +318,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "company"
                 ],
-                "summary": "タスクの作成",
+                "summary": "企業の部分更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -186,6 +330,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "type": "integer",
                         "description": "企業ID",
@@ -193,20 +343,17 @@ const docTemplate = `{
                         "in": "path"
                     },
                     {
-                        "description": "タスク作成用リクエスト",
+                        "description": "変更する項目のみを含む企業更新用リクエスト",
                         "name": "body",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/request.TaskCreate"
+                            "$ref": "#/definitions/request.CompanyUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -217,15 +364,24 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/company/{company_id}/task/list": {
+        "/company/{company_id}/activity": {
             "get": {
-                "description": "閲覧可能なタスクの一覧を企業IDから取得する。",
+                "description": "タスクの作成・担当者の設定・完了・コメントの投稿、ユーザの作成といった企業内の出来事を新しい順に取得する。閲覧できないタスクの出来事は含まない。続きは next_cursor を cursor に指定して取得する。",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,9 +389,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "activity"
                 ],
-                "summary": "企業のタスク一覧の取得",
+                "summary": "企業のタイムラインの取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -250,16 +406,43 @@ const docTemplate = `{
                         "description": "企業ID",
                         "name": "company_id",
                         "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "出来事を起こしたユーザのID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "カンマ区切りの出来事の種類（TASK_CREATED, TASK_ASSIGNED, TASK_COMPLETED, TASK_COMMENTED, USER_CREATED）",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前のページの next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "取得件数（既定20、最大100）",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
-                            }
+                            "$ref": "#/definitions/model.ActivityFeed"
                         }
                     },
                     "400": {
@@ -271,15 +454,18 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/company/{company_id}/task/list_by_assigned_user_id/{assigned_user_id}": {
+        "/company/{company_id}/calendar": {
             "get": {
-                "description": "閲覧可能なタスクの一覧を割り当てユーザIDから取得する。",
+                "description": "自身のタスクの期限をカレンダーのアプリで購読する URL を取得する。初めての場合はトークンを発行する。\nURL はトークンのみで認証するため、他人に知られた場合は発行し直す。",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,9 +473,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "calendar"
                 ],
-                "summary": "ユーザに割り当てられたタスク一覧の取得",
+                "summary": "カレンダーの購読の URL の取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -304,22 +490,13 @@ const docTemplate = `{
                         "description": "企業ID",
                         "name": "company_id",
                         "in": "path"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ユーザID",
-                        "name": "assigned_user_id",
-                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
-                            }
+                            "$ref": "#/definitions/model.CalendarFeedLink"
                         }
                     },
                     "400": {
@@ -337,9 +514,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/company/{company_id}/task/{task_id}": {
-            "get": {
-                "description": "閲覧可能なタスクの情報をIDから取得する。",
+        "/company/{company_id}/calendar/regenerate": {
+            "post": {
+                "description": "トークンを発行し直し、新しい URL を返す。以前の URL では取得できなくなる。",
                 "consumes": [
                     "application/json"
                 ],
@@ -347,9 +524,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "calendar"
                 ],
-                "summary": "タスクの取得",
+                "summary": "カレンダーの購読のトークンの再発行",
                 "parameters": [
                     {
                         "type": "string",
@@ -364,19 +541,13 @@ const docTemplate = `{
                         "description": "企業ID",
                         "name": "company_id",
                         "in": "path"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "タスクID",
-                        "name": "task_id",
-                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
+                            "$ref": "#/definitions/model.CalendarFeedLink"
                         }
                     },
                     "400": {
@@ -394,9 +565,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/company/{company_id}/task/{task_id}/status/{task_status}": {
-            "put": {
-                "description": "タスクのステータスを指定した状態へと更新する。編集者のみ可能。",
+        "/company/{company_id}/label/create": {
+            "post": {
+                "description": "企業のラベルを作成する。color は #RRGGBB 形式。編集者のみ可能。",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,9 +575,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "label"
                 ],
-                "summary": "タスクステータスの更新",
+                "summary": "ラベルの作成",
                 "parameters": [
                     {
                         "type": "string",
@@ -417,27 +588,32 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "企業ID",
-                        "name": "company_id",
-                        "in": "path"
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
                         "type": "integer",
-                        "description": "タスクID",
-                        "name": "task_id",
+                        "description": "企業ID",
+                        "name": "company_id",
                         "in": "path"
                     },
                     {
-                        "type": "string",
-                        "description": "タスクステータス",
-                        "name": "task_status",
-                        "in": "path"
+                        "description": "ラベル作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.LabelCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "登録されたラベルID",
+                        "schema": {
+                            "type": "integer"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -448,15 +624,21 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/company/{company_id}/task/{task_id}/update": {
-            "put": {
-                "description": "タスクの情報を更新する。編集者のみ可能。",
+        "/company/{company_id}/label/list": {
+            "get": {
+                "description": "企業のラベルの一覧を取得する。",
                 "consumes": [
                     "application/json"
                 ],
@@ -464,9 +646,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "label"
                 ],
-                "summary": "タスクの更新",
+                "summary": "ラベル一覧の取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -481,27 +663,16 @@ const docTemplate = `{
                         "description": "企業ID",
                         "name": "company_id",
                         "in": "path"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "タスクID",
-                        "name": "task_id",
-                        "in": "path"
-                    },
-                    {
-                        "description": "タスク更新用リクエスト",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/request.TaskUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Label"
+                            }
                         }
                     },
                     "400": {
@@ -519,9 +690,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/company/{company_id}/update": {
-            "put": {
-                "description": "企業を作成する。管理会社の管理者のみ実行可能。",
+        "/company/{company_id}/label/{label_id}/delete": {
+            "delete": {
+                "description": "企業のラベルを削除する。タスクに付与されているラベルはタスクから外され、タスクの変更として履歴と差分の同期に記録される。編集者のみ可能。",
                 "consumes": [
                     "application/json"
                 ],
@@ -529,9 +700,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "label"
                 ],
-                "summary": "企業の更新",
+                "summary": "ラベルの削除",
                 "parameters": [
                     {
                         "type": "string",
@@ -548,12 +719,10 @@ const docTemplate = `{
                         "in": "path"
                     },
                     {
-                        "description": "企業更新用リクエスト",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/request.CompanyUpdate"
-                        }
+                        "type": "integer",
+                        "description": "ラベルID",
+                        "name": "label_id",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -569,15 +738,18 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/company/{company_id}/user/create": {
-            "post": {
-                "description": "ユーザを登録する。管理会社の管理者と企業の管理者に実行可能。",
+        "/company/{company_id}/label/{label_id}/update": {
+            "put": {
+                "description": "企業のラベルの名前と色を更新する。編集者のみ可能。",
                 "consumes": [
                     "application/json"
                 ],
@@ -585,9 +757,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "label"
                 ],
-                "summary": "ユーザの登録",
+                "summary": "ラベルの更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -604,20 +776,23 @@ const docTemplate = `{
                         "in": "path"
                     },
                     {
-                        "description": "ユーザ作成用リクエスト",
+                        "type": "integer",
+                        "description": "ラベルID",
+                        "name": "label_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "ラベル更新用リクエスト",
                         "name": "body",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/request.AuthCreate"
+                            "$ref": "#/definitions/request.LabelUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "登録されたユーザID",
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -628,15 +803,18 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/company/{company_id}/user/{user_id}": {
+        "/company/{company_id}/notification": {
             "get": {
-                "description": "ユーザの情報をIDから取得する。",
+                "description": "自身の通知を未読を先に新しい順で取得する。",
                 "consumes": [
                     "application/json"
                 ],
@@ -644,9 +822,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "notification"
                 ],
-                "summary": "ユーザの取得",
+                "summary": "通知一覧の取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -664,16 +842,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "ユーザID",
-                        "name": "user_id",
-                        "in": "path"
+                        "description": "ページ番号（1始まり）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数（既定20、最大100）",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                            "$ref": "#/definitions/model.NotificationPage"
                         }
                     },
                     "400": {
@@ -691,9 +875,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/company/{company_id}/user/{user_id}/update": {
-            "post": {
-                "description": "ユーザの情報を更新する。管理会社の管理者と企業の管理者に実行可能。",
+        "/company/{company_id}/notification/preference": {
+            "get": {
+                "description": "自身の通知の種類ごとの設定を取得する。",
                 "consumes": [
                     "application/json"
                 ],
@@ -701,9 +885,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "notification"
                 ],
-                "summary": "ユーザの更新",
+                "summary": "通知の設定の取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -718,25 +902,14 @@ const docTemplate = `{
                         "description": "企業ID",
                         "name": "company_id",
                         "in": "path"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ユーザID",
-                        "name": "user_id",
-                        "in": "path"
-                    },
-                    {
-                        "description": "ユーザ更新用リクエスト",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/request.AuthUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.NotificationPreference"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -753,9 +926,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthz": {
+        "/company/{company_id}/notification/preference/update": {
+            "put": {
+                "description": "自身の通知の種類ごとの設定と、メールの送り先・言語・送り方を更新する。指定のない項目は変更しない。メールを送るにはメールアドレスが必要。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "通知の設定の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "通知の設定更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.NotificationPreferenceUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/notification/read_all": {
+            "put": {
+                "description": "自身の未読の通知をすべて既読にする。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "すべての通知の既読化",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/notification/unread_count": {
             "get": {
-                "description": "ヘルスチェック",
+                "description": "自身の未読の通知の件数を取得する。",
                 "consumes": [
                     "application/json"
                 ],
@@ -763,182 +1040,5139 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "root"
+                    "notification"
+                ],
+                "summary": "未読の通知の件数の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
                 ],
-                "summary": "ヘルスチェック",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.NotificationUnreadCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/notification/{notification_id}/read": {
+            "put": {
+                "description": "自身の通知を既読にする。既読の通知の既読日時は変更しない。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "通知の既読化",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "通知ID",
+                        "name": "notification_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/priority/create": {
+            "post": {
+                "description": "企業のタスク優先度を作成する。level が大きいほど優先度が高い。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "priority"
+                ],
+                "summary": "優先度の作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "優先度作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskPriorityCreate"
                         }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録された優先度ID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.AuthCreate": {
-            "type": "object",
-            "properties": {
-                "name": {
+        },
+        "/company/{company_id}/priority/list": {
+            "get": {
+                "description": "企業のタスク優先度の一覧を優先度の低い順に取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "priority"
+                ],
+                "summary": "優先度一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskPriority"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/priority/{priority_id}/delete": {
+            "delete": {
+                "description": "企業のタスク優先度を削除する。タスクで利用中の優先度は削除できない。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "priority"
+                ],
+                "summary": "優先度の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "優先度ID",
+                        "name": "priority_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/priority/{priority_id}/update": {
+            "put": {
+                "description": "企業のタスク優先度の名前とレベルを更新する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "priority"
+                ],
+                "summary": "優先度の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "優先度ID",
+                        "name": "priority_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "優先度更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskPriorityUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/setting": {
+            "get": {
+                "description": "企業のタスクに関する設定を取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業の設定の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.CompanySetting"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/setting/update": {
+            "put": {
+                "description": "サブタスクの階層の深さの上限、未完了の子タスクがある親タスクの完了を禁止するか、添付ファイルの容量の上限・許可する種類・企業の合計容量の上限を更新する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業の設定の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "企業の設定更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CompanySettingUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/bulk": {
+            "post": {
+                "description": "複数のタスクにステータスの変更、担当者の変更、ラベルの付与と除去、公開範囲の変更、削除を適用する。\n項目ごとに個別の操作と同じく閲覧できる自社のタスクであることを検証する。項目は最大100件。\nmode が atomic の場合はいずれかの項目が失敗するとすべて保存せず、最初に失敗した項目のステータスコードを返す。\nmode が best_effort の場合は成功した項目のみ保存し、200 で項目ごとの結果を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの一括操作",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "一括操作用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskBulk"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TaskBulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.TaskBulkResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.TaskBulkResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.TaskBulkResult"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/changes": {
+            "get": {
+                "description": "前回の同期で受け取ったカーソルより後に作成、更新、削除、公開範囲の変更があった企業のタスクを取得する。\n閲覧できるタスクは最新の内容を、削除されたか閲覧できなくなったタスクは ID のみを返す。\nsince を指定しない場合は閲覧できるすべてのタスクを返す。has_more が true の場合は返した cursor ですぐに取得し直す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの差分の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前回の同期で受け取ったカーソル",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskChangeSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/create": {
+            "post": {
+                "description": "タスクを作成する。parent_id を指定すると同じ企業のタスクのサブタスクとなる。担当者（assignee_ids）とウォッチャー（watcher_ids）は同じ企業のユーザのみ指定できる。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "タスク作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/export": {
+            "get": {
+                "description": "閲覧可能な企業のタスクを CSV または JSON で書き出す。絞り込みと並び順はタスク一覧と同じ指定ができる。\nユーザは ID で、優先度とラベルは名前で表し、CSV の複数の値は ; 区切りとする。書き出した列はそのまま取り込みに使える。",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの書き出し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "形式（既定は csv）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ステータス名（カンマ区切りで複数指定）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "優先度名（カンマ区切りで複数指定）",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の下限（RFC3339）",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の上限（RFC3339）",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の下限（RFC3339）",
+                        "name": "limit_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の上限（RFC3339）",
+                        "name": "limit_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ラベルID（カンマ区切りで複数指定）",
+                        "name": "label_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ラベルの一致方法（any: いずれか, all: すべて）",
+                        "name": "label_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "親タスクID（指定した親タスクの子タスクのみ）",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TaskExport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/import": {
+            "post": {
+                "description": "CSV または JSON のファイルからタスクを作成する。編集権限を持つユーザのみ可能。CSV は1行目をヘッダとし、JSON はオブジェクトの配列とする。\nmapping で項目名（title, detail, visibility, person_in_charge, assignees, watchers, priority, start_date, limit_date, labels, parent_id）から列名への対応を指定できる。\nユーザは ID または名前で、優先度とラベルは名前で指定する。日付は YYYY-MM-DD または RFC3339 形式とする。\nformat に trello（ボードの JSON）、jira（課題の CSV）、github（Issue の JSON）を指定すると他のツールのエクスポートを取り込む。\n他のツールのステータスと優先度は status_mapping と priority_mapping の対応で企業の名前に変換し、対応のない値は同じ名前とする。\n他のツールのユーザは名前または通知のメールアドレスで探し、一致しないユーザは割り当てずに unmatched_users に返す。存在しないラベルは作成し、コメントも取り込む。\n取り込み済みの課題は再び作成せずに skipped に数える。\n誤りのある行は作成せずに行ごとの誤りとして返す。dry_run を指定すると検証のみを行う。\n100件以下の場合はその場で処理して 200 を、超える場合は非同期に処理して 202 を返す。進捗は返した ID で取得する。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの取り込み",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して取り込まないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "file",
+                        "description": "取り込むファイル（10000件まで）",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "trello",
+                            "jira",
+                            "github"
+                        ],
+                        "type": "string",
+                        "description": "形式（未指定の場合は拡張子から判定）",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "項目名から列名への対応の JSON。例: {\u0026quot;title\u0026quot;: \u0026quot;件名\u0026quot;}",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "他のツールのステータスから企業のステータスの名前への対応の JSON。例: {\u0026quot;To Do\u0026quot;: \u0026quot;未着手\u0026quot;}",
+                        "name": "status_mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "他のツールの優先度から企業の優先度の名前への対応の JSON",
+                        "name": "priority_mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "検証のみを行う",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskImportJob"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/import/{job_id}": {
+            "get": {
+                "description": "取り込みの状態、処理した件数、行ごとの誤りを取得する。status が SUCCEEDED または FAILED になるまで繰り返し取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの取り込みの進捗の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "取り込みID",
+                        "name": "job_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/list": {
+            "get": {
+                "description": "閲覧可能なタスクの一覧を企業IDから取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "企業のタスク一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ステータス名（カンマ区切りで複数指定）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "優先度名（カンマ区切りで複数指定）",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の下限（RFC3339）",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の上限（RFC3339）",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の下限（RFC3339）",
+                        "name": "limit_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の上限（RFC3339）",
+                        "name": "limit_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ラベルID（カンマ区切りで複数指定）",
+                        "name": "label_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ラベルの一致方法（any: いずれか, all: すべて）",
+                        "name": "label_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "親タスクID（指定した親タスクの子タスクのみ）",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/list_by_assigned_user_id/{assigned_user_id}": {
+            "get": {
+                "description": "閲覧可能なタスクの一覧を割り当てユーザIDから取得する。主担当者に限らず、いずれかの担当者であるタスクを含む。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "ユーザに割り当てられたタスク一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザID",
+                        "name": "assigned_user_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ステータス名（カンマ区切りで複数指定）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "優先度名（カンマ区切りで複数指定）",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の下限（RFC3339）",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日の上限（RFC3339）",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の下限（RFC3339）",
+                        "name": "limit_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限の上限（RFC3339）",
+                        "name": "limit_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ラベルID（カンマ区切りで複数指定）",
+                        "name": "label_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ラベルの一致方法（any: いずれか, all: すべて）",
+                        "name": "label_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "親タスクID（指定した親タスクの子タスクのみ）",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/stream": {
+            "get": {
+                "description": "企業のタスクの作成、更新、ステータスの変更、削除を Server-Sent Events で送る。閲覧できないタスクの変更は送らない。\n公開範囲の変更で閲覧できなくなったタスクは、変更の値を含まない task.removed を送るため、クライアントは端末から削除する。\n各イベントの id を Last-Event-ID ヘッダ（または last_event_id）で指定して再接続すると、その後の変更を再送する。\n再送できない変更がある場合は最初に reset イベントを送るため、クライアントはタスクの一覧を取得し直す。\n変更がない間も一定の間隔でコメント行を送る。",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの変更のストリーム",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最後に受け取ったイベントのID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最後に受け取ったイベントのID（Last-Event-ID を指定できない場合）",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskStreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}": {
+            "get": {
+                "description": "閲覧可能なタスクの情報をIDから取得する。タスクの版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得済みの ETag",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "タスクの版数"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名はタスク更新用リクエストと同じで、含まれない項目は変更せず、null の項目は値を消去する。title・visibility・status は消去できない。閲覧できる自社のタスクのみ更新でき、編集者のみ可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの部分更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "enum": [
+                            "this",
+                            "future"
+                        ],
+                        "type": "string",
+                        "description": "繰り返しのタスクの編集の範囲（this: この回のみ, future: 以降のすべての回）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "description": "変更する項目のみを含むタスク更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/attachments": {
+            "get": {
+                "description": "閲覧可能なタスクの添付ファイルを登録順に取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "添付ファイル一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/attachments/upload": {
+            "post": {
+                "description": "閲覧可能なタスクにファイルを添付する。ファイルの種類は内容から判定し、企業の設定で許可された種類・容量の上限・合計容量の上限を超えるものは登録できない。編集者のみ可能。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "添付ファイルのアップロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "file",
+                        "description": "添付するファイル",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録された添付ファイルID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/attachments/{attachment_id}/delete": {
+            "delete": {
+                "description": "添付ファイルを削除する。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "添付ファイルの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "添付ファイルID",
+                        "name": "attachment_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/attachments/{attachment_id}/download": {
+            "get": {
+                "description": "添付ファイルの実体を取得する。タスクを閲覧できるユーザのみ可能。",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "添付ファイルのダウンロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "添付ファイルID",
+                        "name": "attachment_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/comments": {
+            "get": {
+                "description": "閲覧可能なタスクのコメントを投稿順に取得する。削除されたコメントは含まない。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "コメント一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ページ番号（1始まり）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数（既定20、最大100）",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/comments/create": {
+            "post": {
+                "description": "閲覧可能なタスクにコメントを投稿する。本文中の @ユーザ名 はタスクを閲覧できる同じ企業のユーザへのメンションとなる。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "コメントの投稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "コメント投稿用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録されたコメントID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/comments/{comment_id}/delete": {
+            "delete": {
+                "description": "コメントを削除する。コメントの投稿者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "コメントの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "コメントID",
+                        "name": "comment_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/comments/{comment_id}/update": {
+            "put": {
+                "description": "コメントの本文を編集する。コメントの投稿者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "コメントの編集",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "コメントID",
+                        "name": "comment_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "コメント編集用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/dependencies": {
+            "get": {
+                "description": "タスクの依存関係のグラフを取得する。BLOCKS の関連は推移的に辿り、それ以外の関連はタスクに直接接続するもののみを含む。閲覧できないタスクとその関連は含まない。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの依存関係の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskGraph"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/history": {
+            "get": {
+                "description": "閲覧可能なタスクの項目ごとの変更履歴（変更者・日時・項目・変更前後の値）を古い順に取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの変更履歴の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ページ番号（1始まり）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数（既定20、最大100）",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TaskHistoryPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/link/create": {
+            "post": {
+                "description": "タスクから対象のタスクへの関連を作成する。BLOCKS は循環する関連を作成できない。両方のタスクを閲覧できる編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの関連の作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "関連作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskLinkCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録された関連ID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/link/{link_id}/delete": {
+            "delete": {
+                "description": "タスクに接続している関連を削除する。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの関連の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "関連ID",
+                        "name": "link_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/recurrence/delete": {
+            "delete": {
+                "description": "タスクの属する系列の繰り返しを終了する。生成済みの回は繰り返さないタスクとして残る。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの繰り返しの終了",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/recurrence/update": {
+            "put": {
+                "description": "タスクを期限を基準に繰り返すようにする。期限のないタスクは繰り返せない。既に繰り返すタスクの場合は以降に生成する回の規則を置き換える。\n次の回はタスクの完了時、または期限を過ぎた時に期限をずらして生成される。編集者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの繰り返しの設定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "繰り返しの規則",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskRecurrence"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/status/{task_status}": {
+            "put": {
+                "description": "閲覧できる自社のタスクのステータスを指定した状態へと更新する。編集者のみ可能。企業のワークフローで許可された遷移のみ可能。企業の設定により、未完了の子タスクがある場合は完了にできない。未完了のブロッカーがある場合は force を指定しない限り開始・完了できない。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクステータスの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タスクステータス",
+                        "name": "task_status",
+                        "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "未完了のブロッカーがあっても開始・完了する",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/task/{task_id}/update": {
+            "put": {
+                "description": "閲覧できる自社のタスクの情報を更新する。編集者のみ可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "タスクの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクID",
+                        "name": "task_id",
+                        "in": "path"
+                    },
+                    {
+                        "enum": [
+                            "this",
+                            "future"
+                        ],
+                        "type": "string",
+                        "description": "繰り返しのタスクの編集の範囲（this: この回のみ, future: 以降のすべての回）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "description": "タスク更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/update": {
+            "put": {
+                "description": "企業名を更新する。管理会社の管理者のみ実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "企業更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CompanyUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/user/create": {
+            "post": {
+                "description": "ユーザを登録する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ユーザの登録",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "ユーザ作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録されたユーザID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/user/{user_id}": {
+            "get": {
+                "description": "ユーザの情報をIDから取得する。ユーザの版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ユーザの取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得済みの ETag",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザID",
+                        "name": "user_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ユーザの版数"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名はユーザ更新用リクエストと同じで、含まれない項目は変更しない。いずれの項目も消去できない。管理会社の管理者と企業の管理者に実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ユーザの部分更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザID",
+                        "name": "user_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "変更する項目のみを含むユーザ更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/user/{user_id}/update": {
+            "post": {
+                "description": "ユーザの情報を更新する。管理会社の管理者と企業の管理者に実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ユーザの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザID",
+                        "name": "user_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "ユーザ更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/create": {
+            "post": {
+                "description": "出来事を通知する Webhook を作成する。署名の鍵は作成時に生成され、Webhook の取得で確認できる。ループバックやプライベートのアドレスの URL は登録できない。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Webhook 作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.WebhookCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録された Webhook ID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/list": {
+            "get": {
+                "description": "企業の Webhook の一覧を取得する。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook 一覧の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/{webhook_id}": {
+            "get": {
+                "description": "Webhook の設定と署名の鍵を取得する。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/{webhook_id}/delete": {
+            "delete": {
+                "description": "Webhook を配信の記録とともに削除する。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/{webhook_id}/deliveries": {
+            "get": {
+                "description": "Webhook の配信を新しい順に、送信の状況と応答のステータスコードとともに取得する。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の配信の記録の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ページ番号（1始まり）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数（既定20、最大100）",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "配信と同じ出来事とペイロードを新しい配信として送る。無効な Webhook には再送できない。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の再送",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "配信ID",
+                        "name": "delivery_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "再送の配信ID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/webhook/{webhook_id}/update": {
+            "put": {
+                "description": "Webhook の URL と購読する出来事、有効かどうかを更新する。管理者のみ可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Webhook の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Webhook 更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.WebhookUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow": {
+            "get": {
+                "description": "企業のタスクステータスとステータス間の遷移の定義を取得する。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "ワークフローの取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Workflow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow/status/create": {
+            "post": {
+                "description": "企業のタスクステータスを作成する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "タスクステータスの作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "タスクステータス作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskStatusCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録されたタスクステータスID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow/status/{status_id}/delete": {
+            "delete": {
+                "description": "企業のタスクステータスを削除する。タスクで利用中のステータスは削除できない。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "タスクステータスの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクステータスID",
+                        "name": "status_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow/status/{status_id}/update": {
+            "put": {
+                "description": "企業のタスクステータスの名前・分類・並び順を更新する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "タスクステータスの更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "タスクステータスID",
+                        "name": "status_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "タスクステータス更新用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskStatusUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow/transition/create": {
+            "post": {
+                "description": "タスクステータス間の遷移を許可する。role, user_type を指定した場合は該当するユーザのみ遷移可能となる。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "ステータス遷移の作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再送で重複して作成しないためのキー",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "ステータス遷移作成用リクエスト",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.TaskStatusTransitionCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録されたステータス遷移ID",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/company/{company_id}/workflow/transition/{transition_id}/delete": {
+            "delete": {
+                "description": "タスクステータス間の遷移を削除する。管理会社の管理者と企業の管理者に実行可能。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "ステータス遷移の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "企業ID",
+                        "name": "company_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ステータス遷移ID",
+                        "name": "transition_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "ヘルスチェック",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "root"
+                ],
+                "summary": "ヘルスチェック",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/unsubscribe": {
+            "get": {
+                "description": "通知のメールに記載された配信停止のURL。トークンのユーザへのメールを停止する。認証は不要。",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "メールの配信停止",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配信停止のトークン",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "通知のメールに記載された配信停止のURL。トークンのユーザへのメールを停止する。認証は不要。",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "メールの配信停止",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配信停止のトークン",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.ActivityFeed": {
+            "type": "object",
+            "properties": {
+                "activities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Activity"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CalendarFeedLink": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL / カレンダーのアプリに登録する URL。クエリパラメータで範囲と絞り込みを指定できる。",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentPage": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Comment"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NotificationPage": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Notification"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NotificationUnreadCount": {
+            "type": "object",
+            "properties": {
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Subtasks": {
+            "type": "object",
+            "properties": {
+                "closed": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TaskBulkItemResult": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TaskBulkResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Applied / 保存されたかどうか。atomic でいずれかの項目が失敗した場合は false。",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TaskBulkItemResult"
+                    }
+                }
+            }
+        },
+        "model.TaskExport": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "description": "Assignees / 主担当者以外の担当者のユーザID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit_date": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "person_in_charge": {
+                    "description": "PersonInCharge / 主担当者のユーザID",
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "watchers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.TaskGraphNode": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_category": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TaskHistoryPage": {
+            "type": "object",
+            "properties": {
+                "histories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskHistory"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TaskRecurrence": {
+            "type": "object",
+            "properties": {
+                "by_day": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "freq": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "description": "Occurrence / 系列の何回目のタスクか（1始まり）",
+                    "type": "integer"
+                },
+                "rrule": {
+                    "description": "RRule / RRULE (RFC 5545) 形式の規則",
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "integer"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TaskStreamChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookDeliveryPage": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.WebhookDelivery"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AuthCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AuthLogin": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AuthUpdate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CommentCreate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Body / 本文。@ユーザ名 で同じ企業のユーザをメンションできる。",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CommentUpdate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CompanyCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CompanySettingUpdate": {
+            "type": "object",
+            "properties": {
+                "allowed_mime_types": {
+                    "description": "AllowedMimeTypes / 添付できるファイルの種類（image/png, image/* など）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_attachment_size": {
+                    "description": "MaxAttachmentSize / 添付ファイル1件あたりの容量の上限（バイト、100MiBまで）",
+                    "type": "integer"
+                },
+                "max_task_depth": {
+                    "description": "MaxTaskDepth / サブタスクの階層の深さの上限（1〜10）",
+                    "type": "integer"
+                },
+                "require_closed_subtasks": {
+                    "description": "RequireClosedSubtasks / 未完了の子タスクがある親タスクを完了にできないようにするか",
+                    "type": "boolean"
+                },
+                "storage_quota": {
+                    "description": "StorageQuota / 企業の添付ファイルの合計容量の上限（バイト、100GiBまで）",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CompanyUpdate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.LabelCreate": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.LabelUpdate": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.NotificationPreferenceUpdate": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email / 種類ごとにメールで通知するかどうか。指定のない種類は変更しない。",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "email_address": {
+                    "description": "EmailAddress / 通知を送るメールアドレス。空文字で削除する。",
+                    "type": "string"
+                },
+                "email_language": {
+                    "description": "EmailLanguage / メールの言語。ja または en",
+                    "type": "string"
+                },
+                "email_mode": {
+                    "description": "EmailMode / OFF（送らない）、IMMEDIATE（通知のたびに送る）、DIGEST（1日分をまとめて送る）",
+                    "type": "string"
+                },
+                "in_app": {
+                    "description": "InApp / 種類ごとにアプリ内で通知するかどうか。ASSIGNED, MENTIONED, STATUS_CHANGED, DUE_SOON。指定のない種類は変更しない。",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "request.TaskBulk": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.TaskBulkItem"
+                    }
+                },
+                "mode": {
+                    "description": "Mode / atomic（すべて成功した場合のみ保存、既定）または best_effort（成功した項目のみ保存）",
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskBulkItem": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "type": "boolean"
+                },
+                "label_id": {
+                    "type": "integer"
+                },
+                "op": {
+                    "description": "Op / set_status, reassign, add_label, remove_label, set_visibility, delete のいずれか",
+                    "type": "string"
+                },
+                "person_in_charge_id": {
+                    "description": "PersonInChargeID / reassign の担当者。null の場合は担当者を外す。",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskCreate": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "description": "AssigneeIDs / 主担当者（person_in_charge_id）以外の担当者のID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.ChecklistItem"
+                    }
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "limit_date": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID / 親タスクのID。未指定の場合は最上位のタスク。",
+                    "type": "integer"
+                },
+                "person_in_charge_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "watcher_ids": {
+                    "description": "WatcherIDs / 担当はせずにタスクをフォローするユーザのID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.TaskLinkCreate": {
+            "type": "object",
+            "properties": {
+                "target_task_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type / BLOCKS（このタスクが対象のタスクをブロックする）、RELATES_TO、DUPLICATES（このタスクが対象のタスクと重複する）",
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskPriorityCreate": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskPriorityUpdate": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskRecurrence": {
+            "type": "object",
+            "properties": {
+                "by_day": {
+                    "description": "ByDay / WEEKLY で期限とする曜日。MO, TU, WE, TH, FR, SA, SU",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "count": {
+                    "description": "Count / 最初の回を含めて生成する回数の上限",
+                    "type": "integer"
+                },
+                "freq": {
+                    "description": "Freq / DAILY, WEEKLY, MONTHLY のいずれか",
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "Interval / 繰り返しの間隔。未指定の場合は1。",
+                    "type": "integer"
+                },
+                "until": {
+                    "description": "Until / この日時より後の回は生成しない。count とは同時に指定できない。",
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskStatusCreate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.TaskStatusTransitionCreate": {
+            "type": "object",
+            "properties": {
+                "from_status_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "to_status_id": {
+                    "type": "integer"
+                },
+                "user_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TaskStatusUpdate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.TaskUpdate": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "description": "AssigneeIDs / 主担当者（person_in_charge_id）以外の担当者のID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.ChecklistItem"
+                    }
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "limit_date": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID / 親タスクのID。未指定の場合は最上位のタスク。",
+                    "type": "integer"
+                },
+                "person_in_charge_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "watcher_ids": {
+                    "description": "WatcherIDs / 担当はせずにタスクをフォローするユーザのID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.WebhookCreate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active / 省略した場合は有効とする",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "EventTypes / 購読する出来事の種類。task.created, task.updated, task.status_changed, task.deleted, comment.created, user.created",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.WebhookUpdate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active / 失敗が続いて無効になった Webhook は true で有効に戻す",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuthLogin": {
+            "type": "object",
+            "properties": {
+                "companyID": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Activity": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type / TASK_CREATED, TASK_ASSIGNED, TASK_COMPLETED, TASK_COMMENTED, USER_CREATED のいずれか",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID / 出来事の対象のユーザ。担当者の変更では新しい担当者、ユーザの作成では作成されたユーザ。",
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "creator": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Comment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "edit_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                    }
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Company": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.CompanySetting": {
+            "type": "object",
+            "properties": {
+                "allowed_mime_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_attachment_size": {
+                    "type": "integer"
+                },
+                "max_task_depth": {
+                    "type": "integer"
+                },
+                "require_closed_subtasks": {
+                    "type": "boolean"
+                },
+                "storage_quota": {
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Label": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Notification": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Actor / 通知のきっかけとなったユーザ。DUE_SOON では含まない。",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                        }
+                    ]
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "limit_date": {
+                    "description": "LimitDate / DUE_SOON の通知の時点の期限。通知より前の場合は期限切れの通知。",
                     "type": "string"
                 },
-                "password": {
+                "read": {
+                    "type": "boolean"
+                },
+                "read_at": {
                     "type": "string"
                 },
-                "role": {
+                "status": {
+                    "description": "Status / STATUS_CHANGED での変更後のステータス",
                     "type": "string"
                 },
-                "user_type": {
+                "task_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type / ASSIGNED, MENTIONED, STATUS_CHANGED, DUE_SOON のいずれか",
                     "type": "string"
                 }
             }
         },
-        "request.AuthLogin": {
+        "todo_api_internal_adapter_inbound_http_model.NotificationPreference": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "email": {
+                    "description": "Email / 種類ごとにメールで通知するかどうか",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
                 },
-                "password": {
+                "email_address": {
+                    "type": "string"
+                },
+                "email_language": {
+                    "type": "string"
+                },
+                "email_mode": {
                     "type": "string"
+                },
+                "in_app": {
+                    "description": "InApp / 種類ごとにアプリ内で通知するかどうか",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
                 }
             }
         },
-        "request.AuthUpdate": {
+        "todo_api_internal_adapter_inbound_http_model.Task": {
             "type": "object",
             "properties": {
-                "name": {
+                "assignees": {
+                    "description": "Assignees / 主担当者（person_in_charge）を先頭に含む担当者",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                    }
+                },
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.ChecklistItem"
+                    }
+                },
+                "create_at": {
                     "type": "string"
                 },
-                "role": {
+                "creator": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "detail": {
                     "type": "string"
                 },
-                "user_type": {
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Label"
+                    }
+                },
+                "limit_date": {
+                    "type": "string"
+                },
+                "open_blocker_ids": {
+                    "description": "OpenBlockerIDs / このタスクをブロックしている未完了のタスクのID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "person_in_charge": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "priority": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskPriority"
+                },
+                "recurrence": {
+                    "description": "Recurrence / 繰り返すタスクの場合のみ含む",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TaskRecurrence"
+                        }
+                    ]
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_category": {
+                    "type": "string"
+                },
+                "subtasks": {
+                    "$ref": "#/definitions/model.Subtasks"
+                },
+                "title": {
                     "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "updator": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "watchers": {
+                    "description": "Watchers / 担当はせずにタスクをフォローするユーザ",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                    }
                 }
             }
         },
-        "request.CompanyCreate": {
+        "todo_api_internal_adapter_inbound_http_model.TaskChangeSet": {
             "type": "object",
             "properties": {
-                "name": {
+                "cursor": {
+                    "description": "Cursor / 次の同期で since に指定する値",
                     "type": "string"
+                },
+                "has_more": {
+                    "description": "HasMore / 続きがあるかどうか。true の場合は cursor を指定してすぐに取得し直す。",
+                    "type": "boolean"
+                },
+                "tasks": {
+                    "description": "Tasks / 作成または更新され、閲覧できるタスク",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.Task"
+                    }
+                },
+                "tombstones": {
+                    "description": "Tombstones / 削除された、または閲覧できなくなったタスクのID。端末から削除する。",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "request.CompanyUpdate": {
+        "todo_api_internal_adapter_inbound_http_model.TaskGraph": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskLink"
+                    }
+                },
+                "root_task_id": {
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TaskGraphNode"
+                    }
                 }
             }
         },
-        "request.TaskCreate": {
+        "todo_api_internal_adapter_inbound_http_model.TaskHistory": {
             "type": "object",
             "properties": {
-                "detail": {
+                "action": {
+                    "description": "Action / CREATE, UPDATE, STATUS, ASSIGN, DELETE のいずれか",
                     "type": "string"
                 },
-                "limit_date": {
+                "actor": {
+                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                },
+                "create_at": {
                     "type": "string"
                 },
-                "person_in_charge_id": {
+                "field": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "title": {
+                "new_value": {
                     "type": "string"
                 },
-                "visibility": {
+                "old_value": {
                     "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
                 }
             }
         },
-        "request.TaskUpdate": {
+        "todo_api_internal_adapter_inbound_http_model.TaskImportError": {
             "type": "object",
             "properties": {
-                "detail": {
+                "field": {
+                    "description": "Field / 誤りのある項目。特定できない場合は空。",
                     "type": "string"
                 },
-                "limit_date": {
+                "line": {
+                    "description": "Line / CSV の場合はヘッダを1行目とした行番号、JSON の場合は配列の1始まりの番号",
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.TaskImportJob": {
+            "type": "object",
+            "properties": {
+                "create_at": {
                     "type": "string"
                 },
-                "person_in_charge_id": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Errors / 行ごとの誤り。先頭の1000件まで。",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskImportError"
+                    }
+                },
+                "failed": {
                     "type": "integer"
                 },
-                "status": {
+                "finished_at": {
                     "type": "string"
                 },
-                "title": {
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "LastError / 処理を続けられなかった理由",
                     "type": "string"
                 },
-                "visibility": {
+                "processed": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Skipped / 取り込み済みのため作成しなかった行の件数",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source / FILE, TRELLO, JIRA, GITHUB のいずれか",
+                    "type": "string"
+                },
+                "started_at": {
                     "type": "string"
+                },
+                "status": {
+                    "description": "Status / PENDING, RUNNING, SUCCEEDED, FAILED のいずれか",
+                    "type": "string"
+                },
+                "succeeded": {
+                    "description": "Succeeded / 作成した（dry_run の場合は検証を通った）行の件数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total / 取り込む行の件数",
+                    "type": "integer"
+                },
+                "unmatched_users": {
+                    "description": "UnmatchedUsers / 他のツールからの取り込みで企業のユーザと一致せず、割り当てなかったユーザ",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "response.AuthLogin": {
+        "todo_api_internal_adapter_inbound_http_model.TaskLink": {
             "type": "object",
             "properties": {
-                "companyID": {
+                "id": {
                     "type": "integer"
                 },
-                "token": {
-                    "type": "string"
+                "source_task_id": {
+                    "type": "integer"
                 },
-                "userID": {
+                "target_task_id": {
                     "type": "integer"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "todo_api_internal_adapter_inbound_http_model.Company": {
+        "todo_api_internal_adapter_inbound_http_model.TaskPriority": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
+                "level": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "todo_api_internal_adapter_inbound_http_model.Task": {
+        "todo_api_internal_adapter_inbound_http_model.TaskStatus": {
             "type": "object",
             "properties": {
-                "create_at": {
+                "category": {
                     "type": "string"
                 },
-                "creator": {
-                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                "id": {
+                    "type": "integer"
                 },
-                "detail": {
+                "name": {
                     "type": "string"
                 },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.TaskStatusTransition": {
+            "type": "object",
+            "properties": {
+                "from_status_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "limit_date": {
+                "role": {
                     "type": "string"
                 },
-                "person_in_charge": {
-                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                "to_status_id": {
+                    "type": "integer"
                 },
-                "status": {
+                "user_type": {
                     "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.TaskStreamEvent": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "changes": {
+                    "description": "Changes / 変更された項目。作成時はすべての項目の値を含む。",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TaskStreamChange"
+                    }
                 },
-                "update_at": {
+                "id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
                     "type": "string"
                 },
-                "updator": {
-                    "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.User"
+                "task_id": {
+                    "type": "integer"
                 },
-                "visibility": {
+                "type": {
+                    "description": "Type / task.created, task.updated, task.status_changed, task.deleted, task.removed のいずれか。\ntask.removed は公開範囲の変更で閲覧できなくなったタスクで、changes を含まない。",
                     "type": "string"
                 }
             }
@@ -962,6 +6196,104 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "description": "DisabledAt / 失敗が続いたために無効にした日時",
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failures": {
+                    "description": "Failures / 連続して配信に失敗した回数",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret / ペイロードの署名の鍵",
+                    "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "redelivery_of": {
+                    "type": "integer"
+                },
+                "response_code": {
+                    "description": "ResponseCode / 最後の送信の応答のステータスコード。応答がなかった場合は含まない。",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status / PENDING, SUCCEEDED, FAILED のいずれか",
+                    "type": "string"
+                }
+            }
+        },
+        "todo_api_internal_adapter_inbound_http_model.Workflow": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskStatus"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todo_api_internal_adapter_inbound_http_model.TaskStatusTransition"
+                    }
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ActivityHandler interface {
+	List(c echo.Context) error
+}
+
+type activityHandler struct {
+	authUsecase     usecase.AuthUsecase
+	activityUsecase usecase.ActivityUsecase
+}
+
+func NewActivityHandler(
+	authUsecase usecase.AuthUsecase,
+	activityUsecase usecase.ActivityUsecase,
+) ActivityHandler {
+	return &activityHandler{
+		authUsecase,
+		activityUsecase,
+	}
+}
+
+// ListActivity
+//
+//	@Summary		企業のタイムラインの取得
+//	@Description	タスクの作成・担当者の設定・完了・コメントの投稿、ユーザの作成といった企業内の出来事を新しい順に取得する。閲覧できないタスクの出来事は含まない。続きは next_cursor を cursor に指定して取得する。
+//	@Tags			activity
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			actor_id		query		int		false	"出来事を起こしたユーザのID"
+//	@Param			task_id			query		int		false	"タスクID"
+//	@Param			type			query		string	false	"カンマ区切りの出来事の種類（TASK_CREATED, TASK_ASSIGNED, TASK_COMPLETED, TASK_COMMENTED, USER_CREATED）"
+//	@Param			cursor			query		string	false	"前のページの next_cursor"
+//	@Param			limit			query		int		false	"取得件数（既定20、最大100）"
+//	@Success		200				{object}	model.ActivityFeed
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/activity [get]
+func (h *activityHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req request.ActivityList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	filter, cursor, aerr := request.MarshalActivityListParams(&req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	activities, aerr := h.activityUsecase.ListByCompanyID(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), *filter, *cursor)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalActivityFeed(activities, *cursor)
+
+	return c.JSON(http.StatusOK, res)
+}
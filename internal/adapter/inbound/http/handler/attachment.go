@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentHandler interface {
+	List(c echo.Context) error
+	Upload(c echo.Context) error
+	Download(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type attachmentHandler struct {
+	authUsecase       usecase.AuthUsecase
+	attachmentUsecase usecase.AttachmentUsecase
+}
+
+func NewAttachmentHandler(
+	authUsecase usecase.AuthUsecase,
+	attachmentUsecase usecase.AttachmentUsecase,
+) AttachmentHandler {
+	return &attachmentHandler{
+		authUsecase,
+		attachmentUsecase,
+	}
+}
+
+// ListAttachment
+//
+//	@Summary		添付ファイル一覧の取得
+//	@Description	閲覧可能なタスクの添付ファイルを登録順に取得する。
+//	@Tags			attachment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			task_id			path		int		false	"タスクID"
+//	@Success		200				{object}	[]model.Attachment
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/attachments [get]
+func (h *attachmentHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	attachments, aerr := h.attachmentUsecase.ListByTaskID(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := []*model.Attachment{}
+	for _, attachment := range attachments {
+		res = append(res, model.UnmarshalAttachment(attachment))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// UploadAttachment
+//
+//	@Summary		添付ファイルのアップロード
+//	@Description	閲覧可能なタスクにファイルを添付する。ファイルの種類は内容から判定し、企業の設定で許可された種類・容量の上限・合計容量の上限を超えるものは登録できない。編集者のみ可能。
+//	@Tags			attachment
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string	false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			task_id			path		int		false	"タスクID"
+//	@Param			file			formData	file	true	"添付するファイル"
+//	@Success		200				{object}	integer	"登録された添付ファイルID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		413
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/attachments/upload [post]
+func (h *attachmentHandler) Upload(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	defer file.Close()
+
+	params, aerr := request.MarshalAttachmentUploadParams(authUserID, fileHeader, file)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.attachmentUsecase.Upload(domain.TaskIdentifier(taskID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// DownloadAttachment
+//
+//	@Summary		添付ファイルのダウンロード
+//	@Description	添付ファイルの実体を取得する。タスクを閲覧できるユーザのみ可能。
+//	@Tags			attachment
+//	@Produce		octet-stream
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			task_id			path	int		false	"タスクID"
+//	@Param			attachment_id	path	int		false	"添付ファイルID"
+//	@Success		200				{file}	binary
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/attachments/{attachment_id}/download [get]
+func (h *attachmentHandler) Download(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	attachment, body, aerr := h.attachmentUsecase.Download(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID), domain.AttachmentIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+	defer body.Close()
+
+	// ブラウザで開かれないよう、常にダウンロードとして扱う
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, body)
+}
+
+// DeleteAttachment
+//
+//	@Summary		添付ファイルの削除
+//	@Description	添付ファイルを削除する。編集者のみ可能。
+//	@Tags			attachment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			task_id			path	int		false	"タスクID"
+//	@Param			attachment_id	path	int		false	"添付ファイルID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/attachments/{attachment_id}/delete [delete]
+func (h *attachmentHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.attachmentUsecase.Delete(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID), domain.AttachmentIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
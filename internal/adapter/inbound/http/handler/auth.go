@@ -20,6 +20,7 @@ const jwtDuration = time.Hour * 24
 type AuthHandler interface {
 	Create(c echo.Context) error
 	Update(c echo.Context) error
+	Patch(c echo.Context) error
 	Login(c echo.Context) error
 }
 
@@ -43,12 +44,15 @@ func NewAuthHandler(
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string				false	"再送で重複して作成しないためのキー"
 //	@Param			company_id		path		int					false	"企業ID"
 //	@Param			body			body		request.AuthCreate	false	"ユーザ作成用リクエスト"
 //	@Success		200				{object}	integer				"登録されたユーザID"
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		409
+//	@Failure		422
 //	@Failure		500
 //	@Router			/company/{company_id}/user/create [post]
 func (h *authHandler) Create(c echo.Context) error {
@@ -58,8 +62,9 @@ func (h *authHandler) Create(c echo.Context) error {
 	}
 
 	// 会社の管理者権限をもつことの認証処理
+	var authUserID uint64
 	{
-		authUserID, err := verifyJwtToken(c)
+		authUserID, err = verifyJwtToken(c)
 		if err != nil {
 			return &echo.HTTPError{
 				Code:    http.StatusUnauthorized,
@@ -85,7 +90,7 @@ func (h *authHandler) Create(c echo.Context) error {
 		}
 	}
 
-	params, aerr := request.MarshalAuthCreateParams(companyID, req)
+	params, aerr := request.MarshalAuthCreateParams(companyID, authUserID, req)
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
@@ -101,11 +106,12 @@ func (h *authHandler) Create(c echo.Context) error {
 // UpdateUser
 //
 //	@Summary		ユーザの更新
-//	@Description	ユーザの情報を更新する。管理会社の管理者と企業の管理者に実行可能。
+//	@Description	ユーザの情報を更新する。管理会社の管理者と企業の管理者に実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header	string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string				false	"取得時の ETag"
 //	@Param			company_id		path	int					false	"企業ID"
 //	@Param			user_id			path	int					false	"ユーザID"
 //	@Param			body			body	request.AuthUpdate	false	"ユーザ更新用リクエスト"
@@ -113,6 +119,8 @@ func (h *authHandler) Create(c echo.Context) error {
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		412
+//	@Failure		428
 //	@Failure		500
 //	@Router			/company/{company_id}/user/{user_id}/update [post]
 func (h *authHandler) Update(c echo.Context) error {
@@ -161,6 +169,10 @@ func (h *authHandler) Update(c echo.Context) error {
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
+	params.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
 
 	if aerr = h.authUsecase.Update(domain.UserIdentifier(id), *params); aerr != nil {
 		return aerr.HTTPError()
@@ -169,6 +181,85 @@ func (h *authHandler) Update(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// PatchUser
+//
+//	@Summary		ユーザの部分更新
+//	@Description	JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名はユーザ更新用リクエストと同じで、含まれない項目は変更しない。いずれの項目も消去できない。管理会社の管理者と企業の管理者に実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string				false	"取得時の ETag"
+//	@Param			company_id		path	int					false	"企業ID"
+//	@Param			user_id			path	int					false	"ユーザID"
+//	@Param			body			body	request.AuthUpdate	false	"変更する項目のみを含むユーザ更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Failure		500
+//	@Router			/company/{company_id}/user/{user_id} [patch]
+func (h *authHandler) Patch(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	patch, err := bindMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	params, aerr := request.MarshalAuthPatchParams(patch)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+	params.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if aerr = h.authUsecase.Patch(domain.UserIdentifier(id), *params); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 // LoginUser
 //
 //	@Summary		ログイン
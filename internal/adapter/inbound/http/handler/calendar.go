@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CalendarHandler interface {
+	GetFeed(c echo.Context) error
+	RegenerateFeed(c echo.Context) error
+	Feed(c echo.Context) error
+}
+
+type calendarHandler struct {
+	authUsecase         usecase.AuthUsecase
+	calendarFeedUsecase usecase.CalendarFeedUsecase
+}
+
+func NewCalendarHandler(
+	authUsecase usecase.AuthUsecase,
+	calendarFeedUsecase usecase.CalendarFeedUsecase,
+) CalendarHandler {
+	return &calendarHandler{
+		authUsecase,
+		calendarFeedUsecase,
+	}
+}
+
+// GetCalendarFeed
+//
+//	@Summary		カレンダーの購読の URL の取得
+//	@Description	自身のタスクの期限をカレンダーのアプリで購読する URL を取得する。初めての場合はトークンを発行する。
+//	@Description	URL はトークンのみで認証するため、他人に知られた場合は発行し直す。
+//	@Tags			calendar
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.CalendarFeedLink
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/calendar [get]
+func (h *calendarHandler) GetFeed(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	link, aerr := h.calendarFeedUsecase.Get(domain.UserIdentifier(authUserID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalCalendarFeedLink(link)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// RegenerateCalendarFeed
+//
+//	@Summary		カレンダーの購読のトークンの再発行
+//	@Description	トークンを発行し直し、新しい URL を返す。以前の URL では取得できなくなる。
+//	@Tags			calendar
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.CalendarFeedLink
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/calendar/regenerate [post]
+func (h *calendarHandler) RegenerateFeed(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	link, aerr := h.calendarFeedUsecase.Regenerate(domain.UserIdentifier(authUserID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalCalendarFeedLink(link)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CalendarFeed
+//
+//	@Summary		カレンダーの購読
+//	@Description	トークンのユーザのタスクを iCalendar（RFC 5545）の形式で取得する。認証は不要で、トークンの末尾の .ics は省略できる。
+//	@Description	期限を DUE（vevent の場合は DTSTART）とし、ステータスの分類を STATUS に対応させる（vevent の場合、完了したタスクは CANCELLED）。トークンのユーザが閲覧できないタスクは含めない。
+//	@Description	絞り込みと並び順はタスク一覧と同じ指定ができ、URL に含めることで保存した絞り込みとして使える。
+//	@Tags			calendar
+//	@Produce		text/calendar
+//	@Param			token				path	string	true	"購読のトークン"
+//	@Param			scope				query	string	false	"範囲（assigned: 自身が担当するタスク（既定）, company: 企業の閲覧できるタスク）"	Enums(assigned, company)
+//	@Param			component			query	string	false	"タスクの表し方（vtodo: ToDo（既定）, vevent: 期限の予定。期限のないタスクは含めない）"	Enums(vtodo, vevent)
+//	@Param			status				query	string	false	"ステータス名（カンマ区切りで複数指定）"
+//	@Param			priority			query	string	false	"優先度名（カンマ区切りで複数指定）"
+//	@Param			start_date_from		query	string	false	"開始日の下限（RFC3339）"
+//	@Param			start_date_to		query	string	false	"開始日の上限（RFC3339）"
+//	@Param			limit_date_from		query	string	false	"期限の下限（RFC3339）"
+//	@Param			limit_date_to		query	string	false	"期限の上限（RFC3339）"
+//	@Param			label_ids			query	string	false	"ラベルID（カンマ区切りで複数指定）"
+//	@Param			label_match			query	string	false	"ラベルの一致方法（any: いずれか, all: すべて）"	Enums(any, all)
+//	@Param			parent_id			query	int		false	"親タスクID（指定した親タスクの子タスクのみ）"
+//	@Param			sort				query	string	false	"並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）"
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/calendar/{token} [get]
+func (h *calendarHandler) Feed(c echo.Context) error {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	var req request.CalendarFeed
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	component := strings.ToLower(req.Component)
+	if component == "" {
+		component = model.CalendarComponentTodo
+	}
+	if component != model.CalendarComponentTodo && component != model.CalendarComponentEvent {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: "component must be vtodo or vevent",
+		}
+	}
+
+	params, aerr := request.MarshalCalendarFeedParams(&req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	tasks, aerr := h.calendarFeedUsecase.ListTasks(token, *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="tasks.ics"`)
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", []byte(model.UnmarshalCalendar(tasks, component, time.Now())))
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommentHandler interface {
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type commentHandler struct {
+	authUsecase    usecase.AuthUsecase
+	commentUsecase usecase.CommentUsecase
+}
+
+func NewCommentHandler(
+	authUsecase usecase.AuthUsecase,
+	commentUsecase usecase.CommentUsecase,
+) CommentHandler {
+	return &commentHandler{
+		authUsecase,
+		commentUsecase,
+	}
+}
+
+// ListComment
+//
+//	@Summary		コメント一覧の取得
+//	@Description	閲覧可能なタスクのコメントを投稿順に取得する。削除されたコメントは含まない。
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			task_id			path		int		false	"タスクID"
+//	@Param			page			query		int		false	"ページ番号（1始まり）"
+//	@Param			per_page		query		int		false	"1ページの件数（既定20、最大100）"
+//	@Success		200				{object}	model.CommentPage
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/comments [get]
+func (h *commentHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req request.CommentList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	page := request.MarshalCommentListPage(&req)
+
+	comments, total, aerr := h.commentUsecase.ListByTaskID(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID), page)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalCommentPage(comments, total, page)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateComment
+//
+//	@Summary		コメントの投稿
+//	@Description	閲覧可能なタスクにコメントを投稿する。本文中の @ユーザ名 はタスクを閲覧できる同じ企業のユーザへのメンションとなる。編集者のみ可能。
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string					false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int						false	"企業ID"
+//	@Param			task_id			path		int						false	"タスクID"
+//	@Param			body			body		request.CommentCreate	false	"コメント投稿用リクエスト"
+//	@Success		200				{object}	integer					"登録されたコメントID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/comments/create [post]
+func (h *commentHandler) Create(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.CommentCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalCommentCreateParams(authUserID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.commentUsecase.Create(domain.TaskIdentifier(taskID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// UpdateComment
+//
+//	@Summary		コメントの編集
+//	@Description	コメントの本文を編集する。コメントの投稿者のみ可能。
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int						false	"企業ID"
+//	@Param			task_id			path	int						false	"タスクID"
+//	@Param			comment_id		path	int						false	"コメントID"
+//	@Param			body			body	request.CommentUpdate	false	"コメント編集用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/comments/{comment_id}/update [put]
+func (h *commentHandler) Update(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.CommentUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalCommentUpdateParams(authUserID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.commentUsecase.Update(domain.TaskIdentifier(taskID), domain.CommentIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteComment
+//
+//	@Summary		コメントの削除
+//	@Description	コメントを削除する。コメントの投稿者のみ可能。
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			task_id			path	int		false	"タスクID"
+//	@Param			comment_id		path	int		false	"コメントID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/comments/{comment_id}/delete [delete]
+func (h *commentHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.commentUsecase.Delete(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID), domain.CommentIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -15,6 +15,7 @@ type CompanyHandler interface {
 	Get(c echo.Context) error
 	Create(c echo.Context) error
 	Update(c echo.Context) error
+	Patch(c echo.Context) error
 }
 
 type companyHandler struct {
@@ -35,13 +36,16 @@ func NewCompanyHandler(
 // GetCompany
 //
 //	@Summary		企業の取得
-//	@Description	企業の情報をIDから取得する。管理会社のユーザのみ実行可能。
+//	@Description	企業の情報をIDから取得する。管理会社のユーザのみ実行可能。企業の版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。
 //	@Tags			company
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-None-Match	header		string	false	"取得済みの ETag"
 //	@Param			company_id		path		int		false	"企業ID"
 //	@Success		200				{object}	model.Company
+//	@Header			200				{string}	ETag	"企業の版数"
+//	@Success		304
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
@@ -86,7 +90,7 @@ func (h *companyHandler) Get(c echo.Context) error {
 
 	res := model.UnmarshalCompany(company)
 
-	return c.JSON(http.StatusOK, res)
+	return respondWithETag(c, company.Version, res)
 }
 
 // CreateCompany
@@ -97,11 +101,14 @@ func (h *companyHandler) Get(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string					false	"再送で重複して作成しないためのキー"
 //	@Param			body			body		request.CompanyCreate	false	"企業作成用リクエスト"
 //	@Success		200				{object}	integer
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		409
+//	@Failure		422
 //	@Failure		500
 //	@Router			/company/create [post]
 func (h *companyHandler) Create(c echo.Context) error {
@@ -144,17 +151,20 @@ func (h *companyHandler) Create(c echo.Context) error {
 // UpdateCompany
 //
 //	@Summary		企業の更新
-//	@Description	企業を作成する。管理会社の管理者のみ実行可能。
+//	@Description	企業名を更新する。管理会社の管理者のみ実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
 //	@Tags			company
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string					false	"取得時の ETag"
 //	@Param			company_id		path	int						false	"企業ID"
 //	@Param			body			body	request.CompanyUpdate	false	"企業更新用リクエスト"
 //	@Success		200				
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		412
+//	@Failure		428
 //	@Failure		500
 //	@Router			/company/{company_id}/update [put]
 func (h *companyHandler) Update(c echo.Context) error {
@@ -194,10 +204,86 @@ func (h *companyHandler) Update(c echo.Context) error {
 		}
 	}
 
-	aerr := h.companyUsecase.Update(domain.CompanyIdentifier(id), req.Name)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	aerr := h.companyUsecase.Update(domain.CompanyIdentifier(id), req.Name, version)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// PatchCompany
+//
+//	@Summary		企業の部分更新
+//	@Description	JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名は企業更新用リクエストと同じで、含まれない項目は変更しない。企業名は消去できない。管理会社の管理者のみ実行可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string					false	"取得時の ETag"
+//	@Param			company_id		path	int						false	"企業ID"
+//	@Param			body			body	request.CompanyUpdate	false	"変更する項目のみを含む企業更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Failure		500
+//	@Router			/company/{company_id} [patch]
+func (h *companyHandler) Patch(c echo.Context) error {
+	// 管理会社の管理者であることの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.IsSuperAdmin() {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	patch, err := bindMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	name, aerr := request.MarshalCompanyPatchName(patch)
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
 
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if aerr = h.companyUsecase.Patch(domain.CompanyIdentifier(id), name, version); aerr != nil {
+		return aerr.HTTPError()
+	}
+
 	return c.NoContent(http.StatusOK)
 }
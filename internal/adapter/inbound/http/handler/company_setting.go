@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CompanySettingHandler interface {
+	Get(c echo.Context) error
+	Update(c echo.Context) error
+}
+
+type companySettingHandler struct {
+	authUsecase           usecase.AuthUsecase
+	companySettingUsecase usecase.CompanySettingUsecase
+}
+
+func NewCompanySettingHandler(
+	authUsecase usecase.AuthUsecase,
+	companySettingUsecase usecase.CompanySettingUsecase,
+) CompanySettingHandler {
+	return &companySettingHandler{
+		authUsecase,
+		companySettingUsecase,
+	}
+}
+
+// GetCompanySetting
+//
+//	@Summary		企業の設定の取得
+//	@Description	企業のタスクに関する設定を取得する。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.CompanySetting
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/setting [get]
+func (h *companySettingHandler) Get(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	setting, aerr := h.companySettingUsecase.Get(domain.CompanyIdentifier(companyID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalCompanySetting(setting)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// UpdateCompanySetting
+//
+//	@Summary		企業の設定の更新
+//	@Description	サブタスクの階層の深さの上限、未完了の子タスクがある親タスクの完了を禁止するか、添付ファイルの容量の上限・許可する種類・企業の合計容量の上限を更新する。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int								false	"企業ID"
+//	@Param			body			body	request.CompanySettingUpdate	false	"企業の設定更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/setting/update [put]
+func (h *companySettingHandler) Update(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.CompanySettingUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalCompanySettingUpdateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.companySettingUsecase.Update(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	domain "todo_api/internal/domain/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireIfMatch / 更新時に If-Match ヘッダを必須とするかどうか。有効な場合、指定のない更新は 428 とする。
+var RequireIfMatch = false
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// formatETag / 版数を強い ETag の形式にする
+func formatETag(version domain.Version) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// respondWithETag / 版数を ETag として返す。If-None-Match が一致する場合は本文を返さずに 304 とする。
+func respondWithETag(c echo.Context, version domain.Version, res interface{}) error {
+	etag := formatETag(version)
+	c.Response().Header().Set(headerETag, etag)
+	if matchesIfNoneMatch(c.Request().Header.Get(headerIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// matchesIfNoneMatch / If-None-Match のいずれかの ETag と一致するか。弱い比較を行う。
+func matchesIfNoneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch / If-Match から更新の前提とする版数を取り出す。単一の ETag のみに対応する。
+// 未指定または * の場合は nil を返す。弱い ETag など版数として解釈できない値は一致しないものとして 412 とする。
+func parseIfMatch(c echo.Context) (*domain.Version, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if header == "" {
+		if RequireIfMatch {
+			return nil, &echo.HTTPError{
+				Code:    http.StatusPreconditionRequired,
+				Message: "If-Match header is required",
+			}
+		}
+		return nil, nil
+	}
+	if header == "*" {
+		return nil, nil
+	}
+
+	if len(header) >= 2 && strings.HasPrefix(header, `"`) && strings.HasSuffix(header, `"`) {
+		if v, err := strconv.ParseUint(header[1:len(header)-1], 10, 64); err == nil {
+			version := domain.Version(v)
+			return &version, nil
+		}
+	}
+	return nil, &echo.HTTPError{
+		Code:    http.StatusPreconditionFailed,
+		Message: "If-Match does not match the current version",
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	// idempotencyMemoryBodySize / 本文をメモリに保持する上限。超える本文は一時ファイルに書き出す
+	idempotencyMemoryBodySize = 1 << 20
+)
+
+// Idempotency / Idempotency-Key が指定されたリクエストの最初のレスポンスをユーザとキーごとに記録し、再送には記録したレスポンスを返す。
+// 同じキーで異なるリクエストが送られた場合は 422、最初のリクエストが処理中の場合は 409 とする。
+// 5xx のレスポンスも記録する。変更をコミットした後に失敗した場合に、再試行で重複して作成しないためである。
+// 本文はハッシュを求めながら読み、添付ファイルや取り込みのような大きな本文は一時ファイルに書き出してハンドラに渡す。
+// JWT の検証の後、BodyLimit の内側に適用すること。
+func Idempotency(idempotencyUsecase usecase.IdempotencyUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(headerIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			authUserID, err := verifyJwtToken(c)
+			if err != nil {
+				return &echo.HTTPError{
+					Code:    http.StatusUnauthorized,
+					Message: err.Error(),
+				}
+			}
+
+			body := &spooledBody{}
+			defer body.Close()
+			hash, err := hashRequest(c.Request(), body)
+			if err != nil {
+				// BodyLimit の上限を超えた場合は 413 とする
+				if herr, ok := err.(*echo.HTTPError); ok {
+					return herr
+				}
+				return &echo.HTTPError{
+					Code:    http.StatusBadRequest,
+					Message: err.Error(),
+				}
+			}
+			if c.Request().Body, err = body.Reader(); err != nil {
+				return &echo.HTTPError{
+					Code:    http.StatusInternalServerError,
+					Message: err.Error(),
+				}
+			}
+
+			record, aerr := idempotencyUsecase.Begin(domain.UserIdentifier(authUserID), key, hash)
+			if aerr != nil {
+				return aerr.HTTPError()
+			}
+			if record.IsCompleted() {
+				c.Response().Header().Set(headerIdempotentReplayed, "true")
+				return c.Blob(record.StatusCode, record.ContentType, record.Body)
+			}
+
+			// 処理中に panic した場合もコミット済みの可能性があるため、500 として記録する
+			defer func() {
+				if r := recover(); r != nil {
+					body := []byte(`{"message":"Internal Server Error"}`)
+					_ = idempotencyUsecase.Complete(record, http.StatusInternalServerError, echo.MIMEApplicationJSON, body)
+					panic(r)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if err := next(c); err != nil {
+				// エラーのレスポンスも記録するため、ここでエラーハンドラに渡す
+				c.Error(err)
+			}
+
+			res := c.Response()
+			if aerr = idempotencyUsecase.Complete(record, res.Status, res.Header().Get(echo.HeaderContentType), recorder.body.Bytes()); aerr != nil {
+				c.Logger().Error(aerr.Message())
+			}
+			return nil
+		}
+	}
+}
+
+// hashRequest / 同じキーで異なるリクエストが送られたことを検出するため、メソッド・パス・本文のハッシュを求める。
+// 読んだ本文は body に保持する。
+func hashRequest(req *http.Request, body *spooledBody) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(req.URL.Path))
+	h.Write([]byte{'\n'})
+	if _, err := io.Copy(io.MultiWriter(h, body), req.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// spooledBody / 読んだ本文を保持する。idempotencyMemoryBodySize まではメモリに、超えた分からは一時ファイルに書き出す。
+type spooledBody struct {
+	buf  bytes.Buffer
+	file *os.File
+}
+
+func (b *spooledBody) Write(p []byte) (int, error) {
+	if b.file == nil && b.buf.Len()+len(p) <= idempotencyMemoryBodySize {
+		return b.buf.Write(p)
+	}
+	if b.file == nil {
+		file, err := os.CreateTemp("", "idempotency-body-*")
+		if err != nil {
+			return 0, err
+		}
+		b.file = file
+		if _, err := b.file.Write(b.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		b.buf.Reset()
+	}
+	return b.file.Write(p)
+}
+
+// Reader / 保持した本文を先頭から読む。一時ファイルは Close で削除するため、読み手が閉じても削除しない。
+func (b *spooledBody) Reader() (io.ReadCloser, error) {
+	if b.file == nil {
+		return io.NopCloser(bytes.NewReader(b.buf.Bytes())), nil
+	}
+	if _, err := b.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(b.file), nil
+}
+
+// Close / 一時ファイルを削除する
+func (b *spooledBody) Close() error {
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	return os.Remove(b.file.Name())
+}
+
+// responseRecorder / クライアントに書き込みながらレスポンスの本文を保持する
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LabelHandler interface {
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type labelHandler struct {
+	authUsecase  usecase.AuthUsecase
+	labelUsecase usecase.LabelUsecase
+}
+
+func NewLabelHandler(
+	authUsecase usecase.AuthUsecase,
+	labelUsecase usecase.LabelUsecase,
+) LabelHandler {
+	return &labelHandler{
+		authUsecase,
+		labelUsecase,
+	}
+}
+
+// ListLabel
+//
+//	@Summary		ラベル一覧の取得
+//	@Description	企業のラベルの一覧を取得する。
+//	@Tags			label
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Success		200				{array}	model.Label
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/label/list [get]
+func (h *labelHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	labels, aerr := h.labelUsecase.ListByCompanyID(domain.CompanyIdentifier(companyID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := []*model.Label{}
+	for _, label := range labels {
+		res = append(res, model.UnmarshalLabel(label))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateLabel
+//
+//	@Summary		ラベルの作成
+//	@Description	企業のラベルを作成する。color は #RRGGBB 形式。編集者のみ可能。
+//	@Tags			label
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string						false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int							false	"企業ID"
+//	@Param			body			body		request.LabelCreate	false	"ラベル作成用リクエスト"
+//	@Success		200				{object}	integer						"登録されたラベルID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/label/create [post]
+func (h *labelHandler) Create(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.LabelCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalLabelCreateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.labelUsecase.Create(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// UpdateLabel
+//
+//	@Summary		ラベルの更新
+//	@Description	企業のラベルの名前と色を更新する。編集者のみ可能。
+//	@Tags			label
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int							false	"企業ID"
+//	@Param			label_id		path	int							false	"ラベルID"
+//	@Param			body			body	request.LabelUpdate	false	"ラベル更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/label/{label_id}/update [put]
+func (h *labelHandler) Update(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("label_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.LabelUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalLabelUpdateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.labelUsecase.Update(domain.CompanyIdentifier(companyID), domain.LabelIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteLabel
+//
+//	@Summary		ラベルの削除
+//	@Description	企業のラベルを削除する。タスクに付与されているラベルはタスクから外され、タスクの変更として履歴と差分の同期に記録される。編集者のみ可能。
+//	@Tags			label
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			label_id		path	int		false	"ラベルID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/label/{label_id}/delete [delete]
+func (h *labelHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("label_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.labelUsecase.Delete(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), domain.LabelIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler interface {
+	List(c echo.Context) error
+	CountUnread(c echo.Context) error
+	Read(c echo.Context) error
+	ReadAll(c echo.Context) error
+	GetPreference(c echo.Context) error
+	UpdatePreference(c echo.Context) error
+	Unsubscribe(c echo.Context) error
+}
+
+type notificationHandler struct {
+	authUsecase         usecase.AuthUsecase
+	notificationUsecase usecase.NotificationUsecase
+	mailUsecase         usecase.MailUsecase
+}
+
+func NewNotificationHandler(
+	authUsecase usecase.AuthUsecase,
+	notificationUsecase usecase.NotificationUsecase,
+	mailUsecase usecase.MailUsecase,
+) NotificationHandler {
+	return &notificationHandler{
+		authUsecase,
+		notificationUsecase,
+		mailUsecase,
+	}
+}
+
+// ListNotification
+//
+//	@Summary		通知一覧の取得
+//	@Description	自身の通知を未読を先に新しい順で取得する。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			page			query		int		false	"ページ番号（1始まり）"
+//	@Param			per_page		query		int		false	"1ページの件数（既定20、最大100）"
+//	@Success		200				{object}	model.NotificationPage
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/notification [get]
+func (h *notificationHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req request.NotificationList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	page := request.MarshalNotificationListPage(&req)
+
+	notifications, total, unread, aerr := h.notificationUsecase.List(domain.UserIdentifier(authUserID), page)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalNotificationPage(notifications, total, unread, page)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CountUnreadNotification
+//
+//	@Summary		未読の通知の件数の取得
+//	@Description	自身の未読の通知の件数を取得する。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.NotificationUnreadCount
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/notification/unread_count [get]
+func (h *notificationHandler) CountUnread(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	unread, aerr := h.notificationUsecase.CountUnread(domain.UserIdentifier(authUserID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := &model.NotificationUnreadCount{Unread: unread}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ReadNotification
+//
+//	@Summary		通知の既読化
+//	@Description	自身の通知を既読にする。既読の通知の既読日時は変更しない。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			notification_id	path	int		false	"通知ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/notification/{notification_id}/read [put]
+func (h *notificationHandler) Read(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("notification_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	if aerr := h.notificationUsecase.Read(domain.UserIdentifier(authUserID), domain.NotificationIdentifier(id)); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ReadAllNotification
+//
+//	@Summary		すべての通知の既読化
+//	@Description	自身の未読の通知をすべて既読にする。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/notification/read_all [put]
+func (h *notificationHandler) ReadAll(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	if aerr := h.notificationUsecase.ReadAll(domain.UserIdentifier(authUserID)); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetNotificationPreference
+//
+//	@Summary		通知の設定の取得
+//	@Description	自身の通知の種類ごとの設定を取得する。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.NotificationPreference
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/notification/preference [get]
+func (h *notificationHandler) GetPreference(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	preference, aerr := h.notificationUsecase.GetPreference(domain.UserIdentifier(authUserID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalNotificationPreference(preference)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// UpdateNotificationPreference
+//
+//	@Summary		通知の設定の更新
+//	@Description	自身の通知の種類ごとの設定と、メールの送り先・言語・送り方を更新する。指定のない項目は変更しない。メールを送るにはメールアドレスが必要。
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int									false	"企業ID"
+//	@Param			body			body	request.NotificationPreferenceUpdate	false	"通知の設定更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/notification/preference/update [put]
+func (h *notificationHandler) UpdatePreference(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.NotificationPreferenceUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalNotificationPreferenceParams(authUserID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	if aerr = h.notificationUsecase.UpdatePreference(*params); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// UnsubscribeNotification
+//
+//	@Summary		メールの配信停止
+//	@Description	通知のメールに記載された配信停止のURL。トークンのユーザへのメールを停止する。認証は不要。
+//	@Tags			notification
+//	@Produce		plain
+//	@Param			token	query	string	true	"配信停止のトークン"
+//	@Success		200
+//	@Failure		404
+//	@Failure		500
+//	@Router			/notification/unsubscribe [get]
+//	@Router			/notification/unsubscribe [post]
+func (h *notificationHandler) Unsubscribe(c echo.Context) error {
+	if aerr := h.mailUsecase.Unsubscribe(c.QueryParam("token")); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.String(http.StatusOK, "Unsubscribed")
+}
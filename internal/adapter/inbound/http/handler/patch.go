@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"todo_api/internal/adapter/inbound/http/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+const mimeApplicationMergePatchJSON = "application/merge-patch+json"
+
+// bindMergePatch / 本文を JSON Merge Patch として読み込む。Content-Type は application/merge-patch+json か application/json とする。
+func bindMergePatch(c echo.Context) (request.MergePatch, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != mimeApplicationMergePatchJSON && mediaType != echo.MIMEApplicationJSON {
+		return nil, &echo.HTTPError{
+			Code:    http.StatusUnsupportedMediaType,
+			Message: "Content-Type must be " + mimeApplicationMergePatchJSON,
+		}
+	}
+	patch, aerr := request.ParseMergePatch(c.Request().Body)
+	if aerr != nil {
+		return nil, aerr.HTTPError()
+	}
+	return patch, nil
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"net/http"
 	"strconv"
-	"strings"
 	"todo_api/internal/adapter/inbound/http/model"
 	"todo_api/internal/adapter/inbound/http/request"
 	domain "todo_api/internal/domain/model"
@@ -18,7 +17,11 @@ type TaskHandler interface {
 	ListByCompanyID(c echo.Context) error
 	Create(c echo.Context) error
 	Update(c echo.Context) error
+	Patch(c echo.Context) error
 	UpdateStatus(c echo.Context) error
+	Bulk(c echo.Context) error
+	SetRecurrence(c echo.Context) error
+	DeleteRecurrence(c echo.Context) error
 }
 
 type taskHandler struct {
@@ -39,14 +42,17 @@ func NewTaskHandler(
 // FindTask
 //
 //	@Summary		タスクの取得
-//	@Description	閲覧可能なタスクの情報をIDから取得する。
+//	@Description	閲覧可能なタスクの情報をIDから取得する。タスクの版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。
 //	@Tags			task
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-None-Match	header		string	false	"取得済みの ETag"
 //	@Param			company_id		path		int		false	"企業ID"
 //	@Param			task_id			path		int		false	"タスクID"
 //	@Success		200				{object}	model.Task
+//	@Header			200				{string}	ETag	"タスクの版数"
+//	@Success		304
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
@@ -97,19 +103,29 @@ func (h *taskHandler) Find(c echo.Context) error {
 
 	res := model.UnmarshalTask(task)
 
-	return c.JSON(http.StatusOK, res)
+	return respondWithETag(c, task.Version, res)
 }
 
 // ListTaskByAssignedUserID
 //
 //	@Summary		ユーザに割り当てられたタスク一覧の取得
-//	@Description	閲覧可能なタスクの一覧を割り当てユーザIDから取得する。
+//	@Description	閲覧可能なタスクの一覧を割り当てユーザIDから取得する。主担当者に限らず、いずれかの担当者であるタスクを含む。
 //	@Tags			task
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization		header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			company_id			path	int		false	"企業ID"
 //	@Param			assigned_user_id	path	int		false	"ユーザID"
+//	@Param			status				query	string	false	"ステータス名（カンマ区切りで複数指定）"
+//	@Param			priority			query	string	false	"優先度名（カンマ区切りで複数指定）"
+//	@Param			start_date_from		query	string	false	"開始日の下限（RFC3339）"
+//	@Param			start_date_to		query	string	false	"開始日の上限（RFC3339）"
+//	@Param			limit_date_from		query	string	false	"期限の下限（RFC3339）"
+//	@Param			limit_date_to		query	string	false	"期限の上限（RFC3339）"
+//	@Param			label_ids			query	string	false	"ラベルID（カンマ区切りで複数指定）"
+//	@Param			label_match			query	string	false	"ラベルの一致方法（any: いずれか, all: すべて）"	Enums(any, all)
+//	@Param			parent_id			query	int		false	"親タスクID（指定した親タスクの子タスクのみ）"
+//	@Param			sort				query	string	false	"並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）"
 //	@Success		200					{array}	model.Task
 //	@Failure		400
 //	@Failure		401
@@ -154,7 +170,20 @@ func (h *taskHandler) ListByAssignedUserID(c echo.Context) error {
 		}
 	}
 
-	tasks, aerr := h.taskUsecase.ListByAssignedUserID(domain.UserIdentifier(authUserID), domain.UserIdentifier(assignedUserID))
+	var req request.TaskList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskListParams(&req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	tasks, aerr := h.taskUsecase.ListByAssignedUserID(domain.UserIdentifier(authUserID), domain.UserIdentifier(assignedUserID), *params)
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
@@ -176,6 +205,16 @@ func (h *taskHandler) ListByAssignedUserID(c echo.Context) error {
 //	@Produce		json
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Param			company_id		path	int		false	"企業ID"
+//	@Param			status				query	string	false	"ステータス名（カンマ区切りで複数指定）"
+//	@Param			priority			query	string	false	"優先度名（カンマ区切りで複数指定）"
+//	@Param			start_date_from		query	string	false	"開始日の下限（RFC3339）"
+//	@Param			start_date_to		query	string	false	"開始日の上限（RFC3339）"
+//	@Param			limit_date_from		query	string	false	"期限の下限（RFC3339）"
+//	@Param			limit_date_to		query	string	false	"期限の上限（RFC3339）"
+//	@Param			label_ids			query	string	false	"ラベルID（カンマ区切りで複数指定）"
+//	@Param			label_match			query	string	false	"ラベルの一致方法（any: いずれか, all: すべて）"	Enums(any, all)
+//	@Param			parent_id			query	int		false	"親タスクID（指定した親タスクの子タスクのみ）"
+//	@Param			sort				query	string	false	"並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）"
 //	@Success		200				{array}	model.Task
 //	@Failure		400
 //	@Failure		401
@@ -212,7 +251,20 @@ func (h *taskHandler) ListByCompanyID(c echo.Context) error {
 		}
 	}
 
-	tasks, aerr := h.taskUsecase.ListByCompanyID(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID))
+	var req request.TaskList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskListParams(&req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	tasks, aerr := h.taskUsecase.ListByCompanyID(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), *params)
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
@@ -228,17 +280,20 @@ func (h *taskHandler) ListByCompanyID(c echo.Context) error {
 // CreateTask
 //
 //	@Summary		タスクの作成
-//	@Description	タスクを作成する。編集者のみ可能。
+//	@Description	タスクを作成する。parent_id を指定すると同じ企業のタスクのサブタスクとなる。担当者（assignee_ids）とウォッチャー（watcher_ids）は同じ企業のユーザのみ指定できる。編集者のみ可能。
 //	@Tags			task
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string				false	"再送で重複して作成しないためのキー"
 //	@Param			company_id		path		int					false	"企業ID"
 //	@Param			body			body		request.TaskCreate	false	"タスク作成用リクエスト"
 //	@Success		200				{object}	integer
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		409
+//	@Failure		422
 //	@Failure		500
 //	@Router			/company/{company_id}/task/create [post]
 func (h *taskHandler) Create(c echo.Context) error {
@@ -295,18 +350,22 @@ func (h *taskHandler) Create(c echo.Context) error {
 // UpdateTask
 //
 //	@Summary		タスクの更新
-//	@Description	タスクの情報を更新する。編集者のみ可能。
+//	@Description	閲覧できる自社のタスクの情報を更新する。編集者のみ可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
 //	@Tags			task
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header		string				false	"取得時の ETag"
 //	@Param			company_id		path		int					false	"企業ID"
 //	@Param			task_id			path		int					false	"タスクID"
+//	@Param			scope			query		string				false	"繰り返しのタスクの編集の範囲（this: この回のみ, future: 以降のすべての回）"	Enums(this, future)
 //	@Param			body			body		request.TaskUpdate	false	"タスク更新用リクエスト"
 //	@Success		200				{object}	integer
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		412
+//	@Failure		428
 //	@Failure		500
 //	@Router			/company/{company_id}/task/{task_id}/update [put]
 func (h *taskHandler) Update(c echo.Context) error {
@@ -359,6 +418,14 @@ func (h *taskHandler) Update(c echo.Context) error {
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
+	params.CompanyID = domain.CompanyIdentifier(companyID)
+	if params.Scope, aerr = request.MarshalTaskEditScope(c.QueryParam("scope")); aerr != nil {
+		return aerr.HTTPError()
+	}
+	params.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
 
 	aerr = h.taskUsecase.Update(domain.TaskIdentifier(id), *params)
 	if aerr != nil {
@@ -368,21 +435,110 @@ func (h *taskHandler) Update(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// PatchTask
+//
+//	@Summary		タスクの部分更新
+//	@Description	JSON Merge Patch (RFC 7396) で指定した項目のみを更新する。項目名はタスク更新用リクエストと同じで、含まれない項目は変更せず、null の項目は値を消去する。title・visibility・status は消去できない。閲覧できる自社のタスクのみ更新でき、編集者のみ可能。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string				false	"取得時の ETag"
+//	@Param			company_id		path	int					false	"企業ID"
+//	@Param			task_id			path	int					false	"タスクID"
+//	@Param			scope			query	string				false	"繰り返しのタスクの編集の範囲（this: この回のみ, future: 以降のすべての回）"	Enums(this, future)
+//	@Param			body			body	request.TaskUpdate	false	"変更する項目のみを含むタスク更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id} [patch]
+func (h *taskHandler) Patch(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	patch, err := bindMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	params, aerr := request.MarshalTaskPatchParams(authUserID, patch)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+	params.CompanyID = domain.CompanyIdentifier(companyID)
+	if params.Scope, aerr = request.MarshalTaskEditScope(c.QueryParam("scope")); aerr != nil {
+		return aerr.HTTPError()
+	}
+	params.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if aerr = h.taskUsecase.Patch(domain.TaskIdentifier(id), *params); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 // UpdateTaskStatus
 //
 //	@Summary		タスクステータスの更新
-//	@Description	タスクのステータスを指定した状態へと更新する。編集者のみ可能。
+//	@Description	閲覧できる自社のタスクのステータスを指定した状態へと更新する。編集者のみ可能。企業のワークフローで許可された遷移のみ可能。企業の設定により、未完了の子タスクがある場合は完了にできない。未完了のブロッカーがある場合は force を指定しない限り開始・完了できない。If-Match に取得時の ETag を指定すると、他の更新があった場合は 412 を返す。
 //	@Tags			task
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-Match		header	string	false	"取得時の ETag"
 //	@Param			company_id		path	int		false	"企業ID"
 //	@Param			task_id			path	int		false	"タスクID"
 //	@Param			task_status		path	string	false	"タスクステータス"
+//	@Param			force			query	bool	false	"未完了のブロッカーがあっても開始・完了する"
 //	@Success		200
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
+//	@Failure		412
+//	@Failure		428
 //	@Failure		500
 //	@Router			/company/{company_id}/task/{task_id}/status/{task_status} [put]
 func (h *taskHandler) UpdateStatus(c echo.Context) error {
@@ -423,12 +579,237 @@ func (h *taskHandler) UpdateStatus(c echo.Context) error {
 		}
 	}
 
-	status, aerr := request.MarshalTaskStatus(strings.ToUpper(c.Param("status")))
+	params, aerr := request.MarshalTaskUpdateStatusParams(authUserID, c.Param("status"), c.QueryParam("force"))
 	if aerr != nil {
 		return aerr.HTTPError()
 	}
+	params.CompanyID = domain.CompanyIdentifier(companyID)
+	params.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if aerr := h.taskUsecase.UpdateStatus(domain.TaskIdentifier(id), *params); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// BulkTask
+//
+//	@Summary		タスクの一括操作
+//	@Description	複数のタスクにステータスの変更、担当者の変更、ラベルの付与と除去、公開範囲の変更、削除を適用する。
+//	@Description	項目ごとに個別の操作と同じく閲覧できる自社のタスクであることを検証する。項目は最大100件。
+//	@Description	mode が atomic の場合はいずれかの項目が失敗するとすべて保存せず、最初に失敗した項目のステータスコードを返す。
+//	@Description	mode が best_effort の場合は成功した項目のみ保存し、200 で項目ごとの結果を返す。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int					false	"企業ID"
+//	@Param			body			body		request.TaskBulk	false	"一括操作用リクエスト"
+//	@Success		200				{object}	model.TaskBulkResult
+//	@Failure		400				{object}	model.TaskBulkResult
+//	@Failure		401
+//	@Failure		403				{object}	model.TaskBulkResult
+//	@Failure		404				{object}	model.TaskBulkResult
+//	@Failure		412
+//	@Failure		500
+//	@Router			/company/{company_id}/task/bulk [post]
+func (h *taskHandler) Bulk(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.TaskBulk
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskBulkParams(authUserID, companyID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	result, aerr := h.taskUsecase.Bulk(*params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalTaskBulkResult(result)
+
+	// すべて保存しなかった場合は失敗した項目のステータスコードで応答する
+	if failed := result.Failed(); !result.Applied && failed != nil {
+		return c.JSON(failed.Err.HTTPError().Code, res)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// SetTaskRecurrence
+//
+//	@Summary		タスクの繰り返しの設定
+//	@Description	タスクを期限を基準に繰り返すようにする。期限のないタスクは繰り返せない。既に繰り返すタスクの場合は以降に生成する回の規則を置き換える。
+//	@Description	次の回はタスクの完了時、または期限を過ぎた時に期限をずらして生成される。編集者のみ可能。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int						false	"企業ID"
+//	@Param			task_id			path	int						false	"タスクID"
+//	@Param			body			body	request.TaskRecurrence	false	"繰り返しの規則"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		412
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/recurrence/update [put]
+func (h *taskHandler) SetRecurrence(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.TaskRecurrence
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskRecurrenceParams(authUserID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	if aerr = h.taskUsecase.SetRecurrence(domain.TaskIdentifier(id), *params); aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteTaskRecurrence
+//
+//	@Summary		タスクの繰り返しの終了
+//	@Description	タスクの属する系列の繰り返しを終了する。生成済みの回は繰り返さないタスクとして残る。編集者のみ可能。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			task_id			path	int		false	"タスクID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		412
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/recurrence/delete [delete]
+func (h *taskHandler) DeleteRecurrence(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
 
-	if aerr = h.taskUsecase.UpdateStatus(domain.TaskIdentifier(id), *status); aerr != nil {
+	if aerr := h.taskUsecase.DeleteRecurrence(domain.UserIdentifier(authUserID), domain.TaskIdentifier(id)); aerr != nil {
 		return aerr.HTTPError()
 	}
 
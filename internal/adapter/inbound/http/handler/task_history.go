@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaskHistoryHandler interface {
+	List(c echo.Context) error
+}
+
+type taskHistoryHandler struct {
+	authUsecase        usecase.AuthUsecase
+	taskHistoryUsecase usecase.TaskHistoryUsecase
+}
+
+func NewTaskHistoryHandler(
+	authUsecase usecase.AuthUsecase,
+	taskHistoryUsecase usecase.TaskHistoryUsecase,
+) TaskHistoryHandler {
+	return &taskHistoryHandler{
+		authUsecase,
+		taskHistoryUsecase,
+	}
+}
+
+// ListTaskHistory
+//
+//	@Summary		タスクの変更履歴の取得
+//	@Description	閲覧可能なタスクの項目ごとの変更履歴（変更者・日時・項目・変更前後の値）を古い順に取得する。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			task_id			path		int		false	"タスクID"
+//	@Param			page			query		int		false	"ページ番号（1始まり）"
+//	@Param			per_page		query		int		false	"1ページの件数（既定20、最大100）"
+//	@Success		200				{object}	model.TaskHistoryPage
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/history [get]
+func (h *taskHistoryHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req request.TaskHistoryList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	page := request.MarshalTaskHistoryListPage(&req)
+
+	histories, total, aerr := h.taskHistoryUsecase.ListByTaskID(domain.UserIdentifier(authUserID), domain.TaskIdentifier(taskID), page)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalTaskHistoryPage(histories, total, page)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaskLinkHandler interface {
+	GetDependencies(c echo.Context) error
+	Create(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type taskLinkHandler struct {
+	authUsecase     usecase.AuthUsecase
+	taskLinkUsecase usecase.TaskLinkUsecase
+}
+
+func NewTaskLinkHandler(
+	authUsecase usecase.AuthUsecase,
+	taskLinkUsecase usecase.TaskLinkUsecase,
+) TaskLinkHandler {
+	return &taskLinkHandler{
+		authUsecase,
+		taskLinkUsecase,
+	}
+}
+
+// GetTaskDependencies
+//
+//	@Summary		タスクの依存関係の取得
+//	@Description	タスクの依存関係のグラフを取得する。BLOCKS の関連は推移的に辿り、それ以外の関連はタスクに直接接続するもののみを含む。閲覧できないタスクとその関連は含まない。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			task_id			path		int		false	"タスクID"
+//	@Success		200				{object}	model.TaskGraph
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/dependencies [get]
+func (h *taskLinkHandler) GetDependencies(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	graph, aerr := h.taskLinkUsecase.GetDependencies(domain.UserIdentifier(authUserID), domain.TaskIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalTaskGraph(graph)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateTaskLink
+//
+//	@Summary		タスクの関連の作成
+//	@Description	タスクから対象のタスクへの関連を作成する。BLOCKS は循環する関連を作成できない。両方のタスクを閲覧できる編集者のみ可能。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string					false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int						false	"企業ID"
+//	@Param			task_id			path		int						false	"タスクID"
+//	@Param			body			body		request.TaskLinkCreate	false	"関連作成用リクエスト"
+//	@Success		200				{object}	integer					"登録された関連ID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/link/create [post]
+func (h *taskLinkHandler) Create(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.TaskLinkCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskLinkCreateParams(authUserID, req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	linkID, aerr := h.taskLinkUsecase.Create(domain.TaskIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*linkID))
+}
+
+// DeleteTaskLink
+//
+//	@Summary		タスクの関連の削除
+//	@Description	タスクに接続している関連を削除する。編集者のみ可能。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			task_id			path	int		false	"タスクID"
+//	@Param			link_id			path	int		false	"関連ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/{task_id}/link/{link_id}/delete [delete]
+func (h *taskLinkHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("task_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	linkID, err := strconv.ParseUint(c.Param("link_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.taskLinkUsecase.Delete(domain.UserIdentifier(authUserID), domain.TaskIdentifier(id), domain.TaskLinkIdentifier(linkID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
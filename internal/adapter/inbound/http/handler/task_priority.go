@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaskPriorityHandler interface {
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type taskPriorityHandler struct {
+	authUsecase         usecase.AuthUsecase
+	taskPriorityUsecase usecase.TaskPriorityUsecase
+}
+
+func NewTaskPriorityHandler(
+	authUsecase usecase.AuthUsecase,
+	taskPriorityUsecase usecase.TaskPriorityUsecase,
+) TaskPriorityHandler {
+	return &taskPriorityHandler{
+		authUsecase,
+		taskPriorityUsecase,
+	}
+}
+
+// ListTaskPriority
+//
+//	@Summary		優先度一覧の取得
+//	@Description	企業のタスク優先度の一覧を優先度の低い順に取得する。
+//	@Tags			priority
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Success		200				{array}	model.TaskPriority
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/priority/list [get]
+func (h *taskPriorityHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	priorities, aerr := h.taskPriorityUsecase.ListByCompanyID(domain.CompanyIdentifier(companyID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := []*model.TaskPriority{}
+	for _, priority := range priorities.Sorted() {
+		res = append(res, model.UnmarshalTaskPriority(priority))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateTaskPriority
+//
+//	@Summary		優先度の作成
+//	@Description	企業のタスク優先度を作成する。level が大きいほど優先度が高い。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			priority
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string						false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int							false	"企業ID"
+//	@Param			body			body		request.TaskPriorityCreate	false	"優先度作成用リクエスト"
+//	@Success		200				{object}	integer						"登録された優先度ID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/priority/create [post]
+func (h *taskPriorityHandler) Create(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.TaskPriorityCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskPriorityCreateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.taskPriorityUsecase.Create(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// UpdateTaskPriority
+//
+//	@Summary		優先度の更新
+//	@Description	企業のタスク優先度の名前とレベルを更新する。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			priority
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int							false	"企業ID"
+//	@Param			priority_id		path	int							false	"優先度ID"
+//	@Param			body			body	request.TaskPriorityUpdate	false	"優先度更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/priority/{priority_id}/update [put]
+func (h *taskPriorityHandler) Update(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("priority_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.TaskPriorityUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskPriorityUpdateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.taskPriorityUsecase.Update(domain.CompanyIdentifier(companyID), domain.TaskPriorityIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteTaskPriority
+//
+//	@Summary		優先度の削除
+//	@Description	企業のタスク優先度を削除する。タスクで利用中の優先度は削除できない。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			priority
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			priority_id		path	int		false	"優先度ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/priority/{priority_id}/delete [delete]
+func (h *taskPriorityHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("priority_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.taskPriorityUsecase.Delete(domain.CompanyIdentifier(companyID), domain.TaskPriorityIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"todo_api/internal/adapter/inbound/http/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// taskStreamRetry / 切断されたクライアントが再接続するまでの時間（ミリ秒）
+const taskStreamRetry = 3000
+
+type TaskStreamHandler interface {
+	Stream(c echo.Context) error
+}
+
+type taskStreamHandler struct {
+	authUsecase       usecase.AuthUsecase
+	taskStreamUsecase usecase.TaskStreamUsecase
+	// heartbeat / 変更がない間も接続を保つためにコメントを送る間隔
+	heartbeat time.Duration
+}
+
+func NewTaskStreamHandler(
+	authUsecase usecase.AuthUsecase,
+	taskStreamUsecase usecase.TaskStreamUsecase,
+	heartbeat time.Duration,
+) TaskStreamHandler {
+	return &taskStreamHandler{
+		authUsecase,
+		taskStreamUsecase,
+		heartbeat,
+	}
+}
+
+// StreamTask
+//
+//	@Summary		タスクの変更のストリーム
+//	@Description	企業のタスクの作成、更新、ステータスの変更、削除を Server-Sent Events で送る。閲覧できないタスクの変更は送らない。
+//	@Description	公開範囲の変更で閲覧できなくなったタスクは、変更の値を含まない task.removed を送るため、クライアントは端末から削除する。
+//	@Description	各イベントの id を Last-Event-ID ヘッダ（または last_event_id）で指定して再接続すると、その後の変更を再送する。
+//	@Description	再送できない変更がある場合は最初に reset イベントを送るため、クライアントはタスクの一覧を取得し直す。
+//	@Description	変更がない間も一定の間隔でコメント行を送る。
+//	@Tags			task
+//	@Produce		text/event-stream
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Last-Event-ID	header		int		false	"最後に受け取ったイベントのID"
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			last_event_id	query		int		false	"最後に受け取ったイベントのID（Last-Event-ID を指定できない場合）"
+//	@Success		200				{object}	model.TaskStreamEvent
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/task/stream [get]
+func (h *taskStreamHandler) Stream(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var lastEventID *domain.TaskStreamEventIdentifier
+	s := c.Request().Header.Get("Last-Event-ID")
+	if s == "" {
+		s = c.QueryParam("last_event_id")
+	}
+	if s != "" {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}
+		}
+		eventID := domain.TaskStreamEventIdentifier(id)
+		lastEventID = &eventID
+	}
+
+	stream, aerr := h.taskStreamUsecase.Subscribe(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), lastEventID)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+	defer stream.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// リバースプロキシでバッファリングさせない
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	fmt.Fprintf(res, "retry: %d\n\n", taskStreamRetry)
+	if stream.Reset {
+		fmt.Fprint(res, "event: reset\ndata: {}\n\n")
+	}
+	res.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-stream.Events:
+			// 購読が打ち切られた場合は切断し、再接続時の再送に任せる
+			if !ok {
+				return nil
+			}
+			b, err := json.Marshal(model.UnmarshalTaskStreamEvent(event))
+			if err != nil {
+				return nil
+			}
+			if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.TypeName(), b); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaskSyncHandler interface {
+	ListChanges(c echo.Context) error
+}
+
+type taskSyncHandler struct {
+	authUsecase     usecase.AuthUsecase
+	taskSyncUsecase usecase.TaskSyncUsecase
+}
+
+func NewTaskSyncHandler(
+	authUsecase usecase.AuthUsecase,
+	taskSyncUsecase usecase.TaskSyncUsecase,
+) TaskSyncHandler {
+	return &taskSyncHandler{
+		authUsecase,
+		taskSyncUsecase,
+	}
+}
+
+// ListTaskChanges
+//
+//	@Summary		タスクの差分の取得
+//	@Description	前回の同期で受け取ったカーソルより後に作成、更新、削除、公開範囲の変更があった企業のタスクを取得する。
+//	@Description	閲覧できるタスクは最新の内容を、削除されたか閲覧できなくなったタスクは ID のみを返す。
+//	@Description	since を指定しない場合は閲覧できるすべてのタスクを返す。has_more が true の場合は返した cursor ですぐに取得し直す。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			since			query		string	false	"前回の同期で受け取ったカーソル"
+//	@Success		200				{object}	model.TaskChangeSet
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/task/changes [get]
+func (h *taskSyncHandler) ListChanges(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var since uint64
+	if s := c.QueryParam("since"); s != "" {
+		since, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	changeSet, aerr := h.taskSyncUsecase.ListChanges(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), since)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusOK, model.UnmarshalTaskChangeSet(changeSet))
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// taskExportFlushRows / 書き出しでクライアントに送り出す行の間隔
+const taskExportFlushRows = 100
+
+type TaskTransferHandler interface {
+	Export(c echo.Context) error
+	Import(c echo.Context) error
+	GetImportJob(c echo.Context) error
+}
+
+type taskTransferHandler struct {
+	authUsecase       usecase.AuthUsecase
+	taskUsecase       usecase.TaskUsecase
+	taskImportUsecase usecase.TaskImportUsecase
+}
+
+func NewTaskTransferHandler(
+	authUsecase usecase.AuthUsecase,
+	taskUsecase usecase.TaskUsecase,
+	taskImportUsecase usecase.TaskImportUsecase,
+) TaskTransferHandler {
+	return &taskTransferHandler{
+		authUsecase,
+		taskUsecase,
+		taskImportUsecase,
+	}
+}
+
+// ExportTask
+//
+//	@Summary		タスクの書き出し
+//	@Description	閲覧可能な企業のタスクを CSV または JSON で書き出す。絞り込みと並び順はタスク一覧と同じ指定ができる。
+//	@Description	ユーザは ID で、優先度とラベルは名前で表し、CSV の複数の値は ; 区切りとする。書き出した列はそのまま取り込みに使える。
+//	@Tags			task
+//	@Produce		text/csv
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			format				query	string	false	"形式（既定は csv）"	Enums(csv, json)
+//	@Param			status				query	string	false	"ステータス名（カンマ区切りで複数指定）"
+//	@Param			priority			query	string	false	"優先度名（カンマ区切りで複数指定）"
+//	@Param			start_date_from		query	string	false	"開始日の下限（RFC3339）"
+//	@Param			start_date_to		query	string	false	"開始日の上限（RFC3339）"
+//	@Param			limit_date_from		query	string	false	"期限の下限（RFC3339）"
+//	@Param			limit_date_to		query	string	false	"期限の上限（RFC3339）"
+//	@Param			label_ids			query	string	false	"ラベルID（カンマ区切りで複数指定）"
+//	@Param			label_match			query	string	false	"ラベルの一致方法（any: いずれか, all: すべて）"	Enums(any, all)
+//	@Param			parent_id			query	int		false	"親タスクID（指定した親タスクの子タスクのみ）"
+//	@Param			sort				query	string	false	"並び順（create_at, update_at, start_date, limit_date, priority。先頭に-で降順。カンマ区切り）"
+//	@Success		200				{array}	model.TaskExport
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/task/export [get]
+func (h *taskTransferHandler) Export(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req request.TaskExport
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	if req.Format != "csv" && req.Format != "json" {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: "format must be csv or json",
+		}
+	}
+
+	params, aerr := request.MarshalTaskListParams(&req.TaskList)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	tasks, aerr := h.taskUsecase.ListByCompanyID(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil && aerr.Code() != apperr.ErrorCodeNotFound {
+		return aerr.HTTPError()
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="tasks.`+req.Format+`"`)
+	if req.Format == "csv" {
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+		res.WriteHeader(http.StatusOK)
+		writer := csv.NewWriter(res)
+		if err := writer.Write(model.TaskExportColumns); err != nil {
+			return err
+		}
+		for i, task := range tasks {
+			if err := writer.Write(model.UnmarshalTaskExport(task).CSVRecord()); err != nil {
+				return err
+			}
+			if (i+1)%taskExportFlushRows == 0 {
+				writer.Flush()
+				res.Flush()
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	res.WriteHeader(http.StatusOK)
+	if _, err := res.Write([]byte("[")); err != nil {
+		return err
+	}
+	for i, task := range tasks {
+		if i > 0 {
+			if _, err := res.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		b, err := json.Marshal(model.UnmarshalTaskExport(task))
+		if err != nil {
+			return err
+		}
+		if _, err := res.Write(b); err != nil {
+			return err
+		}
+		if (i+1)%taskExportFlushRows == 0 {
+			res.Flush()
+		}
+	}
+	_, err = res.Write([]byte("]"))
+	return err
+}
+
+// ImportTask
+//
+//	@Summary		タスクの取り込み
+//	@Description	CSV または JSON のファイルからタスクを作成する。編集権限を持つユーザのみ可能。CSV は1行目をヘッダとし、JSON はオブジェクトの配列とする。
+//	@Description	mapping で項目名（title, detail, visibility, person_in_charge, assignees, watchers, priority, start_date, limit_date, labels, parent_id）から列名への対応を指定できる。
+//	@Description	ユーザは ID または名前で、優先度とラベルは名前で指定する。日付は YYYY-MM-DD または RFC3339 形式とする。
+//	@Description	format に trello（ボードの JSON）、jira（課題の CSV）、github（Issue の JSON）を指定すると他のツールのエクスポートを取り込む。
+//	@Description	他のツールのステータスと優先度は status_mapping と priority_mapping の対応で企業の名前に変換し、対応のない値は同じ名前とする。
+//	@Description	他のツールのユーザは名前または通知のメールアドレスで探し、一致しないユーザは割り当てずに unmatched_users に返す。存在しないラベルは作成し、コメントも取り込む。
+//	@Description	取り込み済みの課題は再び作成せずに skipped に数える。
+//	@Description	誤りのある行は作成せずに行ごとの誤りとして返す。dry_run を指定すると検証のみを行う。
+//	@Description	100件以下の場合はその場で処理して 200 を、超える場合は非同期に処理して 202 を返す。進捗は返した ID で取得する。
+//	@Tags			task
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string	false	"再送で重複して取り込まないためのキー"
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			file			formData	file	true	"取り込むファイル（10000件まで）"
+//	@Param			format			formData	string	false	"形式（未指定の場合は拡張子から判定）"	Enums(csv, json, trello, jira, github)
+//	@Param			mapping			formData	string	false	"項目名から列名への対応の JSON。例: {&quot;title&quot;: &quot;件名&quot;}"
+//	@Param			status_mapping		formData	string	false	"他のツールのステータスから企業のステータスの名前への対応の JSON。例: {&quot;To Do&quot;: &quot;未着手&quot;}"
+//	@Param			priority_mapping	formData	string	false	"他のツールの優先度から企業の優先度の名前への対応の JSON"
+//	@Param			dry_run			formData	bool	false	"検証のみを行う"
+//	@Success		200				{object}	model.TaskImportJob
+//	@Success		202				{object}	model.TaskImportJob
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		413
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/task/import [post]
+func (h *taskTransferHandler) Import(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	var authUserID uint64
+	{
+		authUserID, err = verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req request.TaskImport
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+	defer file.Close()
+
+	source, aerr := request.MarshalTaskImportSource(&req, fileHeader.Filename)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+	rows, aerr := request.MarshalTaskImportRows(&req, fileHeader.Filename, file)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	job, aerr := h.taskImportUsecase.Import(domain.UserIdentifier(authUserID), domain.CompanyIdentifier(companyID), *source, rows, req.DryRun)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	if job.Status == domain.TaskImportStatusPending {
+		return c.JSON(http.StatusAccepted, model.UnmarshalTaskImportJob(job))
+	}
+	return c.JSON(http.StatusOK, model.UnmarshalTaskImportJob(job))
+}
+
+// GetTaskImportJob
+//
+//	@Summary		タスクの取り込みの進捗の取得
+//	@Description	取り込みの状態、処理した件数、行ごとの誤りを取得する。status が SUCCEEDED または FAILED になるまで繰り返し取得する。
+//	@Tags			task
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			job_id			path		int		false	"取り込みID"
+//	@Success		200				{object}	model.TaskImportJob
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/task/import/{job_id} [get]
+func (h *taskTransferHandler) GetImportJob(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の編集権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanEdit(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	jobID, err := strconv.ParseUint(c.Param("job_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	job, aerr := h.taskImportUsecase.Get(domain.CompanyIdentifier(companyID), domain.TaskImportJobIdentifier(jobID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusOK, model.UnmarshalTaskImportJob(job))
+}
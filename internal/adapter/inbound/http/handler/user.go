@@ -32,14 +32,17 @@ func NewUserHandler(
 // GetUser
 //
 //	@Summary		ユーザの取得
-//	@Description	ユーザの情報をIDから取得する。
+//	@Description	ユーザの情報をIDから取得する。ユーザの版数を ETag として返し、If-None-Match が一致する場合は 304 を返す。
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			If-None-Match	header		string	false	"取得済みの ETag"
 //	@Param			company_id		path		int		false	"企業ID"
 //	@Param			user_id			path		int		false	"ユーザID"
 //	@Success		200				{object}	model.User
+//	@Header			200				{string}	ETag	"ユーザの版数"
+//	@Success		304
 //	@Failure		400
 //	@Failure		401
 //	@Failure		403
@@ -89,5 +92,5 @@ func (h *userHandler) Get(c echo.Context) error {
 
 	res := model.UnmarshalUser(company)
 
-	return c.JSON(http.StatusOK, res)
+	return respondWithETag(c, company.Version, res)
 }
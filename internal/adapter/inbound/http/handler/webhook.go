@@ -0,0 +1,508 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler interface {
+	List(c echo.Context) error
+	Get(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	ListDeliveries(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
+
+type webhookHandler struct {
+	authUsecase    usecase.AuthUsecase
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(
+	authUsecase usecase.AuthUsecase,
+	webhookUsecase usecase.WebhookUsecase,
+) WebhookHandler {
+	return &webhookHandler{
+		authUsecase,
+		webhookUsecase,
+	}
+}
+
+// ListWebhook
+//
+//	@Summary		Webhook 一覧の取得
+//	@Description	企業の Webhook の一覧を取得する。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Success		200				{array}	model.Webhook
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/list [get]
+func (h *webhookHandler) List(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	webhooks, aerr := h.webhookUsecase.ListByCompanyID(domain.CompanyIdentifier(companyID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := []*model.Webhook{}
+	for _, webhook := range webhooks {
+		res = append(res, model.UnmarshalWebhook(webhook))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// GetWebhook
+//
+//	@Summary		Webhook の取得
+//	@Description	Webhook の設定と署名の鍵を取得する。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			webhook_id		path		int		false	"Webhook ID"
+//	@Success		200				{object}	model.Webhook
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/{webhook_id} [get]
+func (h *webhookHandler) Get(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	webhook, aerr := h.webhookUsecase.Get(domain.CompanyIdentifier(companyID), domain.WebhookIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusOK, model.UnmarshalWebhook(webhook))
+}
+
+// CreateWebhook
+//
+//	@Summary		Webhook の作成
+//	@Description	出来事を通知する Webhook を作成する。署名の鍵は作成時に生成され、Webhook の取得で確認できる。ループバックやプライベートのアドレスの URL は登録できない。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string					false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int						false	"企業ID"
+//	@Param			body			body		request.WebhookCreate	false	"Webhook 作成用リクエスト"
+//	@Success		200				{object}	integer					"登録された Webhook ID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/create [post]
+func (h *webhookHandler) Create(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.WebhookCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalWebhookCreateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.webhookUsecase.Create(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// UpdateWebhook
+//
+//	@Summary		Webhook の更新
+//	@Description	Webhook の URL と購読する出来事、有効かどうかを更新する。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int						false	"企業ID"
+//	@Param			webhook_id		path	int						false	"Webhook ID"
+//	@Param			body			body	request.WebhookUpdate	false	"Webhook 更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/{webhook_id}/update [put]
+func (h *webhookHandler) Update(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.WebhookUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalWebhookUpdateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.webhookUsecase.Update(domain.CompanyIdentifier(companyID), domain.WebhookIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteWebhook
+//
+//	@Summary		Webhook の削除
+//	@Description	Webhook を配信の記録とともに削除する。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			webhook_id		path	int		false	"Webhook ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/{webhook_id}/delete [delete]
+func (h *webhookHandler) Delete(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.webhookUsecase.Delete(domain.CompanyIdentifier(companyID), domain.WebhookIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListWebhookDelivery
+//
+//	@Summary		Webhook の配信の記録の取得
+//	@Description	Webhook の配信を新しい順に、送信の状況と応答のステータスコードとともに取得する。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			webhook_id		path		int		false	"Webhook ID"
+//	@Param			page			query		int		false	"ページ番号（1始まり）"
+//	@Param			per_page		query		int		false	"1ページの件数（既定20、最大100）"
+//	@Success		200				{object}	model.WebhookDeliveryPage
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/{webhook_id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req request.WebhookDeliveryList
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	page := request.MarshalWebhookDeliveryListPage(&req)
+
+	deliveries, total, aerr := h.webhookUsecase.ListDeliveries(domain.CompanyIdentifier(companyID), domain.WebhookIdentifier(id), page)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusOK, model.UnmarshalWebhookDeliveryPage(deliveries, total, page))
+}
+
+// RedeliverWebhook
+//
+//	@Summary		Webhook の再送
+//	@Description	配信と同じ出来事とペイロードを新しい配信として送る。無効な Webhook には再送できない。管理者のみ可能。
+//	@Tags			webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Param			webhook_id		path		int		false	"Webhook ID"
+//	@Param			delivery_id		path		int		false	"配信ID"
+//	@Success		200				{object}	integer	"再送の配信ID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/webhook/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+func (h *webhookHandler) Redeliver(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	redeliveryID, aerr := h.webhookUsecase.Redeliver(domain.CompanyIdentifier(companyID), domain.WebhookIdentifier(id), domain.WebhookDeliveryIdentifier(deliveryID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*redeliveryID))
+}
@@ -0,0 +1,428 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"todo_api/internal/adapter/inbound/http/model"
+	"todo_api/internal/adapter/inbound/http/request"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WorkflowHandler interface {
+	Get(c echo.Context) error
+	CreateStatus(c echo.Context) error
+	UpdateStatus(c echo.Context) error
+	DeleteStatus(c echo.Context) error
+	CreateTransition(c echo.Context) error
+	DeleteTransition(c echo.Context) error
+}
+
+type workflowHandler struct {
+	authUsecase     usecase.AuthUsecase
+	workflowUsecase usecase.WorkflowUsecase
+}
+
+func NewWorkflowHandler(
+	authUsecase usecase.AuthUsecase,
+	workflowUsecase usecase.WorkflowUsecase,
+) WorkflowHandler {
+	return &workflowHandler{
+		authUsecase,
+		workflowUsecase,
+	}
+}
+
+// GetWorkflow
+//
+//	@Summary		ワークフローの取得
+//	@Description	企業のタスクステータスとステータス間の遷移の定義を取得する。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path		int		false	"企業ID"
+//	@Success		200				{object}	model.Workflow
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow [get]
+func (h *workflowHandler) Get(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の閲覧権限を持つことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanView(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	workflow, aerr := h.workflowUsecase.Get(domain.CompanyIdentifier(companyID))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	res := model.UnmarshalWorkflow(workflow)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateTaskStatus
+//
+//	@Summary		タスクステータスの作成
+//	@Description	企業のタスクステータスを作成する。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string					false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int						false	"企業ID"
+//	@Param			body			body		request.TaskStatusCreate	false	"タスクステータス作成用リクエスト"
+//	@Success		200				{object}	integer					"登録されたタスクステータスID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow/status/create [post]
+func (h *workflowHandler) CreateStatus(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.TaskStatusCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskStatusCreateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.workflowUsecase.CreateStatus(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// UpdateTaskStatusDefinition
+//
+//	@Summary		タスクステータスの更新
+//	@Description	企業のタスクステータスの名前・分類・並び順を更新する。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int						false	"企業ID"
+//	@Param			status_id		path	int						false	"タスクステータスID"
+//	@Param			body			body	request.TaskStatusUpdate	false	"タスクステータス更新用リクエスト"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow/status/{status_id}/update [put]
+func (h *workflowHandler) UpdateStatus(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("status_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var req *request.TaskStatusUpdate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskStatusUpdateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	aerr = h.workflowUsecase.UpdateStatus(domain.CompanyIdentifier(companyID), domain.TaskStatusIdentifier(id), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteTaskStatus
+//
+//	@Summary		タスクステータスの削除
+//	@Description	企業のタスクステータスを削除する。タスクで利用中のステータスは削除できない。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			status_id		path	int		false	"タスクステータスID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow/status/{status_id}/delete [delete]
+func (h *workflowHandler) DeleteStatus(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("status_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.workflowUsecase.DeleteStatus(domain.CompanyIdentifier(companyID), domain.TaskStatusIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// CreateTaskStatusTransition
+//
+//	@Summary		ステータス遷移の作成
+//	@Description	タスクステータス間の遷移を許可する。role, user_type を指定した場合は該当するユーザのみ遷移可能となる。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			Idempotency-Key	header		string								false	"再送で重複して作成しないためのキー"
+//	@Param			company_id		path		int									false	"企業ID"
+//	@Param			body			body		request.TaskStatusTransitionCreate	false	"ステータス遷移作成用リクエスト"
+//	@Success		200				{object}	integer								"登録されたステータス遷移ID"
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		422
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow/transition/create [post]
+func (h *workflowHandler) CreateTransition(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	var req *request.TaskStatusTransitionCreate
+	if err := c.Bind(&req); err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	params, aerr := request.MarshalTaskStatusTransitionCreateParams(req)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	id, aerr := h.workflowUsecase.CreateTransition(domain.CompanyIdentifier(companyID), *params)
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.JSON(http.StatusCreated, uint64(*id))
+}
+
+// DeleteTaskStatusTransition
+//
+//	@Summary		ステータス遷移の削除
+//	@Description	タスクステータス間の遷移を削除する。管理会社の管理者と企業の管理者に実行可能。
+//	@Tags			workflow
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			company_id		path	int		false	"企業ID"
+//	@Param			transition_id	path	int		false	"ステータス遷移ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/company/{company_id}/workflow/transition/{transition_id}/delete [delete]
+func (h *workflowHandler) DeleteTransition(c echo.Context) error {
+	companyID, err := strconv.ParseUint(c.Param("company_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	// 会社の管理者権限をもつことの認証処理
+	{
+		authUserID, err := verifyJwtToken(c)
+		if err != nil {
+			return &echo.HTTPError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			}
+		}
+		authUser, aerr := h.authUsecase.Get(domain.UserIdentifier(authUserID))
+		if aerr != nil {
+			return aerr.HTTPError()
+		}
+		if !authUser.CanAdminAction(domain.CompanyIdentifier(companyID)) {
+			return &echo.HTTPError{
+				Code: http.StatusForbidden,
+			}
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("transition_id"), 10, 64)
+	if err != nil {
+		return &echo.HTTPError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	aerr := h.workflowUsecase.DeleteTransition(domain.CompanyIdentifier(companyID), domain.TaskStatusTransitionIdentifier(id))
+	if aerr != nil {
+		return aerr.HTTPError()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
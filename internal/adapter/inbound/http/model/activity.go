@@ -0,0 +1,53 @@
+package model
+
+import (
+	"strconv"
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type Activity struct {
+	ID uint64 `json:"id"`
+	// Type / TASK_CREATED, TASK_ASSIGNED, TASK_COMPLETED, TASK_COMMENTED, USER_CREATED のいずれか
+	Type   string  `json:"type"`
+	TaskID *uint64 `json:"task_id"`
+	// UserID / 出来事の対象のユーザ。担当者の変更では新しい担当者、ユーザの作成では作成されたユーザ。
+	UserID   *uint64   `json:"user_id"`
+	Actor    User      `json:"actor"`
+	CreateAt time.Time `json:"create_at"`
+}
+
+// ActivityFeed / タイムラインの1ページ分。NextCursor は次のページがない場合 nil。
+type ActivityFeed struct {
+	Activities []*Activity `json:"activities"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
+func UnmarshalActivity(d *domain.Activity) *Activity {
+	if d == nil {
+		return nil
+	}
+	return &Activity{
+		ID:       uint64(d.ID),
+		Type:     d.Type.String(),
+		TaskID:   (*uint64)(d.TaskID),
+		UserID:   (*uint64)(d.UserID),
+		Actor:    *UnmarshalUser(&d.Actor),
+		CreateAt: d.CreateAt,
+	}
+}
+
+func UnmarshalActivityFeed(d []*domain.Activity, cursor domain.ActivityCursor) *ActivityFeed {
+	res := &ActivityFeed{
+		Activities: []*Activity{},
+	}
+	for _, activity := range d {
+		res.Activities = append(res.Activities, UnmarshalActivity(activity))
+	}
+	// 件数が上限に達した場合のみ続きがある可能性がある
+	if len(d) == cursor.Limit {
+		next := strconv.FormatUint(uint64(d[len(d)-1].ID), 10)
+		res.NextCursor = &next
+	}
+	return res
+}
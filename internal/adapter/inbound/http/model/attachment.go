@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type Attachment struct {
+	ID          uint64    `json:"id"`
+	TaskID      uint64    `json:"task_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreateAt    time.Time `json:"create_at"`
+	Creator     User      `json:"creator"`
+}
+
+func UnmarshalAttachment(d *domain.Attachment) *Attachment {
+	if d == nil {
+		return nil
+	}
+	return &Attachment{
+		ID:          uint64(d.ID),
+		TaskID:      uint64(d.TaskID),
+		FileName:    d.FileName,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		CreateAt:    d.CreateAt,
+		Creator:     *UnmarshalUser(&d.Creator),
+	}
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/usecase"
+)
+
+const (
+	// CalendarComponentTodo / タスクを ToDo（VTODO）として表す
+	CalendarComponentTodo = "vtodo"
+	// CalendarComponentEvent / 期限のあるタスクを期限の時刻の予定（VEVENT）として表す
+	CalendarComponentEvent = "vevent"
+)
+
+// calendarLineOctets / RFC 5545 で折り返す1行のオクテット数の上限（改行を除く）
+const calendarLineOctets = 75
+
+const calendarTimeLayout = "20060102T150405Z"
+
+// CalendarFeedLink / カレンダーの購読の URL
+type CalendarFeedLink struct {
+	// URL / カレンダーのアプリに登録する URL。クエリパラメータで範囲と絞り込みを指定できる。
+	URL      string    `json:"url"`
+	CreateAt time.Time `json:"create_at"`
+}
+
+func UnmarshalCalendarFeedLink(d *usecase.CalendarFeedLink) *CalendarFeedLink {
+	if d == nil {
+		return nil
+	}
+	return &CalendarFeedLink{
+		URL:      d.URL,
+		CreateAt: d.CreateAt,
+	}
+}
+
+// UnmarshalCalendar / タスクを iCalendar（RFC 5545）の形式で表す。
+// VEVENT の場合は期限のないタスクを含めない。完了したタスクは購読済みの予定を取り消せるよう、除かずに取り消し済みとして含める。
+func UnmarshalCalendar(tasks []*domain.Task, component string, now time.Time) string {
+	w := &calendarWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//todo_api//Task Calendar//JA")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeCalendarText("タスク"))
+	for _, task := range tasks {
+		switch component {
+		case CalendarComponentEvent:
+			if task.LimitDate == nil {
+				continue
+			}
+			w.line("BEGIN", "VEVENT")
+			w.task(task, now)
+			w.line("DTSTART", formatCalendarTime(*task.LimitDate))
+			w.line("STATUS", calendarEventStatus(task.Status.Category))
+			w.line("TRANSP", "TRANSPARENT")
+			w.line("END", "VEVENT")
+		default:
+			w.line("BEGIN", "VTODO")
+			w.task(task, now)
+			// DTSTART は DUE より前でなければならない
+			if task.StartDate != nil && (task.LimitDate == nil || task.StartDate.Before(*task.LimitDate)) {
+				w.line("DTSTART", formatCalendarTime(*task.StartDate))
+			}
+			if task.LimitDate != nil {
+				w.line("DUE", formatCalendarTime(*task.LimitDate))
+			}
+			w.line("STATUS", calendarTodoStatus(task.Status.Category))
+			w.line("END", "VTODO")
+		}
+	}
+	w.line("END", "VCALENDAR")
+	return w.String()
+}
+
+// calendarTodoStatus / ステータスの分類を VTODO の STATUS に対応させる
+func calendarTodoStatus(category domain.TaskStatusCategory) string {
+	switch category {
+	case domain.TaskStatusCategoryInProgress:
+		return "IN-PROCESS"
+	case domain.TaskStatusCategoryClosed:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// calendarEventStatus / ステータスの分類を VEVENT の STATUS に対応させる。
+// VEVENT の STATUS には完了がないため、完了したタスクは取り消し済み（CANCELLED）とする。
+func calendarEventStatus(category domain.TaskStatusCategory) string {
+	if category == domain.TaskStatusCategoryClosed {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+type calendarWriter struct {
+	strings.Builder
+}
+
+// task / VTODO と VEVENT に共通のタスクの項目を書く
+func (w *calendarWriter) task(task *domain.Task, now time.Time) {
+	w.line("UID", "task-"+strconv.FormatUint(uint64(task.ID), 10)+"@todo_api")
+	w.line("DTSTAMP", formatCalendarTime(now))
+	w.line("CREATED", formatCalendarTime(task.CreateAt))
+	w.line("LAST-MODIFIED", formatCalendarTime(task.UpdateAt))
+	w.line("SEQUENCE", strconv.FormatUint(uint64(task.Version), 10))
+	w.line("SUMMARY", escapeCalendarText(task.Title))
+	if task.Detail != nil && *task.Detail != "" {
+		w.line("DESCRIPTION", escapeCalendarText(*task.Detail))
+	}
+	if len(task.Labels) > 0 {
+		var names []string
+		for _, label := range task.Labels {
+			names = append(names, escapeCalendarText(label.Name))
+		}
+		w.line("CATEGORIES", strings.Join(names, ","))
+	}
+}
+
+// line / 1行を CRLF で書き、75オクテットを超える行は UTF-8 の文字の途中で切らずに折り返す
+func (w *calendarWriter) line(name, value string) {
+	s := name + ":" + value
+	limit := calendarLineOctets
+	for len(s) > limit {
+		i := limit
+		// 続きの文字のバイト（10xxxxxx）で切らない
+		for i > 0 && s[i]&0xC0 == 0x80 {
+			i--
+		}
+		w.WriteString(s[:i])
+		w.WriteString("\r\n ")
+		s = s[i:]
+		// 折り返した行は先頭の空白の分だけ短くする
+		limit = calendarLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+// escapeCalendarText / TEXT の値の \ ; , と改行をエスケープする
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+func formatCalendarTime(t time.Time) string {
+	return t.UTC().Format(calendarTimeLayout)
+}
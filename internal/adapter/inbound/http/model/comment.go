@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type Comment struct {
+	ID       uint64     `json:"id"`
+	TaskID   uint64     `json:"task_id"`
+	Author   User       `json:"author"`
+	Body     string     `json:"body"`
+	Mentions []*User    `json:"mentions"`
+	CreateAt time.Time  `json:"create_at"`
+	EditAt   *time.Time `json:"edit_at,omitempty"`
+}
+
+// CommentPage / コメント一覧の1ページ分
+type CommentPage struct {
+	Comments []*Comment `json:"comments"`
+	Total    int        `json:"total"`
+	Page     int        `json:"page"`
+	PerPage  int        `json:"per_page"`
+}
+
+func UnmarshalComment(d *domain.Comment) *Comment {
+	if d == nil {
+		return nil
+	}
+	mentions := []*User{}
+	for _, user := range d.Mentions {
+		mentions = append(mentions, UnmarshalUser(user))
+	}
+	return &Comment{
+		ID:       uint64(d.ID),
+		TaskID:   uint64(d.TaskID),
+		Author:   *UnmarshalUser(&d.Author),
+		Body:     d.Body,
+		Mentions: mentions,
+		CreateAt: d.CreateAt,
+		EditAt:   d.EditAt,
+	}
+}
+
+func UnmarshalCommentPage(d []*domain.Comment, total int, page domain.Page) *CommentPage {
+	res := &CommentPage{
+		Comments: []*Comment{},
+		Total:    total,
+		Page:     page.Number,
+		PerPage:  page.Size,
+	}
+	for _, comment := range d {
+		res.Comments = append(res.Comments, UnmarshalComment(comment))
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+type CompanySetting struct {
+	MaxTaskDepth          int      `json:"max_task_depth"`
+	RequireClosedSubtasks bool     `json:"require_closed_subtasks"`
+	MaxAttachmentSize     int64    `json:"max_attachment_size"`
+	AllowedMimeTypes      []string `json:"allowed_mime_types"`
+	StorageQuota          int64    `json:"storage_quota"`
+}
+
+func UnmarshalCompanySetting(d *domain.CompanySetting) *CompanySetting {
+	if d == nil {
+		return nil
+	}
+	return &CompanySetting{
+		MaxTaskDepth:          d.MaxTaskDepth,
+		RequireClosedSubtasks: d.RequireClosedSubtasks,
+		MaxAttachmentSize:     d.MaxAttachmentSize,
+		AllowedMimeTypes:      d.AllowedMimeTypes,
+		StorageQuota:          d.StorageQuota,
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+type Label struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func UnmarshalLabel(d *domain.Label) *Label {
+	if d == nil {
+		return nil
+	}
+	return &Label{
+		ID:    uint64(d.ID),
+		Name:  d.Name,
+		Color: d.Color,
+	}
+}
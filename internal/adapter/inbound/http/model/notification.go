@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type Notification struct {
+	ID uint64 `json:"id"`
+	// Type / ASSIGNED, MENTIONED, STATUS_CHANGED, DUE_SOON のいずれか
+	Type   string `json:"type"`
+	TaskID uint64 `json:"task_id"`
+	// Actor / 通知のきっかけとなったユーザ。DUE_SOON では含まない。
+	Actor     *User   `json:"actor,omitempty"`
+	CommentID *uint64 `json:"comment_id,omitempty"`
+	// Status / STATUS_CHANGED での変更後のステータス
+	Status *string `json:"status,omitempty"`
+	// LimitDate / DUE_SOON の通知の時点の期限。通知より前の場合は期限切れの通知。
+	LimitDate *time.Time `json:"limit_date,omitempty"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreateAt  time.Time  `json:"create_at"`
+}
+
+// NotificationPage / 通知一覧の1ページ分
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	Total         int             `json:"total"`
+	Unread        int             `json:"unread"`
+	Page          int             `json:"page"`
+	PerPage       int             `json:"per_page"`
+}
+
+type NotificationUnreadCount struct {
+	Unread int `json:"unread"`
+}
+
+type NotificationPreference struct {
+	// InApp / 種類ごとにアプリ内で通知するかどうか
+	InApp map[string]bool `json:"in_app"`
+	// Email / 種類ごとにメールで通知するかどうか
+	Email         map[string]bool `json:"email"`
+	EmailAddress  *string         `json:"email_address,omitempty"`
+	EmailLanguage string          `json:"email_language"`
+	EmailMode     string          `json:"email_mode"`
+}
+
+func UnmarshalNotification(d *domain.Notification) *Notification {
+	if d == nil {
+		return nil
+	}
+	return &Notification{
+		ID:        uint64(d.ID),
+		Type:      d.Type.String(),
+		TaskID:    uint64(d.TaskID),
+		Actor:     UnmarshalUser(d.Actor),
+		CommentID: (*uint64)(d.CommentID),
+		Status:    d.Status,
+		LimitDate: d.LimitDate,
+		Read:      d.ReadAt != nil,
+		ReadAt:    d.ReadAt,
+		CreateAt:  d.CreateAt,
+	}
+}
+
+func UnmarshalNotificationPage(d []*domain.Notification, total, unread int, page domain.Page) *NotificationPage {
+	res := &NotificationPage{
+		Notifications: []*Notification{},
+		Total:         total,
+		Unread:        unread,
+		Page:          page.Number,
+		PerPage:       page.Size,
+	}
+	for _, notification := range d {
+		res.Notifications = append(res.Notifications, UnmarshalNotification(notification))
+	}
+	return res
+}
+
+func UnmarshalNotificationPreference(d *domain.NotificationPreference) *NotificationPreference {
+	if d == nil {
+		return nil
+	}
+	res := &NotificationPreference{
+		InApp:         make(map[string]bool),
+		Email:         make(map[string]bool),
+		EmailAddress:  d.EmailAddress,
+		EmailLanguage: d.EmailLanguage.String(),
+		EmailMode:     d.EmailMode.String(),
+	}
+	for _, notificationType := range domain.NotificationTypes {
+		res.InApp[notificationType.String()] = d.AllowsInApp(notificationType)
+		enabled, ok := d.Email[notificationType]
+		res.Email[notificationType.String()] = !ok || enabled
+	}
+	return res
+}
@@ -6,31 +6,45 @@ import (
 )
 
 type Task struct {
-	ID             uint64     `json:"id"`
-	Title          string     `json:"title"`
-	Detail         *string    `json:"detail,omitempty"`
-	Status         string     `json:"status"`
-	Visibility     string     `json:"visibility"`
-	PersonInCharge *User      `json:"person_in_charge,omitempty"`
-	LimitDate      *time.Time `json:"limit_date,omitempty"`
+	ID             uint64           `json:"id"`
+	Title          string           `json:"title"`
+	Detail         *string          `json:"detail,omitempty"`
+	Status         string           `json:"status"`
+	StatusCategory string           `json:"status_category"`
+	Visibility     string           `json:"visibility"`
+	PersonInCharge *User            `json:"person_in_charge,omitempty"`
+	Priority       *TaskPriority    `json:"priority,omitempty"`
+	StartDate      *time.Time       `json:"start_date,omitempty"`
+	LimitDate      *time.Time       `json:"limit_date,omitempty"`
+	Labels         []*Label         `json:"labels"`
+	ParentID       *uint64          `json:"parent_id,omitempty"`
+	Subtasks       Subtasks         `json:"subtasks"`
+	Checklist      []*ChecklistItem `json:"checklist"`
+	// OpenBlockerIDs / このタスクをブロックしている未完了のタスクのID
+	OpenBlockerIDs []uint64 `json:"open_blocker_ids"`
+	// Assignees / 主担当者（person_in_charge）を先頭に含む担当者
+	Assignees []*User `json:"assignees"`
+	// Watchers / 担当はせずにタスクをフォローするユーザ
+	Watchers []*User `json:"watchers"`
+	// Recurrence / 繰り返すタスクの場合のみ含む
+	Recurrence *TaskRecurrence `json:"recurrence,omitempty"`
 
 	CreateAt time.Time `json:"create_at"`
 	Creator  User      `json:"creator"`
 	UpdateAt time.Time `json:"update_at"`
-	Updator  User     `json:"updator"`
+	Updator  User      `json:"updator"`
 }
 
-func unmarshalStatus(d domain.TaskStatus) string {
-	switch d {
-	case domain.TaskStatusNew:
-		return "NEW"
-	case domain.TaskStatusProcessing:
-		return "PROCESSING"
-	case domain.TaskStatusDone:
-		return "DONE"
-	default:
-		return ""
-	}
+// Subtasks / 子タスクの件数と完了した割合（0〜100）
+type Subtasks struct {
+	Total    int `json:"total"`
+	Closed   int `json:"closed"`
+	Progress int `json:"progress"`
+}
+
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
 }
 
 func unmarshalVisibility(d domain.TaskVisibility) string {
@@ -48,14 +62,52 @@ func UnmarshalTask(d *domain.Task) *Task {
 	if d == nil {
 		return nil
 	}
+	labels := []*Label{}
+	for _, label := range d.Labels {
+		labels = append(labels, UnmarshalLabel(label))
+	}
+	openBlockerIDs := []uint64{}
+	for _, id := range d.OpenBlockerIDs {
+		openBlockerIDs = append(openBlockerIDs, uint64(id))
+	}
+	assignees := []*User{}
+	for _, assignee := range d.Assignees {
+		assignees = append(assignees, UnmarshalUser(assignee))
+	}
+	watchers := []*User{}
+	for _, watcher := range d.Watchers {
+		watchers = append(watchers, UnmarshalUser(watcher))
+	}
+	checklist := []*ChecklistItem{}
+	for _, item := range d.Checklist {
+		checklist = append(checklist, &ChecklistItem{
+			Text: item.Text,
+			Done: item.Done,
+		})
+	}
 	return &Task{
 		ID:             uint64(d.ID),
 		Title:          d.Title,
 		Detail:         d.Detail,
-		Status:         unmarshalStatus(d.Status),
+		Status:         d.Status.Name,
+		StatusCategory: unmarshalTaskStatusCategory(d.Status.Category),
 		Visibility:     unmarshalVisibility(d.Visibility),
 		PersonInCharge: UnmarshalUser(d.PersonInCharge),
+		Priority:       UnmarshalTaskPriority(d.Priority),
+		StartDate:      d.StartDate,
 		LimitDate:      d.LimitDate,
+		Labels:         labels,
+		ParentID:       (*uint64)(d.ParentID),
+		Subtasks: Subtasks{
+			Total:    d.Subtasks.Total,
+			Closed:   d.Subtasks.Closed,
+			Progress: d.Subtasks.Progress(),
+		},
+		Checklist:      checklist,
+		OpenBlockerIDs: openBlockerIDs,
+		Assignees:      assignees,
+		Watchers:       watchers,
+		Recurrence:     UnmarshalTaskRecurrence(d),
 		CreateAt:       d.CreateAt,
 		Creator:        *UnmarshalUser(&d.Creator),
 		UpdateAt:       d.UpdateAt,
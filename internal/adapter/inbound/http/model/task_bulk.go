@@ -0,0 +1,41 @@
+package model
+
+import (
+	"net/http"
+	"todo_api/internal/usecase"
+)
+
+// TaskBulkResult / タスクの一括操作の結果
+type TaskBulkResult struct {
+	// Applied / 保存されたかどうか。atomic でいずれかの項目が失敗した場合は false。
+	Applied bool                  `json:"applied"`
+	Items   []*TaskBulkItemResult `json:"items"`
+}
+
+// TaskBulkItemResult / 項目ごとの結果。status は個別の操作と同じ HTTP ステータスコード。
+type TaskBulkItemResult struct {
+	Index   int    `json:"index"`
+	TaskID  uint64 `json:"task_id"`
+	Status  int    `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+func UnmarshalTaskBulkResult(d *usecase.TaskBulkResult) *TaskBulkResult {
+	res := &TaskBulkResult{
+		Applied: d.Applied,
+		Items:   []*TaskBulkItemResult{},
+	}
+	for i, item := range d.Items {
+		itemRes := &TaskBulkItemResult{
+			Index:  i,
+			TaskID: uint64(item.TaskID),
+			Status: http.StatusOK,
+		}
+		if item.Err != nil {
+			itemRes.Status = item.Err.HTTPError().Code
+			itemRes.Message = item.Err.Message()
+		}
+		res.Items = append(res.Items, itemRes)
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type TaskHistory struct {
+	ID     uint64 `json:"id"`
+	TaskID uint64 `json:"task_id"`
+	// Action / CREATE, UPDATE, STATUS, ASSIGN, DELETE のいずれか
+	Action   string    `json:"action"`
+	Field    string    `json:"field"`
+	OldValue *string   `json:"old_value"`
+	NewValue *string   `json:"new_value"`
+	Actor    User      `json:"actor"`
+	CreateAt time.Time `json:"create_at"`
+}
+
+// TaskHistoryPage / 変更履歴の1ページ分
+type TaskHistoryPage struct {
+	Histories []*TaskHistory `json:"histories"`
+	Total     int            `json:"total"`
+	Page      int            `json:"page"`
+	PerPage   int            `json:"per_page"`
+}
+
+func UnmarshalTaskHistory(d *domain.TaskHistory) *TaskHistory {
+	if d == nil {
+		return nil
+	}
+	return &TaskHistory{
+		ID:       uint64(d.ID),
+		TaskID:   uint64(d.TaskID),
+		Action:   d.Action.String(),
+		Field:    string(d.Field),
+		OldValue: d.OldValue,
+		NewValue: d.NewValue,
+		Actor:    *UnmarshalUser(&d.Actor),
+		CreateAt: d.CreateAt,
+	}
+}
+
+func UnmarshalTaskHistoryPage(d []*domain.TaskHistory, total int, page domain.Page) *TaskHistoryPage {
+	res := &TaskHistoryPage{
+		Histories: []*TaskHistory{},
+		Total:     total,
+		Page:      page.Number,
+		PerPage:   page.Size,
+	}
+	for _, history := range d {
+		res.Histories = append(res.Histories, UnmarshalTaskHistory(history))
+	}
+	return res
+}
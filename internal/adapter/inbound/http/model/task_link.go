@@ -0,0 +1,61 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+type TaskLink struct {
+	ID           uint64 `json:"id"`
+	Type         string `json:"type"`
+	SourceTaskID uint64 `json:"source_task_id"`
+	TargetTaskID uint64 `json:"target_task_id"`
+}
+
+// TaskGraphNode / 依存関係のグラフに含まれるタスク
+type TaskGraphNode struct {
+	ID             uint64 `json:"id"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	StatusCategory string `json:"status_category"`
+}
+
+type TaskGraph struct {
+	RootTaskID uint64           `json:"root_task_id"`
+	Tasks      []*TaskGraphNode `json:"tasks"`
+	Links      []*TaskLink      `json:"links"`
+}
+
+func UnmarshalTaskLink(d *domain.TaskLink) *TaskLink {
+	if d == nil {
+		return nil
+	}
+	return &TaskLink{
+		ID:           uint64(d.ID),
+		Type:         d.Type.String(),
+		SourceTaskID: uint64(d.SourceID),
+		TargetTaskID: uint64(d.TargetID),
+	}
+}
+
+func UnmarshalTaskGraph(d *domain.TaskGraph) *TaskGraph {
+	if d == nil {
+		return nil
+	}
+	graph := &TaskGraph{
+		RootTaskID: uint64(d.RootID),
+		Tasks:      []*TaskGraphNode{},
+		Links:      []*TaskLink{},
+	}
+	for _, task := range d.Tasks {
+		graph.Tasks = append(graph.Tasks, &TaskGraphNode{
+			ID:             uint64(task.ID),
+			Title:          task.Title,
+			Status:         task.Status.Name,
+			StatusCategory: unmarshalTaskStatusCategory(task.Status.Category),
+		})
+	}
+	for _, link := range d.Links {
+		graph.Links = append(graph.Links, UnmarshalTaskLink(link))
+	}
+	return graph
+}
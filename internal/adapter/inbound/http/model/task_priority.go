@@ -0,0 +1,22 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+type TaskPriority struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+func UnmarshalTaskPriority(d *domain.TaskPriority) *TaskPriority {
+	if d == nil {
+		return nil
+	}
+	return &TaskPriority{
+		ID:    uint64(d.ID),
+		Name:  d.Name,
+		Level: d.Level,
+	}
+}
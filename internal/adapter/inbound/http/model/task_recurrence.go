@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+// TaskRecurrence / タスクの属する系列と繰り返しの規則
+type TaskRecurrence struct {
+	SeriesID uint64 `json:"series_id"`
+	// Occurrence / 系列の何回目のタスクか（1始まり）
+	Occurrence int        `json:"occurrence"`
+	Freq       string     `json:"freq"`
+	Interval   int        `json:"interval"`
+	ByDay      []string   `json:"by_day"`
+	Until      *time.Time `json:"until,omitempty"`
+	Count      *int       `json:"count,omitempty"`
+	// RRule / RRULE (RFC 5545) 形式の規則
+	RRule string `json:"rrule"`
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func UnmarshalTaskRecurrence(d *domain.Task) *TaskRecurrence {
+	if d == nil || d.Series == nil {
+		return nil
+	}
+	recurrence := d.Series.Recurrence
+	byDay := []string{}
+	for _, weekday := range recurrence.Weekdays {
+		byDay = append(byDay, weekdayNames[weekday])
+	}
+	return &TaskRecurrence{
+		SeriesID:   uint64(d.Series.ID),
+		Occurrence: d.Occurrence,
+		Freq:       recurrence.Frequency.String(),
+		Interval:   recurrence.Interval,
+		ByDay:      byDay,
+		Until:      recurrence.Until,
+		Count:      recurrence.Count,
+		RRule:      recurrence.String(),
+	}
+}
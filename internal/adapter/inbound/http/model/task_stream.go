@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+// TaskStreamEvent / リアルタイムに送るタスクの変更。SSE の data として送る。
+type TaskStreamEvent struct {
+	ID uint64 `json:"id"`
+	// Type / task.created, task.updated, task.status_changed, task.deleted, task.removed のいずれか。
+	// task.removed は公開範囲の変更で閲覧できなくなったタスクで、changes を含まない。
+	Type    string  `json:"type"`
+	TaskID  uint64  `json:"task_id"`
+	ActorID *uint64 `json:"actor_id,omitempty"`
+	// Changes / 変更された項目。作成時はすべての項目の値を含む。
+	Changes    []*TaskStreamChange `json:"changes"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+type TaskStreamChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+func UnmarshalTaskStreamEvent(d *domain.TaskStreamEvent) *TaskStreamEvent {
+	if d == nil {
+		return nil
+	}
+	res := &TaskStreamEvent{
+		ID:         uint64(d.ID),
+		Type:       d.TypeName(),
+		TaskID:     uint64(d.TaskID),
+		ActorID:    (*uint64)(d.ActorID),
+		Changes:    []*TaskStreamChange{},
+		OccurredAt: d.OccurredAt,
+	}
+	for _, change := range d.Changes {
+		res.Changes = append(res.Changes, &TaskStreamChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"strconv"
+	domain "todo_api/internal/domain/model"
+)
+
+// TaskChangeSet / 差分の同期の結果
+type TaskChangeSet struct {
+	// Tasks / 作成または更新され、閲覧できるタスク
+	Tasks []*Task `json:"tasks"`
+	// Tombstones / 削除された、または閲覧できなくなったタスクのID。端末から削除する。
+	Tombstones []uint64 `json:"tombstones"`
+	// Cursor / 次の同期で since に指定する値
+	Cursor string `json:"cursor"`
+	// HasMore / 続きがあるかどうか。true の場合は cursor を指定してすぐに取得し直す。
+	HasMore bool `json:"has_more"`
+}
+
+func UnmarshalTaskChangeSet(d *domain.TaskChangeSet) *TaskChangeSet {
+	if d == nil {
+		return nil
+	}
+	res := &TaskChangeSet{
+		Tasks:      []*Task{},
+		Tombstones: []uint64{},
+		Cursor:     strconv.FormatUint(d.Cursor, 10),
+		HasMore:    d.HasMore,
+	}
+	for _, task := range d.Tasks {
+		res.Tasks = append(res.Tasks, UnmarshalTask(task))
+	}
+	for _, id := range d.Tombstones {
+		res.Tombstones = append(res.Tombstones, uint64(id))
+	}
+	return res
+}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+// TaskExportColumns / 書き出すタスクの列。取り込みの項目名と同じ列はそのまま取り込み直せる。
+var TaskExportColumns = []string{
+	"id", "title", "detail", "status", "visibility", "person_in_charge", "assignees", "watchers",
+	"priority", "start_date", "limit_date", "labels", "parent_id", "create_at", "update_at",
+}
+
+// TaskExport / 書き出すタスク。ユーザは ID で、優先度とラベルは名前で表す。
+type TaskExport struct {
+	ID         uint64  `json:"id"`
+	Title      string  `json:"title"`
+	Detail     *string `json:"detail"`
+	Status     string  `json:"status"`
+	Visibility string  `json:"visibility"`
+	// PersonInCharge / 主担当者のユーザID
+	PersonInCharge *uint64 `json:"person_in_charge"`
+	// Assignees / 主担当者以外の担当者のユーザID
+	Assignees []uint64   `json:"assignees"`
+	Watchers  []uint64   `json:"watchers"`
+	Priority  *string    `json:"priority"`
+	StartDate *time.Time `json:"start_date"`
+	LimitDate *time.Time `json:"limit_date"`
+	Labels    []string   `json:"labels"`
+	ParentID  *uint64    `json:"parent_id"`
+	CreateAt  time.Time  `json:"create_at"`
+	UpdateAt  time.Time  `json:"update_at"`
+}
+
+func UnmarshalTaskExport(d *domain.Task) *TaskExport {
+	if d == nil {
+		return nil
+	}
+	res := &TaskExport{
+		ID:         uint64(d.ID),
+		Title:      d.Title,
+		Detail:     d.Detail,
+		Status:     d.Status.Name,
+		Visibility: unmarshalVisibility(d.Visibility),
+		Assignees:  []uint64{},
+		Watchers:   []uint64{},
+		StartDate:  d.StartDate,
+		LimitDate:  d.LimitDate,
+		Labels:     []string{},
+		ParentID:   (*uint64)(d.ParentID),
+		CreateAt:   d.CreateAt,
+		UpdateAt:   d.UpdateAt,
+	}
+	if d.PersonInCharge != nil {
+		id := uint64(d.PersonInCharge.ID)
+		res.PersonInCharge = &id
+	}
+	for _, assignee := range d.Assignees {
+		if d.PersonInCharge != nil && assignee.ID == d.PersonInCharge.ID {
+			continue
+		}
+		res.Assignees = append(res.Assignees, uint64(assignee.ID))
+	}
+	for _, watcher := range d.Watchers {
+		res.Watchers = append(res.Watchers, uint64(watcher.ID))
+	}
+	if d.Priority != nil {
+		res.Priority = &d.Priority.Name
+	}
+	for _, label := range d.Labels {
+		res.Labels = append(res.Labels, label.Name)
+	}
+	return res
+}
+
+// CSVRecord / TaskExportColumns の順に CSV の1行の値を返す。複数の値は ; 区切りとする。
+func (m *TaskExport) CSVRecord() []string {
+	return []string{
+		strconv.FormatUint(m.ID, 10),
+		m.Title,
+		formatCSVString(m.Detail),
+		m.Status,
+		m.Visibility,
+		formatCSVUint(m.PersonInCharge),
+		formatCSVUints(m.Assignees),
+		formatCSVUints(m.Watchers),
+		formatCSVString(m.Priority),
+		formatCSVTime(m.StartDate),
+		formatCSVTime(m.LimitDate),
+		strings.Join(m.Labels, ";"),
+		formatCSVUint(m.ParentID),
+		m.CreateAt.Format(time.RFC3339),
+		m.UpdateAt.Format(time.RFC3339),
+	}
+}
+
+func formatCSVString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatCSVUint(n *uint64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatUint(*n, 10)
+}
+
+func formatCSVUints(ns []uint64) string {
+	var values []string
+	for _, n := range ns {
+		values = append(values, strconv.FormatUint(n, 10))
+	}
+	return strings.Join(values, ";")
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// TaskImportJob / タスクの取り込みの状態と進捗
+type TaskImportJob struct {
+	ID uint64 `json:"id"`
+	// Source / FILE, TRELLO, JIRA, GITHUB のいずれか
+	Source string `json:"source"`
+	DryRun bool   `json:"dry_run"`
+	// Status / PENDING, RUNNING, SUCCEEDED, FAILED のいずれか
+	Status string `json:"status"`
+	// Total / 取り込む行の件数
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	// Succeeded / 作成した（dry_run の場合は検証を通った）行の件数
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// Skipped / 取り込み済みのため作成しなかった行の件数
+	Skipped int `json:"skipped"`
+	// Errors / 行ごとの誤り。先頭の1000件まで。
+	Errors []*TaskImportError `json:"errors"`
+	// UnmatchedUsers / 他のツールからの取り込みで企業のユーザと一致せず、割り当てなかったユーザ
+	UnmatchedUsers []string `json:"unmatched_users"`
+	// LastError / 処理を続けられなかった理由
+	LastError  *string    `json:"last_error"`
+	CreateAt   time.Time  `json:"create_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// TaskImportError / 取り込む行の誤り
+type TaskImportError struct {
+	// Line / CSV の場合はヘッダを1行目とした行番号、JSON の場合は配列の1始まりの番号
+	Line int `json:"line"`
+	// Field / 誤りのある項目。特定できない場合は空。
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func UnmarshalTaskImportJob(d *domain.TaskImportJob) *TaskImportJob {
+	if d == nil {
+		return nil
+	}
+	res := &TaskImportJob{
+		ID:         uint64(d.ID),
+		Source:     d.Source.String(),
+		DryRun:     d.DryRun,
+		Status:     d.Status.String(),
+		Total:      d.Total,
+		Processed:  d.Processed,
+		Succeeded:  d.Succeeded,
+		Failed:     d.Failed,
+		Skipped:    d.Skipped,
+		Errors:     []*TaskImportError{},
+		LastError:  d.LastError,
+		CreateAt:   d.CreateAt,
+		StartedAt:  d.StartedAt,
+		FinishedAt: d.FinishedAt,
+
+		UnmatchedUsers: append([]string{}, d.UnmatchedUsers...),
+	}
+	for _, err := range d.Errors {
+		res.Errors = append(res.Errors, &TaskImportError{
+			Line:    err.Line,
+			Field:   err.Field,
+			Message: err.Message,
+		})
+	}
+	return res
+}
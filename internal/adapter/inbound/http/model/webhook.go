@@ -0,0 +1,100 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type Webhook struct {
+	ID  uint64 `json:"id"`
+	URL string `json:"url"`
+	// Secret / ペイロードの署名の鍵
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	// Failures / 連続して配信に失敗した回数
+	Failures int `json:"failures"`
+	// DisabledAt / 失敗が続いたために無効にした日時
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreateAt   time.Time  `json:"create_at"`
+	UpdateAt   time.Time  `json:"update_at"`
+}
+
+type WebhookDelivery struct {
+	ID        uint64 `json:"id"`
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	Payload   string `json:"payload"`
+	// Status / PENDING, SUCCEEDED, FAILED のいずれか
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// ResponseCode / 最後の送信の応答のステータスコード。応答がなかった場合は含まない。
+	ResponseCode *int       `json:"response_code,omitempty"`
+	LastError    *string    `json:"last_error,omitempty"`
+	RedeliveryOf *uint64    `json:"redelivery_of,omitempty"`
+	CreateAt     time.Time  `json:"create_at"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+}
+
+// WebhookDeliveryPage / 配信の記録の1ページ分
+type WebhookDeliveryPage struct {
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+	Total      int                `json:"total"`
+	Page       int                `json:"page"`
+	PerPage    int                `json:"per_page"`
+}
+
+func UnmarshalWebhook(d *domain.Webhook) *Webhook {
+	if d == nil {
+		return nil
+	}
+	res := &Webhook{
+		ID:         uint64(d.ID),
+		URL:        d.URL,
+		Secret:     d.Secret,
+		EventTypes: []string{},
+		Active:     d.Active,
+		Failures:   d.Failures,
+		DisabledAt: d.DisabledAt,
+		CreateAt:   d.CreateAt,
+		UpdateAt:   d.UpdateAt,
+	}
+	for _, eventType := range d.EventTypes {
+		res.EventTypes = append(res.EventTypes, eventType.String())
+	}
+	return res
+}
+
+func UnmarshalWebhookDelivery(d *domain.WebhookDelivery) *WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	return &WebhookDelivery{
+		ID:            uint64(d.ID),
+		EventID:       d.EventID,
+		EventType:     d.EventType.String(),
+		Payload:       d.Payload,
+		Status:        d.Status.String(),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		ResponseCode:  d.ResponseCode,
+		LastError:     d.LastError,
+		RedeliveryOf:  (*uint64)(d.RedeliveryOf),
+		CreateAt:      d.CreateAt,
+		DeliveredAt:   d.DeliveredAt,
+	}
+}
+
+func UnmarshalWebhookDeliveryPage(d []*domain.WebhookDelivery, total int, page domain.Page) *WebhookDeliveryPage {
+	res := &WebhookDeliveryPage{
+		Deliveries: []*WebhookDelivery{},
+		Total:      total,
+		Page:       page.Number,
+		PerPage:    page.Size,
+	}
+	for _, delivery := range d {
+		res.Deliveries = append(res.Deliveries, UnmarshalWebhookDelivery(delivery))
+	}
+	return res
+}
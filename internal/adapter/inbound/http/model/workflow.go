@@ -0,0 +1,87 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+type Workflow struct {
+	Statuses    []*TaskStatus           `json:"statuses"`
+	Transitions []*TaskStatusTransition `json:"transitions"`
+}
+
+type TaskStatus struct {
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type TaskStatusTransition struct {
+	ID           uint64  `json:"id"`
+	FromStatusID uint64  `json:"from_status_id"`
+	ToStatusID   uint64  `json:"to_status_id"`
+	Role         *string `json:"role,omitempty"`
+	UserType     *string `json:"user_type,omitempty"`
+}
+
+func unmarshalTaskStatusCategory(d domain.TaskStatusCategory) string {
+	switch d {
+	case domain.TaskStatusCategoryOpen:
+		return "OPEN"
+	case domain.TaskStatusCategoryInProgress:
+		return "IN_PROGRESS"
+	case domain.TaskStatusCategoryClosed:
+		return "CLOSED"
+	default:
+		return ""
+	}
+}
+
+func UnmarshalTaskStatus(d *domain.TaskStatus) *TaskStatus {
+	if d == nil {
+		return nil
+	}
+	return &TaskStatus{
+		ID:        uint64(d.ID),
+		Name:      d.Name,
+		Category:  unmarshalTaskStatusCategory(d.Category),
+		SortOrder: d.SortOrder,
+	}
+}
+
+func UnmarshalTaskStatusTransition(d *domain.TaskStatusTransition) *TaskStatusTransition {
+	if d == nil {
+		return nil
+	}
+	transition := &TaskStatusTransition{
+		ID:           uint64(d.ID),
+		FromStatusID: uint64(d.FromStatusID),
+		ToStatusID:   uint64(d.ToStatusID),
+	}
+	if d.Role != nil {
+		role := unmarshalRole(*d.Role)
+		transition.Role = &role
+	}
+	if d.UserType != nil {
+		userType := unmarshalUserType(*d.UserType)
+		transition.UserType = &userType
+	}
+	return transition
+}
+
+func UnmarshalWorkflow(d *domain.Workflow) *Workflow {
+	if d == nil {
+		return nil
+	}
+	res := &Workflow{
+		Statuses:    []*TaskStatus{},
+		Transitions: []*TaskStatusTransition{},
+	}
+	for _, status := range d.SortedStatuses() {
+		res.Statuses = append(res.Statuses, UnmarshalTaskStatus(status))
+	}
+	for _, transition := range d.Transitions {
+		res.Transitions = append(res.Transitions, UnmarshalTaskStatusTransition(transition))
+	}
+	return res
+}
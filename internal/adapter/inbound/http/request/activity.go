@@ -0,0 +1,76 @@
+package request
+
+import (
+	"strconv"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// ActivityList / タイムラインのクエリパラメータ
+type ActivityList struct {
+	ActorID string `query:"actor_id"`
+	TaskID  string `query:"task_id"`
+	// Type / カンマ区切りの出来事の種類。例: TASK_CREATED,TASK_COMPLETED
+	Type string `query:"type"`
+	// Cursor / 前のページのレスポンスの next_cursor
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+func MarshalActivityListParams(req *ActivityList) (*domain.ActivityFilter, *domain.ActivityCursor, apperr.AppErr) {
+	if req == nil {
+		cursor := domain.NewActivityCursor(0, 0)
+		return &domain.ActivityFilter{}, &cursor, nil
+	}
+	filter := &domain.ActivityFilter{}
+	if req.ActorID != "" {
+		actorID, err := strconv.ParseUint(req.ActorID, 10, 64)
+		if err != nil {
+			return nil, nil, apperr.NewBadRequestError().Wrap(err)
+		}
+		filter.ActorID = (*domain.UserIdentifier)(&actorID)
+	}
+	if req.TaskID != "" {
+		taskID, err := strconv.ParseUint(req.TaskID, 10, 64)
+		if err != nil {
+			return nil, nil, apperr.NewBadRequestError().Wrap(err)
+		}
+		filter.TaskID = (*domain.TaskIdentifier)(&taskID)
+	}
+	for _, s := range splitQuery(req.Type) {
+		activityType, err := marshalActivityType(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter.Types = append(filter.Types, *activityType)
+	}
+
+	var after uint64
+	if req.Cursor != "" {
+		var err error
+		if after, err = strconv.ParseUint(req.Cursor, 10, 64); err != nil {
+			return nil, nil, apperr.NewBadRequestError().SetMessage("cursor is invalid")
+		}
+	}
+	cursor := domain.NewActivityCursor(domain.ActivityIdentifier(after), req.Limit)
+	return filter, &cursor, nil
+}
+
+func marshalActivityType(s string) (*domain.ActivityType, apperr.AppErr) {
+	var activityType domain.ActivityType
+	switch s {
+	case "TASK_CREATED":
+		activityType = domain.ActivityTypeTaskCreated
+	case "TASK_ASSIGNED":
+		activityType = domain.ActivityTypeTaskAssigned
+	case "TASK_COMPLETED":
+		activityType = domain.ActivityTypeTaskCompleted
+	case "TASK_COMMENTED":
+		activityType = domain.ActivityTypeTaskCommented
+	case "USER_CREATED":
+		activityType = domain.ActivityTypeUserCreated
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("activity type is invalid")
+	}
+	return &activityType, nil
+}
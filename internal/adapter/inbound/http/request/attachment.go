@@ -0,0 +1,46 @@
+package request
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// sniffLength / 内容から種類を判定するために読み込むバイト数
+const sniffLength = 512
+
+// MarshalAttachmentUploadParams / multipart で受け取ったファイルから登録に必要な情報を生成する。
+// 種類はクライアントの申告ではなく内容から判定し、判定できない場合のみ申告された種類を使う。
+func MarshalAttachmentUploadParams(userID uint64, header *multipart.FileHeader, file multipart.File) (*usecase.AttachmentUploadParams, apperr.AppErr) {
+	if header == nil || file == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	contentType := http.DetectContentType(buf[:n])
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/octet-stream" {
+		if declared := header.Header.Get("Content-Type"); declared != "" {
+			contentType = declared
+		}
+	}
+
+	return &usecase.AttachmentUploadParams{
+		FileName:    header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+		Body:        file,
+		CreatorID:   domain.UserIdentifier(userID),
+	}, nil
+}
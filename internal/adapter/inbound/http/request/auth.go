@@ -13,7 +13,7 @@ type AuthCreate struct {
 	UserType string `json:"user_type"`
 }
 
-func MarshalAuthCreateParams(companyID uint64, req *AuthCreate) (*usecase.AuthCreateParams, apperr.AppErr) {
+func MarshalAuthCreateParams(companyID, creatorID uint64, req *AuthCreate) (*usecase.AuthCreateParams, apperr.AppErr) {
 	if req == nil {
 		return nil, nil
 	}
@@ -31,6 +31,7 @@ func MarshalAuthCreateParams(companyID uint64, req *AuthCreate) (*usecase.AuthCr
 		Role:      *role,
 		UserType:  *userType,
 		CompanyID: domain.CompanyIdentifier(companyID),
+		CreatorID: domain.UserIdentifier(creatorID),
 	}, nil
 }
 
@@ -59,6 +60,34 @@ func MarshalAuthUpdateParams(companyID uint64, req *AuthUpdate) (*usecase.AuthUp
 	}, nil
 }
 
+// MarshalAuthPatchParams / JSON Merge Patch をユーザの部分更新に変換する。項目名は AuthUpdate と同じ。
+func MarshalAuthPatchParams(patch MergePatch) (*usecase.AuthPatchParams, apperr.AppErr) {
+	if err := patch.validateKeys("name", "role", "user_type"); err != nil {
+		return nil, err
+	}
+	params := &usecase.AuthPatchParams{}
+
+	var err apperr.AppErr
+	if params.Name, err = decodeRequiredPatch[string](patch, "name"); err != nil {
+		return nil, err
+	}
+	role, err := decodeRequiredPatch[string](patch, "role")
+	if err != nil {
+		return nil, err
+	}
+	if params.Role, err = marshalPatch(role, marshalUserRole); err != nil {
+		return nil, err
+	}
+	userType, err := decodeRequiredPatch[string](patch, "user_type")
+	if err != nil {
+		return nil, err
+	}
+	if params.UserType, err = marshalPatch(userType, marshalUserType); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
 type AuthLogin struct {
 	ID       uint64 `json:"id"`
 	Password string `json:"password"`
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"strings"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// CalendarFeed / カレンダーの購読のクエリパラメータ。絞り込みと並び順はタスク一覧と同じで、URL に含めて保存した絞り込みとして使う。
+type CalendarFeed struct {
+	TaskList
+	// Scope / assigned（自身が担当するタスク、既定）または company（企業の閲覧できるタスク）
+	Scope string `query:"scope"`
+	// Component / vtodo（既定）または vevent
+	Component string `query:"component"`
+}
+
+func MarshalCalendarFeedParams(req *CalendarFeed) (*usecase.CalendarFeedParams, apperr.AppErr) {
+	scope, err := marshalCalendarFeedScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := MarshalTaskListParams(&req.TaskList)
+	if err != nil {
+		return nil, err
+	}
+	return &usecase.CalendarFeedParams{
+		Scope: *scope,
+		Tasks: *tasks,
+	}, nil
+}
+
+func marshalCalendarFeedScope(s string) (*domain.CalendarFeedScope, apperr.AppErr) {
+	var scope domain.CalendarFeedScope
+	switch strings.ToUpper(s) {
+	case "", "ASSIGNED":
+		scope = domain.CalendarFeedScopeAssigned
+	case "COMPANY":
+		scope = domain.CalendarFeedScopeCompany
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("scope must be assigned or company")
+	}
+	return &scope, nil
+}
@@ -0,0 +1,49 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type CommentCreate struct {
+	// Body / 本文。@ユーザ名 で同じ企業のユーザをメンションできる。
+	Body string `json:"body"`
+}
+
+type CommentUpdate struct {
+	Body string `json:"body"`
+}
+
+// CommentList / コメント一覧のクエリパラメータ
+type CommentList struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+func MarshalCommentCreateParams(userID uint64, req *CommentCreate) (*usecase.CommentParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.CommentParams{
+		Body:   req.Body,
+		UserID: domain.UserIdentifier(userID),
+	}, nil
+}
+
+func MarshalCommentUpdateParams(userID uint64, req *CommentUpdate) (*usecase.CommentParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.CommentParams{
+		Body:   req.Body,
+		UserID: domain.UserIdentifier(userID),
+	}, nil
+}
+
+func MarshalCommentListPage(req *CommentList) domain.Page {
+	if req == nil {
+		return domain.NewPage(0, 0)
+	}
+	return domain.NewPage(req.Page, req.PerPage)
+}
@@ -1,5 +1,10 @@
 package request
 
+import (
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
 type CompanyCreate struct {
 	Name string `json:"name"`
 }
@@ -7,3 +12,11 @@ type CompanyCreate struct {
 type CompanyUpdate struct {
 	Name string `json:"name"`
 }
+
+// MarshalCompanyPatchName / JSON Merge Patch から企業名の変更を取り出す。項目名は CompanyUpdate と同じ。
+func MarshalCompanyPatchName(patch MergePatch) (usecase.Patch[string], apperr.AppErr) {
+	if err := patch.validateKeys("name"); err != nil {
+		return usecase.Patch[string]{}, err
+	}
+	return decodeRequiredPatch[string](patch, "name")
+}
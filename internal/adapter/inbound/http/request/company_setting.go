@@ -0,0 +1,32 @@
+package request
+
+import (
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type CompanySettingUpdate struct {
+	// MaxTaskDepth / サブタスクの階層の深さの上限（1〜10）
+	MaxTaskDepth int `json:"max_task_depth"`
+	// RequireClosedSubtasks / 未完了の子タスクがある親タスクを完了にできないようにするか
+	RequireClosedSubtasks bool `json:"require_closed_subtasks"`
+	// MaxAttachmentSize / 添付ファイル1件あたりの容量の上限（バイト、100MiBまで）
+	MaxAttachmentSize int64 `json:"max_attachment_size"`
+	// AllowedMimeTypes / 添付できるファイルの種類（image/png, image/* など）
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+	// StorageQuota / 企業の添付ファイルの合計容量の上限（バイト、100GiBまで）
+	StorageQuota int64 `json:"storage_quota"`
+}
+
+func MarshalCompanySettingUpdateParams(req *CompanySettingUpdate) (*usecase.CompanySettingParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.CompanySettingParams{
+		MaxTaskDepth:          req.MaxTaskDepth,
+		RequireClosedSubtasks: req.RequireClosedSubtasks,
+		MaxAttachmentSize:     req.MaxAttachmentSize,
+		AllowedMimeTypes:      req.AllowedMimeTypes,
+		StorageQuota:          req.StorageQuota,
+	}, nil
+}
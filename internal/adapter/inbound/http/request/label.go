@@ -0,0 +1,59 @@
+package request
+
+import (
+	"strings"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type LabelCreate struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type LabelUpdate struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func MarshalLabelCreateParams(req *LabelCreate) (*usecase.LabelParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.LabelParams{
+		Name:  req.Name,
+		Color: req.Color,
+	}, nil
+}
+
+func MarshalLabelUpdateParams(req *LabelUpdate) (*usecase.LabelParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.LabelParams{
+		Name:  req.Name,
+		Color: req.Color,
+	}, nil
+}
+
+func marshalLabelIDs(ids []uint64) []domain.LabelIdentifier {
+	var labelIDs []domain.LabelIdentifier
+	for _, id := range ids {
+		labelIDs = append(labelIDs, domain.LabelIdentifier(id))
+	}
+	return labelIDs
+}
+
+func marshalLabelMatch(s string) (*domain.LabelMatch, apperr.AppErr) {
+	var match domain.LabelMatch
+	switch strings.ToUpper(s) {
+	case "", "ANY":
+		match = domain.LabelMatchAny
+	case "ALL":
+		match = domain.LabelMatchAll
+	default:
+		return nil, apperr.NewBadRequestError()
+	}
+	return &match, nil
+}
@@ -0,0 +1,118 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// NotificationList / 通知一覧のクエリパラメータ
+type NotificationList struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+type NotificationPreferenceUpdate struct {
+	// InApp / 種類ごとにアプリ内で通知するかどうか。ASSIGNED, MENTIONED, STATUS_CHANGED, DUE_SOON。指定のない種類は変更しない。
+	InApp map[string]bool `json:"in_app"`
+	// Email / 種類ごとにメールで通知するかどうか。指定のない種類は変更しない。
+	Email map[string]bool `json:"email"`
+	// EmailAddress / 通知を送るメールアドレス。空文字で削除する。
+	EmailAddress *string `json:"email_address"`
+	// EmailLanguage / メールの言語。ja または en
+	EmailLanguage *string `json:"email_language"`
+	// EmailMode / OFF（送らない）、IMMEDIATE（通知のたびに送る）、DIGEST（1日分をまとめて送る）
+	EmailMode *string `json:"email_mode"`
+}
+
+func MarshalNotificationListPage(req *NotificationList) domain.Page {
+	if req == nil {
+		return domain.NewPage(0, 0)
+	}
+	return domain.NewPage(req.Page, req.PerPage)
+}
+
+func MarshalNotificationPreferenceParams(userID uint64, req *NotificationPreferenceUpdate) (*usecase.NotificationPreferenceParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	params := &usecase.NotificationPreferenceParams{
+		EmailAddress: req.EmailAddress,
+		UserID:       domain.UserIdentifier(userID),
+	}
+	var err apperr.AppErr
+	if params.InApp, err = marshalNotificationTypeFlags(req.InApp); err != nil {
+		return nil, err
+	}
+	if params.Email, err = marshalNotificationTypeFlags(req.Email); err != nil {
+		return nil, err
+	}
+	if req.EmailLanguage != nil {
+		if params.EmailLanguage, err = marshalLanguage(*req.EmailLanguage); err != nil {
+			return nil, err
+		}
+	}
+	if req.EmailMode != nil {
+		if params.EmailMode, err = marshalEmailMode(*req.EmailMode); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+func marshalNotificationTypeFlags(flags map[string]bool) (map[domain.NotificationType]bool, apperr.AppErr) {
+	res := make(map[domain.NotificationType]bool)
+	for s, enabled := range flags {
+		notificationType, err := marshalNotificationType(s)
+		if err != nil {
+			return nil, err
+		}
+		res[*notificationType] = enabled
+	}
+	return res, nil
+}
+
+func marshalLanguage(s string) (*domain.Language, apperr.AppErr) {
+	var language domain.Language
+	switch s {
+	case "ja":
+		language = domain.LanguageJapanese
+	case "en":
+		language = domain.LanguageEnglish
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("email_language is invalid")
+	}
+	return &language, nil
+}
+
+func marshalEmailMode(s string) (*domain.EmailMode, apperr.AppErr) {
+	var mode domain.EmailMode
+	switch s {
+	case "OFF":
+		mode = domain.EmailModeOff
+	case "IMMEDIATE":
+		mode = domain.EmailModeImmediate
+	case "DIGEST":
+		mode = domain.EmailModeDigest
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("email_mode is invalid")
+	}
+	return &mode, nil
+}
+
+func marshalNotificationType(s string) (*domain.NotificationType, apperr.AppErr) {
+	var notificationType domain.NotificationType
+	switch s {
+	case "ASSIGNED":
+		notificationType = domain.NotificationTypeAssigned
+	case "MENTIONED":
+		notificationType = domain.NotificationTypeMentioned
+	case "STATUS_CHANGED":
+		notificationType = domain.NotificationTypeStatusChanged
+	case "DUE_SOON":
+		notificationType = domain.NotificationTypeDueSoon
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("notification type is invalid")
+	}
+	return &notificationType, nil
+}
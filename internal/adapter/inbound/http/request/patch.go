@@ -0,0 +1,93 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// MergePatch / RFC 7396 の JSON Merge Patch。キーのない項目は変更せず、null の項目は値を消去する。
+type MergePatch map[string]json.RawMessage
+
+// ParseMergePatch / 本文を JSON Merge Patch として読み込む。オブジェクト以外は不正とする。
+func ParseMergePatch(body io.Reader) (MergePatch, apperr.AppErr) {
+	var patch MergePatch
+	if err := json.NewDecoder(body).Decode(&patch); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if patch == nil {
+		return nil, apperr.NewBadRequestError().SetMessage("merge patch must be a JSON object")
+	}
+	return patch, nil
+}
+
+// validateKeys / 更新できない項目が含まれていないことを検証する
+func (p MergePatch) validateKeys(keys ...string) apperr.AppErr {
+	for key := range p {
+		if !slices.Contains(keys, key) {
+			return apperr.NewBadRequestError().SetMessage(fmt.Sprintf("%s cannot be patched", key))
+		}
+	}
+	return nil
+}
+
+// decodePatch / 項目を取り出す。null の場合は値を消去する指定となる。
+func decodePatch[T any](patch MergePatch, key string) (usecase.Patch[T], apperr.AppErr) {
+	raw, ok := patch[key]
+	if !ok {
+		return usecase.Patch[T]{}, nil
+	}
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return usecase.Patch[T]{Set: true}, nil
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return usecase.Patch[T]{}, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("%s: %s", key, err.Error()))
+	}
+	return usecase.NewPatch(value), nil
+}
+
+// decodeRequiredPatch / 消去できない項目を取り出す。null は不正とする。
+func decodeRequiredPatch[T any](patch MergePatch, key string) (usecase.Patch[T], apperr.AppErr) {
+	p, err := decodePatch[T](patch, key)
+	if err != nil {
+		return p, err
+	}
+	if p.Set && p.Value == nil {
+		return usecase.Patch[T]{}, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("%s cannot be null", key))
+	}
+	return p, nil
+}
+
+// mapPatch / 項目の値を変換する
+func mapPatch[T, U any](p usecase.Patch[T], convert func(T) U) usecase.Patch[U] {
+	if p.Value == nil {
+		return usecase.Patch[U]{Set: p.Set}
+	}
+	return usecase.NewPatch(convert(*p.Value))
+}
+
+// marshalPatch / 項目の値を検証しながら変換する
+func marshalPatch[T, U any](p usecase.Patch[T], marshal func(T) (*U, apperr.AppErr)) (usecase.Patch[U], apperr.AppErr) {
+	if p.Value == nil {
+		return usecase.Patch[U]{Set: p.Set}, nil
+	}
+	value, err := marshal(*p.Value)
+	if err != nil {
+		return usecase.Patch[U]{}, err
+	}
+	return usecase.NewPatch(*value), nil
+}
+
+// emptyPatch / null を空の値に置き換える。消去が空にすることを意味する配列の項目に利用する。
+func emptyPatch[T any](p usecase.Patch[T]) usecase.Patch[T] {
+	if p.Set && p.Value == nil {
+		var empty T
+		return usecase.NewPatch(empty)
+	}
+	return p
+}
@@ -0,0 +1,44 @@
+package request
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+// splitQuery / カンマ区切りのクエリパラメータを分割する。空の要素は除外する。
+func splitQuery(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitQueryUint / カンマ区切りの数値のクエリパラメータを分割する
+func splitQueryUint(s string) ([]uint64, apperr.AppErr) {
+	var values []uint64
+	for _, v := range splitQuery(s) {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// parseQueryTime / RFC3339 形式のクエリパラメータを解析する。未指定の場合は nil を返す。
+func parseQueryTime(s string) (*time.Time, apperr.AppErr) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	return &t, nil
+}
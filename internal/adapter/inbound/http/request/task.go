@@ -1,6 +1,8 @@
 package request
 
 import (
+	"strconv"
+	"strings"
 	"time"
 	domain "todo_api/internal/domain/model"
 	"todo_api/internal/lib/apperr"
@@ -12,7 +14,17 @@ type TaskCreate struct {
 	Detail           *string    `json:"detail"`
 	Visibility       string     `json:"visibility"`
 	PersonInChargeID *uint64    `json:"person_in_charge_id"`
+	Priority         *string    `json:"priority"`
+	StartDate        *time.Time `json:"start_date"`
 	LimitDate        *time.Time `json:"limit_date"`
+	LabelIDs         []uint64   `json:"label_ids"`
+	// ParentID / 親タスクのID。未指定の場合は最上位のタスク。
+	ParentID  *uint64         `json:"parent_id"`
+	Checklist []ChecklistItem `json:"checklist"`
+	// AssigneeIDs / 主担当者（person_in_charge_id）以外の担当者のID
+	AssigneeIDs []uint64 `json:"assignee_ids"`
+	// WatcherIDs / 担当はせずにタスクをフォローするユーザのID
+	WatcherIDs []uint64 `json:"watcher_ids"`
 }
 
 type TaskUpdate struct {
@@ -21,7 +33,40 @@ type TaskUpdate struct {
 	Visibility       string     `json:"visibility"`
 	Status           string     `json:"status"`
 	PersonInChargeID *uint64    `json:"person_in_charge_id"`
+	Priority         *string    `json:"priority"`
+	StartDate        *time.Time `json:"start_date"`
 	LimitDate        *time.Time `json:"limit_date"`
+	LabelIDs         []uint64   `json:"label_ids"`
+	// ParentID / 親タスクのID。未指定の場合は最上位のタスク。
+	ParentID  *uint64         `json:"parent_id"`
+	Checklist []ChecklistItem `json:"checklist"`
+	// AssigneeIDs / 主担当者（person_in_charge_id）以外の担当者のID
+	AssigneeIDs []uint64 `json:"assignee_ids"`
+	// WatcherIDs / 担当はせずにタスクをフォローするユーザのID
+	WatcherIDs []uint64 `json:"watcher_ids"`
+}
+
+// ChecklistItem / チェックリストの項目。配列の順序が表示順となる。
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// TaskList / タスク一覧のクエリパラメータ。複数指定はカンマ区切り、日時は RFC3339 形式。
+type TaskList struct {
+	Status        string `query:"status"`
+	Priority      string `query:"priority"`
+	StartDateFrom string `query:"start_date_from"`
+	StartDateTo   string `query:"start_date_to"`
+	LimitDateFrom string `query:"limit_date_from"`
+	LimitDateTo   string `query:"limit_date_to"`
+	LabelIDs      string `query:"label_ids"`
+	// LabelMatch / ラベルの一致方法。any（いずれか、既定）または all（すべて）
+	LabelMatch string `query:"label_match"`
+	// ParentID / 指定した親タスクの子タスクのみを取得する
+	ParentID string `query:"parent_id"`
+	// Sort / 並び順。先頭に - を付けると降順。例: -priority,limit_date
+	Sort string `query:"sort"`
 }
 
 func MarshalTaskCreateParams(userID uint64, req *TaskCreate) (*usecase.TaskCreateParams, apperr.AppErr) {
@@ -37,7 +82,14 @@ func MarshalTaskCreateParams(userID uint64, req *TaskCreate) (*usecase.TaskCreat
 		Detail:           req.Detail,
 		Visibility:       *visibility,
 		PersonInChargeID: (*domain.UserIdentifier)(req.PersonInChargeID),
+		Priority:         req.Priority,
+		StartDate:        req.StartDate,
 		LimitDate:        req.LimitDate,
+		LabelIDs:         marshalLabelIDs(req.LabelIDs),
+		ParentID:         (*domain.TaskIdentifier)(req.ParentID),
+		Checklist:        marshalChecklist(req.Checklist),
+		AssigneeIDs:      marshalUserIDs(req.AssigneeIDs),
+		WatcherIDs:       marshalUserIDs(req.WatcherIDs),
 		CreatorID:        domain.UserIdentifier(userID),
 	}, nil
 }
@@ -50,34 +102,213 @@ func MarshalTaskUpdateParams(userID uint64, req *TaskUpdate) (*usecase.TaskUpdat
 	if err != nil {
 		return nil, err
 	}
-	status, err := MarshalTaskStatus(req.Status)
-	if err != nil {
-		return nil, err
-	}
 	return &usecase.TaskUpdateParams{
 		Title:            req.Title,
 		Detail:           req.Detail,
 		Visibility:       *visibility,
-		Status:           *status,
+		Status:           req.Status,
 		PersonInChargeID: (*domain.UserIdentifier)(req.PersonInChargeID),
+		Priority:         req.Priority,
+		StartDate:        req.StartDate,
 		LimitDate:        req.LimitDate,
+		LabelIDs:         marshalLabelIDs(req.LabelIDs),
+		ParentID:         (*domain.TaskIdentifier)(req.ParentID),
+		Checklist:        marshalChecklist(req.Checklist),
+		AssigneeIDs:      marshalUserIDs(req.AssigneeIDs),
+		WatcherIDs:       marshalUserIDs(req.WatcherIDs),
 		UpdatorID:        domain.UserIdentifier(userID),
 	}, nil
 }
 
-func MarshalTaskStatus(s string) (*domain.TaskStatus, apperr.AppErr) {
-	var status domain.TaskStatus
+// MarshalTaskPatchParams / JSON Merge Patch をタスクの部分更新に変換する。項目名は TaskUpdate と同じ。
+func MarshalTaskPatchParams(userID uint64, patch MergePatch) (*usecase.TaskPatchParams, apperr.AppErr) {
+	if err := patch.validateKeys(
+		"title", "detail", "visibility", "status", "person_in_charge_id", "priority",
+		"start_date", "limit_date", "label_ids", "parent_id", "checklist",
+		"assignee_ids", "watcher_ids",
+	); err != nil {
+		return nil, err
+	}
+	params := &usecase.TaskPatchParams{
+		UpdatorID: domain.UserIdentifier(userID),
+	}
+
+	var err apperr.AppErr
+	if params.Title, err = decodeRequiredPatch[string](patch, "title"); err != nil {
+		return nil, err
+	}
+	if params.Detail, err = decodePatch[string](patch, "detail"); err != nil {
+		return nil, err
+	}
+	visibility, err := decodeRequiredPatch[string](patch, "visibility")
+	if err != nil {
+		return nil, err
+	}
+	if params.Visibility, err = marshalPatch(visibility, marshalTaskVisibility); err != nil {
+		return nil, err
+	}
+	if params.Status, err = decodeRequiredPatch[string](patch, "status"); err != nil {
+		return nil, err
+	}
+	personInChargeID, err := decodePatch[uint64](patch, "person_in_charge_id")
+	if err != nil {
+		return nil, err
+	}
+	params.PersonInChargeID = mapPatch(personInChargeID, func(id uint64) domain.UserIdentifier {
+		return domain.UserIdentifier(id)
+	})
+	if params.Priority, err = decodePatch[string](patch, "priority"); err != nil {
+		return nil, err
+	}
+	if params.StartDate, err = decodePatch[time.Time](patch, "start_date"); err != nil {
+		return nil, err
+	}
+	if params.LimitDate, err = decodePatch[time.Time](patch, "limit_date"); err != nil {
+		return nil, err
+	}
+	// 配列は RFC 7396 に従って全体を置き換える。null は空にする指定となる。
+	labelIDs, err := decodePatch[[]uint64](patch, "label_ids")
+	if err != nil {
+		return nil, err
+	}
+	params.LabelIDs = emptyPatch(mapPatch(labelIDs, marshalLabelIDs))
+	parentID, err := decodePatch[uint64](patch, "parent_id")
+	if err != nil {
+		return nil, err
+	}
+	params.ParentID = mapPatch(parentID, func(id uint64) domain.TaskIdentifier {
+		return domain.TaskIdentifier(id)
+	})
+	checklist, err := decodePatch[[]ChecklistItem](patch, "checklist")
+	if err != nil {
+		return nil, err
+	}
+	params.Checklist = emptyPatch(mapPatch(checklist, marshalChecklist))
+	assigneeIDs, err := decodePatch[[]uint64](patch, "assignee_ids")
+	if err != nil {
+		return nil, err
+	}
+	params.AssigneeIDs = emptyPatch(mapPatch(assigneeIDs, marshalUserIDs))
+	watcherIDs, err := decodePatch[[]uint64](patch, "watcher_ids")
+	if err != nil {
+		return nil, err
+	}
+	params.WatcherIDs = emptyPatch(mapPatch(watcherIDs, marshalUserIDs))
+	return params, nil
+}
+
+func MarshalTaskUpdateStatusParams(userID uint64, status string, force string) (*usecase.TaskUpdateStatusParams, apperr.AppErr) {
+	params := &usecase.TaskUpdateStatusParams{
+		Status:    status,
+		UpdatorID: domain.UserIdentifier(userID),
+	}
+	if force != "" {
+		var err error
+		if params.Force, err = strconv.ParseBool(force); err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+	}
+	return params, nil
+}
+
+func MarshalTaskListParams(req *TaskList) (*usecase.TaskListParams, apperr.AppErr) {
+	if req == nil {
+		return &usecase.TaskListParams{}, nil
+	}
+	params := &usecase.TaskListParams{
+		Statuses:   splitQuery(req.Status),
+		Priorities: splitQuery(req.Priority),
+	}
+
+	var err apperr.AppErr
+	if params.StartDateFrom, err = parseQueryTime(req.StartDateFrom); err != nil {
+		return nil, err
+	}
+	if params.StartDateTo, err = parseQueryTime(req.StartDateTo); err != nil {
+		return nil, err
+	}
+	if params.LimitDateFrom, err = parseQueryTime(req.LimitDateFrom); err != nil {
+		return nil, err
+	}
+	if params.LimitDateTo, err = parseQueryTime(req.LimitDateTo); err != nil {
+		return nil, err
+	}
+
+	labelIDs, err := splitQueryUint(req.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+	params.LabelIDs = marshalLabelIDs(labelIDs)
+	labelMatch, err := marshalLabelMatch(req.LabelMatch)
+	if err != nil {
+		return nil, err
+	}
+	params.LabelMatch = *labelMatch
+	parentIDs, err := splitQueryUint(req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parentIDs) > 1 {
+		return nil, apperr.NewBadRequestError()
+	}
+	if len(parentIDs) == 1 {
+		parentID := domain.TaskIdentifier(parentIDs[0])
+		params.ParentID = &parentID
+	}
+
+	for _, s := range splitQuery(req.Sort) {
+		sort := domain.TaskSort{}
+		if strings.HasPrefix(s, "-") {
+			sort.Desc = true
+			s = strings.TrimPrefix(s, "-")
+		}
+		key, err := marshalTaskSortKey(s)
+		if err != nil {
+			return nil, err
+		}
+		sort.Key = *key
+		params.Sorts = append(params.Sorts, sort)
+	}
+
+	return params, nil
+}
+
+func marshalUserIDs(ids []uint64) []domain.UserIdentifier {
+	var userIDs []domain.UserIdentifier
+	for _, id := range ids {
+		userIDs = append(userIDs, domain.UserIdentifier(id))
+	}
+	return userIDs
+}
+
+func marshalChecklist(items []ChecklistItem) []domain.ChecklistItemDescription {
+	var descs []domain.ChecklistItemDescription
+	for _, item := range items {
+		descs = append(descs, domain.ChecklistItemDescription{
+			Text: item.Text,
+			Done: item.Done,
+		})
+	}
+	return descs
+}
+
+func marshalTaskSortKey(s string) (*domain.TaskSortKey, apperr.AppErr) {
+	var key domain.TaskSortKey
 	switch s {
-	case "NEW":
-		status = domain.TaskStatusNew
-	case "PROCESSING":
-		status = domain.TaskStatusProcessing
-	case "DONE":
-		status = domain.TaskStatusDone
+	case "create_at":
+		key = domain.TaskSortKeyCreateAt
+	case "update_at":
+		key = domain.TaskSortKeyUpdateAt
+	case "start_date":
+		key = domain.TaskSortKeyStartDate
+	case "limit_date":
+		key = domain.TaskSortKeyLimitDate
+	case "priority":
+		key = domain.TaskSortKeyPriority
 	default:
 		return nil, apperr.NewBadRequestError()
 	}
-	return &status, nil
+	return &key, nil
 }
 
 func marshalTaskVisibility(s string) (*domain.TaskVisibility, apperr.AppErr) {
@@ -0,0 +1,99 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// TaskBulk / タスクの一括操作のリクエスト
+type TaskBulk struct {
+	// Mode / atomic（すべて成功した場合のみ保存、既定）または best_effort（成功した項目のみ保存）
+	Mode  string         `json:"mode"`
+	Items []TaskBulkItem `json:"items"`
+}
+
+// TaskBulkItem / 一つのタスクへの操作。op に応じた項目のみを指定する。
+type TaskBulkItem struct {
+	TaskID uint64 `json:"task_id"`
+	// Op / set_status, reassign, add_label, remove_label, set_visibility, delete のいずれか
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	Force  bool   `json:"force"`
+	// PersonInChargeID / reassign の担当者。null の場合は担当者を外す。
+	PersonInChargeID *uint64 `json:"person_in_charge_id"`
+	LabelID          uint64  `json:"label_id"`
+	Visibility       string  `json:"visibility"`
+}
+
+func MarshalTaskBulkParams(userID uint64, companyID uint64, req *TaskBulk) (*usecase.TaskBulkParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	mode, err := marshalTaskBulkMode(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	params := &usecase.TaskBulkParams{
+		CompanyID: domain.CompanyIdentifier(companyID),
+		Mode:      *mode,
+		UpdatorID: domain.UserIdentifier(userID),
+	}
+	for _, reqItem := range req.Items {
+		item, err := marshalTaskBulkItem(reqItem)
+		if err != nil {
+			return nil, err
+		}
+		params.Items = append(params.Items, *item)
+	}
+	return params, nil
+}
+
+func marshalTaskBulkItem(req TaskBulkItem) (*usecase.TaskBulkItem, apperr.AppErr) {
+	item := &usecase.TaskBulkItem{
+		TaskID: domain.TaskIdentifier(req.TaskID),
+	}
+	switch req.Op {
+	case "set_status":
+		item.Operation = usecase.TaskBulkOperationSetStatus
+		item.Status = req.Status
+		item.Force = req.Force
+	case "reassign":
+		item.Operation = usecase.TaskBulkOperationReassign
+		if req.PersonInChargeID != nil {
+			id := domain.UserIdentifier(*req.PersonInChargeID)
+			item.PersonInChargeID = &id
+		}
+	case "add_label":
+		item.Operation = usecase.TaskBulkOperationAddLabel
+		item.LabelID = domain.LabelIdentifier(req.LabelID)
+	case "remove_label":
+		item.Operation = usecase.TaskBulkOperationRemoveLabel
+		item.LabelID = domain.LabelIdentifier(req.LabelID)
+	case "set_visibility":
+		item.Operation = usecase.TaskBulkOperationSetVisibility
+		visibility, err := marshalTaskVisibility(req.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		item.Visibility = *visibility
+	case "delete":
+		item.Operation = usecase.TaskBulkOperationDelete
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("op is invalid")
+	}
+	return item, nil
+}
+
+func marshalTaskBulkMode(s string) (*usecase.TaskBulkMode, apperr.AppErr) {
+	var mode usecase.TaskBulkMode
+	switch s {
+	case "", "atomic":
+		mode = usecase.TaskBulkModeAtomic
+	case "best_effort":
+		mode = usecase.TaskBulkModeBestEffort
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("mode is invalid")
+	}
+	return &mode, nil
+}
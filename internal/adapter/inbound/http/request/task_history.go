@@ -0,0 +1,18 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+)
+
+// TaskHistoryList / 変更履歴のクエリパラメータ
+type TaskHistoryList struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+func MarshalTaskHistoryListPage(req *TaskHistoryList) domain.Page {
+	if req == nil {
+		return domain.NewPage(0, 0)
+	}
+	return domain.NewPage(req.Page, req.PerPage)
+}
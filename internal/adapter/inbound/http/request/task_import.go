@@ -0,0 +1,344 @@
+package request
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// TaskExport / タスクの書き出しのクエリパラメータ。絞り込みと並び順はタスク一覧と同じ。
+type TaskExport struct {
+	TaskList
+	// Format / csv（既定）または json
+	Format string `query:"format"`
+}
+
+// TaskImport / タスクの取り込みのフォームの値。ファイルは file で受け取る。
+type TaskImport struct {
+	// Format / csv, json または他のツールの trello, jira, github。未指定の場合はファイル名の拡張子から判定する。
+	Format string `form:"format"`
+	// Mapping / 項目名から列名（JSON の場合はキー）への対応の JSON。指定のない項目は項目名と同じ列を読む。
+	Mapping string `form:"mapping"`
+	// StatusMapping / 他のツールのステータスから企業のステータスの名前への対応の JSON。対応のないステータスは同じ名前とする。
+	StatusMapping string `form:"status_mapping"`
+	// PriorityMapping / 他のツールの優先度から企業の優先度の名前への対応の JSON。対応のない優先度は同じ名前とする。
+	PriorityMapping string `form:"priority_mapping"`
+	// DryRun / 検証のみを行い、タスクを作成しない
+	DryRun bool `form:"dry_run"`
+}
+
+// taskImportFields / 取り込める項目。列名の既定値を兼ねる。
+var taskImportFields = []string{
+	"title", "detail", "visibility", "person_in_charge", "assignees", "watchers",
+	"priority", "start_date", "limit_date", "labels", "parent_id",
+}
+
+// taskImportMultiFields / 複数の値を取る項目。CSV では ; 区切りで指定する。
+var taskImportMultiFields = map[string]bool{
+	"assignees": true,
+	"watchers":  true,
+	"labels":    true,
+}
+
+// taskImportRecord / 項目名から値への対応。値のない項目は含まない。
+type taskImportRecord map[string][]string
+
+// MarshalTaskImportRows / 取り込むファイルを読み、列の対応に従って行に変換する。
+// ファイル全体を読めない場合はエラーを返し、行ごとの誤りは各行に記録する。
+func MarshalTaskImportRows(req *TaskImport, fileName string, file io.Reader) ([]*domain.TaskImportRow, apperr.AppErr) {
+	if req == nil || file == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+
+	format := taskImportFormat(req, fileName)
+	switch format {
+	case "csv", "json":
+		mapping, err := marshalTaskImportMapping(req.Mapping)
+		if err != nil {
+			return nil, err
+		}
+		if format == "csv" {
+			return parseTaskImportCSV(file, mapping)
+		}
+		return parseTaskImportJSON(file, mapping)
+	case "trello", "jira", "github":
+		mapping, err := marshalTaskImportValueMapping(req)
+		if err != nil {
+			return nil, err
+		}
+		switch format {
+		case "trello":
+			return parseTrelloBoard(file, mapping)
+		case "jira":
+			return parseJiraCSV(file, mapping)
+		default:
+			return parseGitHubIssues(file, mapping)
+		}
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("format must be csv, json, trello, jira or github")
+	}
+}
+
+// MarshalTaskImportSource / 取り込むファイルの形式から元となるツールを判定する
+func MarshalTaskImportSource(req *TaskImport, fileName string) (*domain.TaskImportSource, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	var source domain.TaskImportSource
+	switch taskImportFormat(req, fileName) {
+	case "csv", "json":
+		source = domain.TaskImportSourceFile
+	case "trello":
+		source = domain.TaskImportSourceTrello
+	case "jira":
+		source = domain.TaskImportSourceJira
+	case "github":
+		source = domain.TaskImportSourceGitHub
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("format must be csv, json, trello, jira or github")
+	}
+	return &source, nil
+}
+
+// taskImportFormat / 指定された形式。未指定の場合はファイル名の拡張子とする。
+func taskImportFormat(req *TaskImport, fileName string) string {
+	if req.Format != "" {
+		return req.Format
+	}
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+}
+
+// marshalTaskImportMapping / 列の対応を解析し、すべての項目の列名を返す
+func marshalTaskImportMapping(s string) (map[string]string, apperr.AppErr) {
+	mapping := map[string]string{}
+	if s != "" {
+		if err := json.Unmarshal([]byte(s), &mapping); err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+	}
+	columns := map[string]string{}
+	for _, field := range taskImportFields {
+		columns[field] = field
+	}
+	for field, column := range mapping {
+		if _, ok := columns[field]; !ok {
+			return nil, apperr.NewBadRequestError().SetMessage("unknown field in mapping: " + field)
+		}
+		columns[field] = column
+	}
+	return columns, nil
+}
+
+func parseTaskImportCSV(file io.Reader, mapping map[string]string) ([]*domain.TaskImportRow, apperr.AppErr) {
+	reader := csv.NewReader(file)
+	// 列の数が揃っていない行も読み、足りない列は空として扱う
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if len(header) > 0 {
+		// 表計算ソフトが付ける BOM を取り除く
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	indexes := map[string]int{}
+	for i, column := range header {
+		indexes[strings.TrimSpace(column)] = i
+	}
+	if _, ok := indexes[mapping["title"]]; !ok {
+		return nil, apperr.NewBadRequestError().SetMessage("title column is not found: " + mapping["title"])
+	}
+
+	var rows []*domain.TaskImportRow
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+		if len(rows) >= domain.MaxTaskImportRows {
+			return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("rows must be less than or equal %d", domain.MaxTaskImportRows))
+		}
+		line, _ := reader.FieldPos(0)
+
+		record := taskImportRecord{}
+		for field, column := range mapping {
+			i, ok := indexes[column]
+			if !ok || i >= len(values) {
+				continue
+			}
+			value := strings.TrimSpace(values[i])
+			if value == "" {
+				continue
+			}
+			if taskImportMultiFields[field] {
+				record[field] = splitTaskImportValues(value)
+			} else {
+				record[field] = []string{value}
+			}
+		}
+		rows = append(rows, marshalTaskImportRow(line, record, nil))
+	}
+	return rows, nil
+}
+
+func parseTaskImportJSON(file io.Reader, mapping map[string]string) ([]*domain.TaskImportRow, apperr.AppErr) {
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if len(objects) > domain.MaxTaskImportRows {
+		return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("rows must be less than or equal %d", domain.MaxTaskImportRows))
+	}
+
+	var rows []*domain.TaskImportRow
+	for i, object := range objects {
+		line := i + 1
+		record := taskImportRecord{}
+		var errs []*domain.TaskImportError
+		for field, key := range mapping {
+			values, ok := marshalTaskImportJSONValue(object[key])
+			if !ok {
+				errs = append(errs, &domain.TaskImportError{Line: line, Field: field, Message: "value must be a string, number or array of them"})
+				continue
+			}
+			if len(values) > 0 {
+				record[field] = values
+			}
+		}
+		rows = append(rows, marshalTaskImportRow(line, record, errs))
+	}
+	return rows, nil
+}
+
+// marshalTaskImportJSONValue / JSON の値を文字列の値に変換する。null や空文字は値なしとする。
+func marshalTaskImportJSONValue(v any) ([]string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, true
+	case string:
+		if v = strings.TrimSpace(v); v == "" {
+			return nil, true
+		}
+		return []string{v}, true
+	case json.Number:
+		return []string{v.String()}, true
+	case bool:
+		return []string{strconv.FormatBool(v)}, true
+	case []any:
+		var values []string
+		for _, e := range v {
+			value, ok := marshalTaskImportJSONValue(e)
+			if !ok || len(value) > 1 {
+				return nil, false
+			}
+			values = append(values, value...)
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+// marshalTaskImportRow / 値を型に合わせて変換する。変換できない項目は誤りとして行に記録する。
+func marshalTaskImportRow(line int, record taskImportRecord, errs []*domain.TaskImportError) *domain.TaskImportRow {
+	row := &domain.TaskImportRow{
+		Line:   line,
+		Errors: errs,
+	}
+	reject := func(field, message string) {
+		row.Errors = append(row.Errors, &domain.TaskImportError{Line: line, Field: field, Message: message})
+	}
+	single := func(field string) *string {
+		values := record[field]
+		switch len(values) {
+		case 0:
+			return nil
+		case 1:
+			return &values[0]
+		default:
+			reject(field, "must be a single value")
+			return nil
+		}
+	}
+
+	if title := single("title"); title != nil {
+		row.Title = *title
+	}
+	row.Detail = single("detail")
+	if s := single("visibility"); s != nil {
+		visibility, err := marshalTaskVisibility(strings.ToUpper(*s))
+		if err != nil {
+			reject("visibility", "must be ME or COMPANY")
+		} else {
+			row.Visibility = *visibility
+		}
+	}
+	row.PersonInCharge = single("person_in_charge")
+	row.Assignees = record["assignees"]
+	row.Watchers = record["watchers"]
+	row.Priority = single("priority")
+	for _, date := range []struct {
+		field string
+		value **time.Time
+	}{
+		{"start_date", &row.StartDate},
+		{"limit_date", &row.LimitDate},
+	} {
+		s := single(date.field)
+		if s == nil {
+			continue
+		}
+		t, err := parseTaskImportDate(*s)
+		if err != nil {
+			reject(date.field, "must be YYYY-MM-DD or RFC3339")
+			continue
+		}
+		*date.value = t
+	}
+	row.Labels = record["labels"]
+	if s := single("parent_id"); s != nil {
+		id, err := strconv.ParseUint(*s, 10, 64)
+		if err != nil {
+			reject("parent_id", "must be a task ID")
+		} else {
+			parentID := domain.TaskIdentifier(id)
+			row.ParentID = &parentID
+		}
+	}
+	return row
+}
+
+// parseTaskImportDate / 日付（YYYY-MM-DD）または RFC3339 形式の日時を解析する。日付はサーバのタイムゾーンの0時とする。
+func parseTaskImportDate(s string) (*time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// splitTaskImportValues / ; 区切りの値を分割する
+func splitTaskImportValues(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,455 @@
+package request
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// jiraDateLayouts / Jira の CSV の日時の形式。言語や設定によって異なるため順に試す。
+var jiraDateLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	time.RFC3339,
+}
+
+// taskImportValueMapping / 他のツールのステータスと優先度から企業の定義の名前への対応
+type taskImportValueMapping struct {
+	statuses   map[string]string
+	priorities map[string]string
+}
+
+func marshalTaskImportValueMapping(req *TaskImport) (*taskImportValueMapping, apperr.AppErr) {
+	mapping := &taskImportValueMapping{
+		statuses:   map[string]string{},
+		priorities: map[string]string{},
+	}
+	if req.StatusMapping != "" {
+		if err := json.Unmarshal([]byte(req.StatusMapping), &mapping.statuses); err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+	}
+	if req.PriorityMapping != "" {
+		if err := json.Unmarshal([]byte(req.PriorityMapping), &mapping.priorities); err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+	}
+	return mapping, nil
+}
+
+// status / ステータスを対応に従って変換する。対応のない場合はそのままとし、空の場合は nil とする。
+func (m *taskImportValueMapping) status(s string) *string {
+	return mapTaskImportValue(m.statuses, s)
+}
+
+// priority / 優先度を対応に従って変換する。対応のない場合はそのままとし、空の場合は nil とする。
+func (m *taskImportValueMapping) priority(s string) *string {
+	return mapTaskImportValue(m.priorities, s)
+}
+
+func mapTaskImportValue(mapping map[string]string, s string) *string {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	if mapped, ok := mapping[s]; ok {
+		s = mapped
+	}
+	return &s
+}
+
+// optionalString / 空の場合は nil とする
+func optionalString(s string) *string {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	return &s
+}
+
+type trelloBoard struct {
+	Cards []struct {
+		ID        string     `json:"id"`
+		Name      string     `json:"name"`
+		Desc      string     `json:"desc"`
+		IDList    string     `json:"idList"`
+		IDMembers []string   `json:"idMembers"`
+		IDLabels  []string   `json:"idLabels"`
+		Start     *time.Time `json:"start"`
+		Due       *time.Time `json:"due"`
+	} `json:"cards"`
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Labels []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Checklists []struct {
+		IDCard     string  `json:"idCard"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+	Actions []struct {
+		Type string    `json:"type"`
+		Date time.Time `json:"date"`
+		Data struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+		MemberCreator struct {
+			FullName string `json:"fullName"`
+			Username string `json:"username"`
+		} `json:"memberCreator"`
+	} `json:"actions"`
+}
+
+// parseTrelloBoard / Trello のボードの JSON を読む。リストをステータス、メンバーを担当者、チェックリストとコメントはそのまま取り込む。
+func parseTrelloBoard(file io.Reader, mapping *taskImportValueMapping) ([]*domain.TaskImportRow, apperr.AppErr) {
+	var board trelloBoard
+	if err := json.NewDecoder(file).Decode(&board); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if len(board.Cards) > domain.MaxTaskImportRows {
+		return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("rows must be less than or equal %d", domain.MaxTaskImportRows))
+	}
+
+	lists := map[string]string{}
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+	}
+	labels := map[string]string{}
+	for _, label := range board.Labels {
+		labels[label.ID] = label.Name
+	}
+	members := map[string]string{}
+	for _, member := range board.Members {
+		members[member.ID] = member.FullName
+		if member.FullName == "" {
+			members[member.ID] = member.Username
+		}
+	}
+	sort.SliceStable(board.Checklists, func(i, j int) bool {
+		return board.Checklists[i].Pos < board.Checklists[j].Pos
+	})
+	checklists := map[string][]domain.ChecklistItemDescription{}
+	for _, checklist := range board.Checklists {
+		items := checklist.CheckItems
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Pos < items[j].Pos
+		})
+		for _, item := range items {
+			checklists[checklist.IDCard] = append(checklists[checklist.IDCard], domain.ChecklistItemDescription{
+				Text: item.Name,
+				Done: item.State == "complete",
+			})
+		}
+	}
+	// エクスポートは新しい順のため、投稿順に並べ直す
+	sort.SliceStable(board.Actions, func(i, j int) bool {
+		return board.Actions[i].Date.Before(board.Actions[j].Date)
+	})
+	comments := map[string][]*domain.TaskImportComment{}
+	for _, action := range board.Actions {
+		if action.Type != "commentCard" {
+			continue
+		}
+		author := action.MemberCreator.FullName
+		if author == "" {
+			author = action.MemberCreator.Username
+		}
+		date := action.Date
+		comments[action.Data.Card.ID] = append(comments[action.Data.Card.ID], &domain.TaskImportComment{
+			Author:   author,
+			Body:     action.Data.Text,
+			CreateAt: &date,
+		})
+	}
+
+	var rows []*domain.TaskImportRow
+	for i, card := range board.Cards {
+		id := card.ID
+		row := &domain.TaskImportRow{
+			Line:       i + 1,
+			Title:      strings.TrimSpace(card.Name),
+			Detail:     optionalString(card.Desc),
+			Status:     mapping.status(lists[card.IDList]),
+			StartDate:  card.Start,
+			LimitDate:  card.Due,
+			Checklist:  checklists[card.ID],
+			ExternalID: &id,
+			Comments:   comments[card.ID],
+		}
+		for j, memberID := range card.IDMembers {
+			member, ok := members[memberID]
+			if !ok || member == "" {
+				continue
+			}
+			// 最初のメンバーを主担当者とする
+			if j == 0 {
+				row.PersonInCharge = &member
+			} else {
+				row.Assignees = append(row.Assignees, member)
+			}
+		}
+		for _, labelID := range card.IDLabels {
+			// 名前のない色のみのラベルは取り込まない
+			if name := labels[labelID]; name != "" {
+				row.Labels = append(row.Labels, name)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJiraCSV / Jira の課題の CSV を読む。ラベルやウォッチャー、コメントのように同じ名前の列が複数ある場合はすべての値を読む。
+// 親の課題を先に取り込むよう、親を持たない課題から順に並べる。
+func parseJiraCSV(file io.Reader, mapping *taskImportValueMapping) ([]*domain.TaskImportRow, apperr.AppErr) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	indexes := map[string][]int{}
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		indexes[column] = append(indexes[column], i)
+	}
+	if _, ok := indexes["Summary"]; !ok {
+		return nil, apperr.NewBadRequestError().SetMessage("Summary column is not found")
+	}
+
+	var rows []*domain.TaskImportRow
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperr.NewBadRequestError().Wrap(err)
+		}
+		if len(rows) >= domain.MaxTaskImportRows {
+			return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("rows must be less than or equal %d", domain.MaxTaskImportRows))
+		}
+		line, _ := reader.FieldPos(0)
+
+		all := func(column string) []string {
+			var res []string
+			for _, i := range indexes[column] {
+				if i < len(values) {
+					if value := strings.TrimSpace(values[i]); value != "" {
+						res = append(res, value)
+					}
+				}
+			}
+			return res
+		}
+		first := func(columns ...string) string {
+			for _, column := range columns {
+				if res := all(column); len(res) > 0 {
+					return res[0]
+				}
+			}
+			return ""
+		}
+
+		row := &domain.TaskImportRow{
+			Line:             line,
+			Title:            first("Summary"),
+			Detail:           optionalString(first("Description")),
+			Status:           mapping.status(first("Status")),
+			Priority:         mapping.priority(first("Priority")),
+			PersonInCharge:   optionalString(first("Assignee")),
+			Watchers:         all("Watchers"),
+			Labels:           all("Labels"),
+			ExternalID:       optionalString(first("Issue id", "Issue key")),
+			ParentExternalID: optionalString(first("Parent id", "Parent")),
+		}
+		reject := func(field, message string) {
+			row.Errors = append(row.Errors, &domain.TaskImportError{Line: line, Field: field, Message: message})
+		}
+		for _, date := range []struct {
+			column string
+			value  **time.Time
+		}{
+			{"Custom field (Start date)", &row.StartDate},
+			{"Due date", &row.LimitDate},
+		} {
+			s := first(date.column)
+			if s == "" {
+				continue
+			}
+			t, err := parseJiraDate(s)
+			if err != nil {
+				reject(date.column, "date format is not supported: "+s)
+				continue
+			}
+			*date.value = t
+		}
+		for _, value := range all("Comment") {
+			row.Comments = append(row.Comments, parseJiraComment(value))
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].ParentExternalID == nil && rows[j].ParentExternalID != nil
+	})
+	return rows, nil
+}
+
+func parseJiraDate(s string) (*time.Time, error) {
+	var err error
+	for _, layout := range jiraDateLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, err
+}
+
+// parseJiraComment / 「日時;投稿者;本文」の形式のコメントを読む。形式が異なる場合は全体を本文とする。
+func parseJiraComment(s string) *domain.TaskImportComment {
+	parts := strings.SplitN(s, ";", 3)
+	if len(parts) != 3 {
+		return &domain.TaskImportComment{Body: s}
+	}
+	comment := &domain.TaskImportComment{
+		Author: strings.TrimSpace(parts[1]),
+		Body:   parts[2],
+	}
+	if t, err := parseJiraDate(strings.TrimSpace(parts[0])); err == nil {
+		comment.CreateAt = t
+	}
+	return comment
+}
+
+// githubIssue / REST API と gh issue list --json のどちらの形式も読む
+type githubIssue struct {
+	ID     json.RawMessage `json:"id"`
+	Number int             `json:"number"`
+	Title  string          `json:"title"`
+	Body   *string         `json:"body"`
+	State  string          `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		DueOn    *time.Time `json:"due_on"`
+		DueOnCLI *time.Time `json:"dueOn"`
+	} `json:"milestone"`
+	// Comments / REST API では件数、gh では配列
+	Comments    json.RawMessage `json:"comments"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type githubComment struct {
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body      string     `json:"body"`
+	CreatedAt *time.Time `json:"createdAt"`
+	CreateAt  *time.Time `json:"created_at"`
+}
+
+// parseGitHubIssues / GitHub の Issue の JSON の配列を読む。状態（OPEN, CLOSED）をステータスとし、プルリクエストは取り込まない。
+func parseGitHubIssues(file io.Reader, mapping *taskImportValueMapping) ([]*domain.TaskImportRow, apperr.AppErr) {
+	var issues []*githubIssue
+	if err := json.NewDecoder(file).Decode(&issues); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+	if len(issues) > domain.MaxTaskImportRows {
+		return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("rows must be less than or equal %d", domain.MaxTaskImportRows))
+	}
+
+	var rows []*domain.TaskImportRow
+	for i, issue := range issues {
+		if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+			continue
+		}
+		row := &domain.TaskImportRow{
+			Line:   i + 1,
+			Title:  strings.TrimSpace(issue.Title),
+			Status: mapping.status(strings.ToUpper(issue.State)),
+		}
+		if issue.Body != nil {
+			row.Detail = optionalString(*issue.Body)
+		}
+		// ID は REST API では数値、gh では文字列。ない場合は番号とする。
+		externalID := strings.Trim(string(issue.ID), `"`)
+		if externalID == "" || externalID == "null" {
+			externalID = fmt.Sprint(issue.Number)
+		}
+		row.ExternalID = &externalID
+		for j, assignee := range issue.Assignees {
+			login := assignee.Login
+			if j == 0 {
+				row.PersonInCharge = &login
+			} else {
+				row.Assignees = append(row.Assignees, login)
+			}
+		}
+		for _, label := range issue.Labels {
+			row.Labels = append(row.Labels, label.Name)
+		}
+		if issue.Milestone != nil {
+			row.LimitDate = issue.Milestone.DueOn
+			if row.LimitDate == nil {
+				row.LimitDate = issue.Milestone.DueOnCLI
+			}
+		}
+		var comments []*githubComment
+		if strings.HasPrefix(strings.TrimSpace(string(issue.Comments)), "[") {
+			if err := json.Unmarshal(issue.Comments, &comments); err != nil {
+				row.Errors = append(row.Errors, &domain.TaskImportError{Line: row.Line, Field: "comments", Message: err.Error()})
+			}
+		}
+		for _, comment := range comments {
+			author := comment.Author.Login
+			if author == "" {
+				author = comment.User.Login
+			}
+			createAt := comment.CreatedAt
+			if createAt == nil {
+				createAt = comment.CreateAt
+			}
+			row.Comments = append(row.Comments, &domain.TaskImportComment{
+				Author:   author,
+				Body:     comment.Body,
+				CreateAt: createAt,
+			})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
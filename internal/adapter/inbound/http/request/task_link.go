@@ -0,0 +1,43 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type TaskLinkCreate struct {
+	// Type / BLOCKS（このタスクが対象のタスクをブロックする）、RELATES_TO、DUPLICATES（このタスクが対象のタスクと重複する）
+	Type         string `json:"type"`
+	TargetTaskID uint64 `json:"target_task_id"`
+}
+
+func MarshalTaskLinkCreateParams(userID uint64, req *TaskLinkCreate) (*usecase.TaskLinkCreateParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	linkType, err := marshalTaskLinkType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &usecase.TaskLinkCreateParams{
+		Type:      *linkType,
+		TargetID:  domain.TaskIdentifier(req.TargetTaskID),
+		CreatorID: domain.UserIdentifier(userID),
+	}, nil
+}
+
+func marshalTaskLinkType(s string) (*domain.TaskLinkType, apperr.AppErr) {
+	var linkType domain.TaskLinkType
+	switch s {
+	case "BLOCKS":
+		linkType = domain.TaskLinkTypeBlocks
+	case "RELATES_TO":
+		linkType = domain.TaskLinkTypeRelatesTo
+	case "DUPLICATES":
+		linkType = domain.TaskLinkTypeDuplicates
+	default:
+		return nil, apperr.NewBadRequestError()
+	}
+	return &linkType, nil
+}
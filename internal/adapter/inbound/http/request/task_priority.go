@@ -0,0 +1,36 @@
+package request
+
+import (
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type TaskPriorityCreate struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+type TaskPriorityUpdate struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+func MarshalTaskPriorityCreateParams(req *TaskPriorityCreate) (*usecase.TaskPriorityParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.TaskPriorityParams{
+		Name:  req.Name,
+		Level: req.Level,
+	}, nil
+}
+
+func MarshalTaskPriorityUpdateParams(req *TaskPriorityUpdate) (*usecase.TaskPriorityParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	return &usecase.TaskPriorityParams{
+		Name:  req.Name,
+		Level: req.Level,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package request
+
+import (
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// TaskRecurrence / 繰り返しの規則。RRULE (RFC 5545) の FREQ, INTERVAL, BYDAY, UNTIL, COUNT に相当する。
+type TaskRecurrence struct {
+	// Freq / DAILY, WEEKLY, MONTHLY のいずれか
+	Freq string `json:"freq"`
+	// Interval / 繰り返しの間隔。未指定の場合は1。
+	Interval int `json:"interval"`
+	// ByDay / WEEKLY で期限とする曜日。MO, TU, WE, TH, FR, SA, SU
+	ByDay []string `json:"by_day"`
+	// Until / この日時より後の回は生成しない。count とは同時に指定できない。
+	Until *time.Time `json:"until"`
+	// Count / 最初の回を含めて生成する回数の上限
+	Count *int `json:"count"`
+}
+
+func MarshalTaskRecurrenceParams(userID uint64, req *TaskRecurrence) (*usecase.TaskRecurrenceParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	frequency, err := marshalRecurrenceFrequency(req.Freq)
+	if err != nil {
+		return nil, err
+	}
+	params := &usecase.TaskRecurrenceParams{
+		Frequency: *frequency,
+		Interval:  req.Interval,
+		Until:     req.Until,
+		Count:     req.Count,
+		UpdatorID: domain.UserIdentifier(userID),
+	}
+	if params.Interval == 0 {
+		params.Interval = 1
+	}
+	for _, name := range req.ByDay {
+		weekday, err := marshalWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		params.Weekdays = append(params.Weekdays, *weekday)
+	}
+	return params, nil
+}
+
+// MarshalTaskEditScope / 繰り返しのタスクの編集の範囲。未指定の場合はこの回のみ。
+func MarshalTaskEditScope(s string) (usecase.TaskEditScope, apperr.AppErr) {
+	switch s {
+	case "", "this":
+		return usecase.TaskEditScopeThis, nil
+	case "future":
+		return usecase.TaskEditScopeFuture, nil
+	default:
+		return 0, apperr.NewBadRequestError().SetMessage("scope is invalid")
+	}
+}
+
+func marshalRecurrenceFrequency(s string) (*domain.RecurrenceFrequency, apperr.AppErr) {
+	var frequency domain.RecurrenceFrequency
+	switch strings.ToUpper(s) {
+	case "DAILY":
+		frequency = domain.RecurrenceFrequencyDaily
+	case "WEEKLY":
+		frequency = domain.RecurrenceFrequencyWeekly
+	case "MONTHLY":
+		frequency = domain.RecurrenceFrequencyMonthly
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("freq is invalid")
+	}
+	return &frequency, nil
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func marshalWeekday(s string) (*time.Weekday, apperr.AppErr) {
+	for i, name := range weekdayNames {
+		if name == strings.ToUpper(s) {
+			weekday := time.Weekday(i)
+			return &weekday, nil
+		}
+	}
+	return nil, apperr.NewBadRequestError().SetMessage("by_day is invalid")
+}
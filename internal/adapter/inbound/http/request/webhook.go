@@ -0,0 +1,102 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type WebhookCreate struct {
+	URL string `json:"url"`
+	// EventTypes / 購読する出来事の種類。task.created, task.updated, task.status_changed, task.deleted, comment.created, user.created
+	EventTypes []string `json:"event_types"`
+	// Active / 省略した場合は有効とする
+	Active *bool `json:"active"`
+}
+
+type WebhookUpdate struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Active / 失敗が続いて無効になった Webhook は true で有効に戻す
+	Active bool `json:"active"`
+}
+
+// WebhookDeliveryList / 配信の記録の一覧のクエリパラメータ
+type WebhookDeliveryList struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+func MarshalWebhookCreateParams(req *WebhookCreate) (*usecase.WebhookParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	eventTypes, err := marshalWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	return &usecase.WebhookParams{
+		URL:        req.URL,
+		EventTypes: eventTypes,
+		Active:     active,
+	}, nil
+}
+
+func MarshalWebhookUpdateParams(req *WebhookUpdate) (*usecase.WebhookParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	eventTypes, err := marshalWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &usecase.WebhookParams{
+		URL:        req.URL,
+		EventTypes: eventTypes,
+		Active:     req.Active,
+	}, nil
+}
+
+func MarshalWebhookDeliveryListPage(req *WebhookDeliveryList) domain.Page {
+	if req == nil {
+		return domain.NewPage(0, 0)
+	}
+	return domain.NewPage(req.Page, req.PerPage)
+}
+
+func marshalWebhookEventTypes(names []string) ([]domain.WebhookEventType, apperr.AppErr) {
+	var eventTypes []domain.WebhookEventType
+	for _, name := range names {
+		eventType, err := marshalWebhookEventType(name)
+		if err != nil {
+			return nil, err
+		}
+		eventTypes = append(eventTypes, *eventType)
+	}
+	return eventTypes, nil
+}
+
+func marshalWebhookEventType(s string) (*domain.WebhookEventType, apperr.AppErr) {
+	var eventType domain.WebhookEventType
+	switch s {
+	case "task.created":
+		eventType = domain.WebhookEventTypeTaskCreated
+	case "task.updated":
+		eventType = domain.WebhookEventTypeTaskUpdated
+	case "task.status_changed":
+		eventType = domain.WebhookEventTypeTaskStatusChanged
+	case "task.deleted":
+		eventType = domain.WebhookEventTypeTaskDeleted
+	case "comment.created":
+		eventType = domain.WebhookEventTypeCommentCreated
+	case "user.created":
+		eventType = domain.WebhookEventTypeUserCreated
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("unknown event type: " + s)
+	}
+	return &eventType, nil
+}
@@ -0,0 +1,96 @@
+package request
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+type TaskStatusCreate struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type TaskStatusUpdate struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type TaskStatusTransitionCreate struct {
+	FromStatusID uint64  `json:"from_status_id"`
+	ToStatusID   uint64  `json:"to_status_id"`
+	Role         *string `json:"role"`
+	UserType     *string `json:"user_type"`
+}
+
+func MarshalTaskStatusCreateParams(req *TaskStatusCreate) (*usecase.TaskStatusParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	category, err := marshalTaskStatusCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+	return &usecase.TaskStatusParams{
+		Name:      req.Name,
+		Category:  *category,
+		SortOrder: req.SortOrder,
+	}, nil
+}
+
+func MarshalTaskStatusUpdateParams(req *TaskStatusUpdate) (*usecase.TaskStatusParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	category, err := marshalTaskStatusCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+	return &usecase.TaskStatusParams{
+		Name:      req.Name,
+		Category:  *category,
+		SortOrder: req.SortOrder,
+	}, nil
+}
+
+func MarshalTaskStatusTransitionCreateParams(req *TaskStatusTransitionCreate) (*usecase.TaskStatusTransitionCreateParams, apperr.AppErr) {
+	if req == nil {
+		return nil, apperr.NewBadRequestError()
+	}
+	params := &usecase.TaskStatusTransitionCreateParams{
+		FromStatusID: domain.TaskStatusIdentifier(req.FromStatusID),
+		ToStatusID:   domain.TaskStatusIdentifier(req.ToStatusID),
+	}
+	if req.Role != nil {
+		role, err := marshalUserRole(*req.Role)
+		if err != nil {
+			return nil, err
+		}
+		params.Role = role
+	}
+	if req.UserType != nil {
+		userType, err := marshalUserType(*req.UserType)
+		if err != nil {
+			return nil, err
+		}
+		params.UserType = userType
+	}
+	return params, nil
+}
+
+func marshalTaskStatusCategory(s string) (*domain.TaskStatusCategory, apperr.AppErr) {
+	var category domain.TaskStatusCategory
+	switch s {
+	case "OPEN":
+		category = domain.TaskStatusCategoryOpen
+	case "IN_PROGRESS":
+		category = domain.TaskStatusCategoryInProgress
+	case "CLOSED":
+		category = domain.TaskStatusCategoryClosed
+	default:
+		return nil, apperr.NewBadRequestError()
+	}
+	return &category, nil
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DomainEventScheduler / アウトボックスの出来事を定期的に購読者に配信する。
+// 配信の順序を保つため、複数のプロセスで動かした場合もロックを保持する一つのプロセスのみが配信する。
+type DomainEventScheduler struct {
+	dispatcher usecase.DomainEventDispatcher
+	lock       repository.LeaderLock
+	interval   time.Duration
+	logger     echo.Logger
+}
+
+func NewDomainEventScheduler(dispatcher usecase.DomainEventDispatcher, lock repository.LeaderLock, interval time.Duration, logger echo.Logger) *DomainEventScheduler {
+	return &DomainEventScheduler{
+		dispatcher,
+		lock,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに配信する。終了時にロックを解放する。
+func (s *DomainEventScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(); err != nil {
+			s.logger.Errorf("failed to release domain event lock: %s", err.Message())
+		}
+	}()
+	for {
+		s.dispatch()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DomainEventScheduler) dispatch() {
+	leader, err := s.lock.TryAcquire()
+	if err != nil {
+		s.logger.Errorf("failed to acquire domain event lock: %s", err.Message())
+		return
+	}
+	if !leader {
+		return
+	}
+	dispatched, err := s.dispatcher.Dispatch(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to dispatch domain events: %s", err.Message())
+	}
+	if dispatched > 0 {
+		s.logger.Debugf("dispatched %d domain events", dispatched)
+	}
+}
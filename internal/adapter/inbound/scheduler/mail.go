@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MailScheduler / ダイジェストのメールを送信待ちにし、送信待ちのメールを定期的に送る。
+// 複数のプロセスで動かした場合もロックを保持する一つのプロセスのみが送る。
+type MailScheduler struct {
+	mailUsecase usecase.MailUsecase
+	lock        repository.LeaderLock
+	interval    time.Duration
+	logger      echo.Logger
+}
+
+func NewMailScheduler(mailUsecase usecase.MailUsecase, lock repository.LeaderLock, interval time.Duration, logger echo.Logger) *MailScheduler {
+	return &MailScheduler{
+		mailUsecase,
+		lock,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに送る。終了時にロックを解放する。
+func (s *MailScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(); err != nil {
+			s.logger.Errorf("failed to release mail lock: %s", err.Message())
+		}
+	}()
+	for {
+		s.send()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *MailScheduler) send() {
+	leader, err := s.lock.TryAcquire()
+	if err != nil {
+		s.logger.Errorf("failed to acquire mail lock: %s", err.Message())
+		return
+	}
+	if !leader {
+		return
+	}
+	queued, err := s.mailUsecase.QueueDigests(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to queue digests: %s", err.Message())
+	}
+	if queued > 0 {
+		s.logger.Infof("queued %d digests", queued)
+	}
+	sent, err := s.mailUsecase.DeliverPending(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to deliver mails: %s", err.Message())
+	}
+	if sent > 0 {
+		s.logger.Infof("sent %d mails", sent)
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RecurrenceScheduler / 期限を過ぎた繰り返しのタスクの次の回を定期的に生成する。
+// 同じ回は二度生成されないため、複数のプロセスで動かしてもよい。
+type RecurrenceScheduler struct {
+	taskUsecase usecase.TaskUsecase
+	interval    time.Duration
+	logger      echo.Logger
+}
+
+func NewRecurrenceScheduler(taskUsecase usecase.TaskUsecase, interval time.Duration, logger echo.Logger) *RecurrenceScheduler {
+	return &RecurrenceScheduler{
+		taskUsecase,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに生成する
+func (s *RecurrenceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.generate()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RecurrenceScheduler) generate() {
+	generated, err := s.taskUsecase.GenerateDueOccurrences(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to generate recurring tasks: %s", err.Message())
+	}
+	if generated > 0 {
+		s.logger.Infof("generated %d recurring tasks", generated)
+	}
+}
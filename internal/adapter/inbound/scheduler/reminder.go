@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReminderScheduler / 期限が近い・過ぎたタスクのリマインダーを定期的に送る。
+// 複数のプロセスで動かした場合もロックを保持する一つのプロセスのみが送る。
+type ReminderScheduler struct {
+	taskReminderUsecase usecase.TaskReminderUsecase
+	lock                repository.LeaderLock
+	interval            time.Duration
+	logger              echo.Logger
+}
+
+func NewReminderScheduler(taskReminderUsecase usecase.TaskReminderUsecase, lock repository.LeaderLock, interval time.Duration, logger echo.Logger) *ReminderScheduler {
+	return &ReminderScheduler{
+		taskReminderUsecase,
+		lock,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに送る。終了時にロックを解放する。
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(); err != nil {
+			s.logger.Errorf("failed to release reminder lock: %s", err.Message())
+		}
+	}()
+	for {
+		s.send()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) send() {
+	leader, err := s.lock.TryAcquire()
+	if err != nil {
+		s.logger.Errorf("failed to acquire reminder lock: %s", err.Message())
+		return
+	}
+	if !leader {
+		return
+	}
+	sent, err := s.taskReminderUsecase.SendDueReminders(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to send reminders: %s", err.Message())
+	}
+	if sent > 0 {
+		s.logger.Infof("sent %d reminders", sent)
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TaskImportScheduler / 非同期に処理する取り込みを定期的に処理する。
+// 同じ取り込みを重ねて処理しないよう、複数のプロセスで動かした場合もロックを保持する一つのプロセスのみが処理する。
+type TaskImportScheduler struct {
+	taskImportUsecase usecase.TaskImportUsecase
+	lock              repository.LeaderLock
+	interval          time.Duration
+	logger            echo.Logger
+}
+
+func NewTaskImportScheduler(taskImportUsecase usecase.TaskImportUsecase, lock repository.LeaderLock, interval time.Duration, logger echo.Logger) *TaskImportScheduler {
+	return &TaskImportScheduler{
+		taskImportUsecase,
+		lock,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに処理する。終了時にロックを解放する。
+func (s *TaskImportScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(); err != nil {
+			s.logger.Errorf("failed to release task import lock: %s", err.Message())
+		}
+	}()
+	for {
+		s.run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run / 処理待ちの取り込みがなくなるまで順に処理する
+func (s *TaskImportScheduler) run(ctx context.Context) {
+	leader, err := s.lock.TryAcquire()
+	if err != nil {
+		s.logger.Errorf("failed to acquire task import lock: %s", err.Message())
+		return
+	}
+	if !leader {
+		return
+	}
+	for ctx.Err() == nil {
+		ran, err := s.taskImportUsecase.RunNext(time.Now())
+		if err != nil {
+			s.logger.Errorf("failed to run task import: %s", err.Message())
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
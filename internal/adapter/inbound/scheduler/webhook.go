@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookScheduler / 送信待ちの Webhook の配信を定期的に送る。
+// 複数のプロセスで動かした場合もロックを保持する一つのプロセスのみが送る。
+type WebhookScheduler struct {
+	webhookUsecase usecase.WebhookUsecase
+	lock           repository.LeaderLock
+	interval       time.Duration
+	logger         echo.Logger
+}
+
+func NewWebhookScheduler(webhookUsecase usecase.WebhookUsecase, lock repository.LeaderLock, interval time.Duration, logger echo.Logger) *WebhookScheduler {
+	return &WebhookScheduler{
+		webhookUsecase,
+		lock,
+		interval,
+		logger,
+	}
+}
+
+// Run / ctx が終了するまで起動時と一定の間隔ごとに送る。終了時にロックを解放する。
+func (s *WebhookScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(); err != nil {
+			s.logger.Errorf("failed to release webhook lock: %s", err.Message())
+		}
+	}()
+	for {
+		s.deliver()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *WebhookScheduler) deliver() {
+	leader, err := s.lock.TryAcquire()
+	if err != nil {
+		s.logger.Errorf("failed to acquire webhook lock: %s", err.Message())
+		return
+	}
+	if !leader {
+		return
+	}
+	sent, err := s.webhookUsecase.DeliverPending(time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to deliver webhooks: %s", err.Message())
+	}
+	if sent > 0 {
+		s.logger.Infof("delivered %d webhooks", sent)
+	}
+}
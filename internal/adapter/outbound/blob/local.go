@@ -0,0 +1,93 @@
+package blob
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"todo_api/internal/lib/apperr"
+)
+
+var errInvalidKey = errors.New("blob key must be a relative path inside the store")
+
+// LocalBlobStore / ローカルのファイルシステムに保存するストレージ
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) *LocalBlobStore {
+	return &LocalBlobStore{root}
+}
+
+func (s *LocalBlobStore) Put(key string, body io.Reader, size int64, contentType string) apperr.AppErr {
+	path, err := s.path(key)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+
+	// 書き込み途中のファイルを読まれないよう、一時ファイルに書き込んでから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// 申告より大きい本文を際限なく書き込まないよう、1バイト超えた時点で読むのをやめる
+	written, err := io.Copy(tmp, io.LimitReader(body, size+1))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	if written != size {
+		return apperr.NewBadRequestError().SetMessage("uploaded file size does not match")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, apperr.AppErr) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, apperr.NewNotFoundError()
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return f, nil
+}
+
+func (s *LocalBlobStore) Delete(key string) apperr.AppErr {
+	path, err := s.path(key)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+// path / キーをルート配下のパスに変換する。ルートの外を指すキーは受け付けない。
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || filepath.IsAbs(key) {
+		return "", errInvalidKey
+	}
+	rel := filepath.Clean(filepath.FromSlash(key))
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errInvalidKey
+	}
+	return filepath.Join(s.root, rel), nil
+}
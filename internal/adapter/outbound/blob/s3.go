@@ -0,0 +1,195 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Config / S3 互換ストレージの接続情報。MinIO などの互換実装にはパス形式でアクセスする。
+type S3Config struct {
+	// Endpoint / http://localhost:9000 のようなスキームを含むURL
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3BlobStore / S3 互換のオブジェクトストレージに保存するストレージ
+type S3BlobStore struct {
+	config S3Config
+	client *http.Client
+}
+
+func NewS3BlobStore(config S3Config) *S3BlobStore {
+	return &S3BlobStore{
+		config: config,
+		client: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s *S3BlobStore) Put(key string, body io.Reader, size int64, contentType string) apperr.AppErr {
+	req, err := s.newRequest(http.MethodPut, key, body)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.do(req)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return apperr.NewInternalServerError().Wrap(s3Error(res))
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(key string) (io.ReadCloser, apperr.AppErr) {
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	res, err := s.do(req)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		defer res.Body.Close()
+		return nil, apperr.NewNotFoundError().Wrap(s3Error(res))
+	default:
+		defer res.Body.Close()
+		return nil, apperr.NewInternalServerError().Wrap(s3Error(res))
+	}
+}
+
+func (s *S3BlobStore) Delete(key string) apperr.AppErr {
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+
+	res, err := s.do(req)
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	defer res.Body.Close()
+
+	// S3 は存在しないキーの削除にも 204 を返す
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return apperr.NewInternalServerError().Wrap(s3Error(res))
+	}
+	return nil
+}
+
+func (s *S3BlobStore) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" {
+		return nil, errInvalidKey
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(s.config.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = "/" + s.config.Bucket + "/" + key
+	endpoint.RawPath = s3EscapePath(endpoint.Path)
+	return http.NewRequest(method, endpoint.String(), body)
+}
+
+func (s *S3BlobStore) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign / 署名バージョン4でリクエストに署名する。本文はストリームで送るため署名の対象外とする。
+func (s *S3BlobStore) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := strings.Join([]string{date, s.config.Region, s3Service, "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// s3EscapePath / パスの各セグメントを RFC 3986 の非予約文字以外すべてエスケープする
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func s3Error(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3: %s: %s", res.Status, strings.TrimSpace(string(body)))
+}
+
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
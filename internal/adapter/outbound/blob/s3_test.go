@@ -0,0 +1,224 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+const (
+	testS3Region          = "ap-northeast-1"
+	testS3Bucket          = "attachments"
+	testS3AccessKeyID     = "AKIAEXAMPLE"
+	testS3SecretAccessKey = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+)
+
+// fakeS3Server / テスト用の MinIO 相当の S3 互換サーバ。署名バージョン4の署名を検証し、オブジェクトをメモリに保持する。
+type fakeS3Server struct {
+	*httptest.Server
+	t *testing.T
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	// requests / 受け取ったリクエストのヘッダ
+	requests []http.Header
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	t.Helper()
+	s := &fakeS3Server{t: t, objects: make(map[string][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeS3Server) config(secretAccessKey string) S3Config {
+	return S3Config{
+		Endpoint:        s.URL + "/",
+		Region:          testS3Region,
+		Bucket:          testS3Bucket,
+		AccessKeyID:     testS3AccessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+}
+
+func (s *fakeS3Server) lastRequest() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *fakeS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Header.Clone())
+	s.mu.Unlock()
+
+	if err := verifySignature(r); err != nil {
+		s.t.Logf("signature: %v", err)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		return
+	}
+	// 署名に使われるエスケープ済みのパスをキーとする
+	path := strings.SplitN(r.RequestURI, "?", 2)[0]
+	prefix := "/" + testS3Bucket + "/"
+	if !strings.HasPrefix(path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<Error><Code>NoSuchBucket</Code></Error>")
+		return
+	}
+	key := strings.TrimPrefix(path, prefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySignature / 受け取ったリクエストから署名バージョン4の署名を計算し直し、Authorization ヘッダと比べる
+func verifySignature(r *http.Request) error {
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return fmt.Errorf("x-amz-date %q: %w", amzDate, err)
+	}
+	if d := time.Since(signedAt); d < -time.Minute || d > time.Minute {
+		return fmt.Errorf("x-amz-date %q is not now", amzDate)
+	}
+	payload := r.Header.Get("X-Amz-Content-Sha256")
+	if payload != "UNSIGNED-PAYLOAD" {
+		return fmt.Errorf("x-amz-content-sha256 = %q", payload)
+	}
+
+	date := signedAt.Format("20060102")
+	scope := date + "/" + testS3Region + "/s3/aws4_request"
+	canonicalRequest := r.Method + "\n" +
+		strings.SplitN(r.RequestURI, "?", 2)[0] + "\n" +
+		r.URL.RawQuery + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:" + payload + "\n" +
+		"x-amz-date:" + amzDate + "\n" +
+		"\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		payload
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	mac := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	key := mac([]byte("AWS4"+testS3SecretAccessKey), date)
+	key = mac(key, testS3Region)
+	key = mac(key, "s3")
+	key = mac(key, "aws4_request")
+	want := fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		testS3AccessKeyID, scope, hex.EncodeToString(mac(key, stringToSign)),
+	)
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("Authorization = %q, want %q", got, want)
+	}
+	return nil
+}
+
+func TestS3BlobStoreRoundTrip(t *testing.T) {
+	server := newFakeS3Server(t)
+	store := NewS3BlobStore(server.config(testS3SecretAccessKey))
+	// 予約文字と日本語を含むキーもエスケープしたパスで署名する
+	key := "company/1/task/42/議事録 (1)+final.txt"
+	content := "四半期の売上報告書\r\n"
+
+	if aerr := store.Put(key, strings.NewReader(content), int64(len(content)), "text/plain; charset=utf-8"); aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	put := server.lastRequest()
+	if got := put.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := put.Get("Content-Length"); got != fmt.Sprint(len(content)) {
+		t.Errorf("Content-Length = %q, want %d", got, len(content))
+	}
+
+	body, aerr := store.Get(key)
+	if aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	got, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Errorf("body = %q, want %q", got, content)
+	}
+
+	if aerr := store.Delete(key); aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	if _, aerr := store.Get(key); aerr == nil || aerr.Code() != apperr.ErrorCodeNotFound {
+		t.Fatalf("Get after Delete = %v, want NotFound", aerr)
+	}
+	// 存在しないキーの削除は成功とする
+	if aerr := store.Delete(key); aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+}
+
+func TestS3BlobStoreSignatureMismatch(t *testing.T) {
+	server := newFakeS3Server(t)
+	store := NewS3BlobStore(server.config("wrong-secret"))
+
+	aerr := store.Put("a.txt", strings.NewReader("a"), 1, "text/plain")
+	if aerr == nil || aerr.Code() != apperr.ErrorCodeInternalServerError {
+		t.Fatalf("Put = %v, want InternalServerError", aerr)
+	}
+	if _, aerr := store.Get("a.txt"); aerr == nil || aerr.Code() != apperr.ErrorCodeInternalServerError {
+		t.Fatalf("Get = %v, want InternalServerError", aerr)
+	}
+}
+
+func TestS3BlobStoreRejectsEmptyKey(t *testing.T) {
+	server := newFakeS3Server(t)
+	store := NewS3BlobStore(server.config(testS3SecretAccessKey))
+
+	if aerr := store.Put("", strings.NewReader(""), 0, "text/plain"); aerr == nil {
+		t.Fatal("Put with an empty key succeeded")
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.requests) != 0 {
+		t.Fatalf("sent %d requests, want 0", len(server.requests))
+	}
+}
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LogSender / メールを送らずにログに出力する。SMTP サーバのない開発環境向け。
+type LogSender struct {
+	logger echo.Logger
+}
+
+func NewLogSender(logger echo.Logger) *LogSender {
+	return &LogSender{logger}
+}
+
+func (s *LogSender) Send(message *domain.MailMessage) apperr.AppErr {
+	s.logger.Infof("mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// SMTPConfig / SMTP サーバの接続情報。Username が空の場合は認証しない。
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPSender / SMTP サーバを介してメールを送る。サーバが対応している場合は STARTTLS を用いる。
+type SMTPSender struct {
+	config SMTPConfig
+}
+
+func NewSMTPSender(config SMTPConfig) *SMTPSender {
+	return &SMTPSender{config}
+}
+
+func (s *SMTPSender) Send(message *domain.MailMessage) apperr.AppErr {
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	if err := smtp.SendMail(addr, auth, s.config.From, []string{message.To}, buildMessage(s.config.From, message)); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+// buildMessage / UTF-8 のテキストのメールを組み立てる。件名は MIME エンコードし、本文は base64 とする。
+func buildMessage(from string, message *domain.MailMessage) []byte {
+	var b bytes.Buffer
+	header := func(key, value string) {
+		b.WriteString(key + ": " + value + "\r\n")
+	}
+	header("From", from)
+	header("To", message.To)
+	header("Subject", mime.BEncoding.Encode("UTF-8", message.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="UTF-8"`)
+	header("Content-Transfer-Encoding", "base64")
+	if message.UnsubscribeURL != "" {
+		header("List-Unsubscribe", "<"+message.UnsubscribeURL+">")
+		header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	b.WriteString("\r\n")
+
+	// 1行76文字で折り返す
+	encoded := base64.StdEncoding.EncodeToString([]byte(strings.ReplaceAll(message.Body, "\n", "\r\n")))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,291 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"todo_api/internal/usecase"
+)
+
+// fakeSMTPServer / テスト用のプロセス内の SMTP サーバ。受け取ったメッセージを記録する。
+type fakeSMTPServer struct {
+	listener net.Listener
+	// rejects / DATA を一時的な失敗として拒否する残りの回数
+	rejects int
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T, rejects int) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTPServer{listener: listener, rejects: rejects}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) config() SMTPConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "noreply@example.com"}
+}
+
+func (s *fakeSMTPServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"), command == "RSET", command == "NOOP":
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			s.mu.Lock()
+			if s.rejects > 0 {
+				s.rejects--
+				s.mu.Unlock()
+				reply("451 Try again later")
+				continue
+			}
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// parseMessage / 受け取ったメッセージを解析し、件名と base64 を復号した本文を返す
+func parseMessage(t *testing.T, raw string) (*netmail.Message, string, string) {
+	t.Helper()
+	msg, err := netmail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(encoded), "\r\n"), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("base64 line is longer than 76 characters: %d", len(line))
+		}
+	}
+	body, err := base64.StdEncoding.DecodeString(strings.NewReplacer("\r", "", "\n", "").Replace(string(encoded)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg, subject, string(body)
+}
+
+func newAssignedMessage(t *testing.T, language domain.Language, title string) *domain.MailMessage {
+	t.Helper()
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	notification := &domain.Notification{
+		UserID:   2,
+		Type:     domain.NotificationTypeAssigned,
+		TaskID:   42,
+		Actor:    &domain.User{ID: 1, Name: "Alice"},
+		CreateAt: now,
+	}
+	task := &domain.Task{ID: 42, Title: title}
+	unsubscribeURL := "https://todo.example.com/api/v1/notification/unsubscribe?token=abc"
+	subject, body, aerr := NewTemplateRenderer().RenderNotification(language, notification, task, unsubscribeURL)
+	if aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	return domain.NewMailMessage(2, "bob@example.com", subject, body, unsubscribeURL, now)
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	title := strings.Repeat("四半期の売上報告書を作成する", 4)
+	tests := []struct {
+		name     string
+		language domain.Language
+		subject  string
+		body     []string
+	}{
+		{
+			name:     "ja",
+			language: domain.LanguageJapanese,
+			subject:  "[TODO] Aliceさんがあなたを「" + title + "」の担当者に設定しました",
+			body:     []string{"タスクID: 42\r\n", "このメールの配信を停止するには次のURLを開いてください。\r\n"},
+		},
+		{
+			name:     "en",
+			language: domain.LanguageEnglish,
+			subject:  `[TODO] Alice assigned you to "` + title + `"`,
+			body:     []string{"Task ID: 42\r\n", "To stop receiving these emails, open the following URL.\r\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, 0)
+			message := newAssignedMessage(t, tt.language, title)
+			if aerr := NewSMTPSender(server.config()).Send(message); aerr != nil {
+				t.Fatal(aerr.Message())
+			}
+
+			received := server.received()
+			if len(received) != 1 {
+				t.Fatalf("received %d messages, want 1", len(received))
+			}
+			msg, subject, body := parseMessage(t, received[0])
+			if subject != tt.subject {
+				t.Errorf("Subject = %q, want %q", subject, tt.subject)
+			}
+			headers := map[string]string{
+				"From":                      "noreply@example.com",
+				"To":                        "bob@example.com",
+				"Content-Type":              `text/plain; charset="UTF-8"`,
+				"Content-Transfer-Encoding": "base64",
+				"List-Unsubscribe":          "<" + message.UnsubscribeURL + ">",
+				"List-Unsubscribe-Post":     "List-Unsubscribe=One-Click",
+			}
+			for key, want := range headers {
+				if got := msg.Header.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+			// 本文の改行は CRLF とする
+			for _, want := range append(tt.body, message.UnsubscribeURL+"\r\n") {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q:\n%s", want, body)
+				}
+			}
+			if strings.Contains(strings.ReplaceAll(body, "\r\n", ""), "\n") {
+				t.Errorf("body contains a bare LF:\n%q", body)
+			}
+		})
+	}
+}
+
+// memoryMailRepository / 送信待ちのメールをメモリに保持するアウトボックス
+type memoryMailRepository struct {
+	messages []*domain.MailMessage
+}
+
+func (r *memoryMailRepository) Create(messages ...*domain.MailMessage) apperr.AppErr {
+	for _, message := range messages {
+		message.ID = domain.MailMessageIdentifier(len(r.messages) + 1)
+		r.messages = append(r.messages, message)
+	}
+	return nil
+}
+
+func (r *memoryMailRepository) ListDue(now time.Time, limit int) ([]*domain.MailMessage, apperr.AppErr) {
+	var due []*domain.MailMessage
+	for _, message := range r.messages {
+		if message.Status == domain.MailStatusPending && !message.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryMailRepository) Update(message *domain.MailMessage) apperr.AppErr {
+	return nil
+}
+
+func TestDeliverPendingRetries(t *testing.T) {
+	server := newFakeSMTPServer(t, 1)
+	repository := &memoryMailRepository{}
+	message := newAssignedMessage(t, domain.LanguageEnglish, "Write report")
+	if aerr := repository.Create(message); aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	mailUsecase := usecase.NewMailUsecase(repository, NewSMTPSender(server.config()), NewTemplateRenderer(), nil, nil, nil, "", 8)
+
+	// 一時的な失敗は送信待ちのまま再送の時刻を先に延ばす
+	now := time.Now()
+	sent, aerr := mailUsecase.DeliverPending(now)
+	if aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	if sent != 0 || message.Status != domain.MailStatusPending || message.Attempts != 1 || message.LastError == nil {
+		t.Fatalf("after failure: sent=%d status=%s attempts=%d", sent, message.Status, message.Attempts)
+	}
+	if !message.NextAttemptAt.After(now) {
+		t.Fatalf("NextAttemptAt = %v, want after %v", message.NextAttemptAt, now)
+	}
+	if sent, _ := mailUsecase.DeliverPending(now); sent != 0 {
+		t.Fatalf("retried before NextAttemptAt")
+	}
+
+	sent, aerr = mailUsecase.DeliverPending(message.NextAttemptAt)
+	if aerr != nil {
+		t.Fatal(aerr.Message())
+	}
+	if sent != 1 || message.Status != domain.MailStatusSent || message.Attempts != 2 || message.LastError != nil {
+		t.Fatalf("after retry: sent=%d status=%s attempts=%d", sent, message.Status, message.Attempts)
+	}
+	if received := server.received(); len(received) != 1 {
+		t.Fatalf("received %d messages, want 1", len(received))
+	}
+}
+
+func TestBuildMessageWrapsBase64(t *testing.T) {
+	message := &domain.MailMessage{To: "bob@example.com", Subject: "s", Body: strings.Repeat("0123456789", 50)}
+	_, _, body := parseMessage(t, string(buildMessage("noreply@example.com", message)))
+	if body != message.Body {
+		t.Errorf("body = %q, want %q", body, message.Body)
+	}
+}
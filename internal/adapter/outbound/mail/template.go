@@ -0,0 +1,97 @@
+package mail
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+	"text/template"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// TemplateRenderer / 言語ごとのテンプレートからメールを生成する
+type TemplateRenderer struct {
+	templates map[domain.Language]*template.Template
+}
+
+func NewTemplateRenderer() *TemplateRenderer {
+	return &TemplateRenderer{
+		templates: map[domain.Language]*template.Template{
+			domain.LanguageJapanese: template.Must(template.ParseFS(templateFS, "templates/ja.tmpl")),
+			domain.LanguageEnglish:  template.Must(template.ParseFS(templateFS, "templates/en.tmpl")),
+		},
+	}
+}
+
+// notificationData / テンプレートに渡す一件の通知
+type notificationData struct {
+	Type           string
+	ActorName      string
+	TaskID         uint64
+	TaskTitle      string
+	Status         string
+	LimitDate      string
+	Overdue        bool
+	UnsubscribeURL string
+}
+
+type digestData struct {
+	Items          []*notificationData
+	UnsubscribeURL string
+}
+
+func (r *TemplateRenderer) RenderNotification(language domain.Language, notification *domain.Notification, task *domain.Task, unsubscribeURL string) (string, string, apperr.AppErr) {
+	data := newNotificationData(notification, task)
+	data.UnsubscribeURL = unsubscribeURL
+	return r.render(language, "notification", data)
+}
+
+func (r *TemplateRenderer) RenderDigest(language domain.Language, notifications []*domain.Notification, tasks map[domain.TaskIdentifier]*domain.Task, unsubscribeURL string) (string, string, apperr.AppErr) {
+	data := &digestData{UnsubscribeURL: unsubscribeURL}
+	for _, notification := range notifications {
+		data.Items = append(data.Items, newNotificationData(notification, tasks[notification.TaskID]))
+	}
+	return r.render(language, "digest", data)
+}
+
+func (r *TemplateRenderer) render(language domain.Language, name string, data any) (string, string, apperr.AppErr) {
+	t, ok := r.templates[language]
+	if !ok {
+		t = r.templates[domain.LanguageJapanese]
+	}
+	var subject, body strings.Builder
+	if err := t.ExecuteTemplate(&subject, name+"_subject", data); err != nil {
+		return "", "", apperr.NewInternalServerError().Wrap(err)
+	}
+	if err := t.ExecuteTemplate(&body, name+"_body", data); err != nil {
+		return "", "", apperr.NewInternalServerError().Wrap(err)
+	}
+	return subject.String(), body.String(), nil
+}
+
+// newNotificationData / タスクが削除されて取得できない場合はタスクIDのみを表示する
+func newNotificationData(notification *domain.Notification, task *domain.Task) *notificationData {
+	data := &notificationData{
+		Type:   notification.Type.String(),
+		TaskID: uint64(notification.TaskID),
+	}
+	if notification.Actor != nil {
+		data.ActorName = notification.Actor.Name
+	}
+	if task != nil {
+		data.TaskTitle = task.Title
+	} else {
+		data.TaskTitle = "#" + strconv.FormatUint(data.TaskID, 10)
+	}
+	if notification.Status != nil {
+		data.Status = *notification.Status
+	}
+	if notification.LimitDate != nil {
+		data.LimitDate = notification.LimitDate.Format("2006-01-02 15:04")
+		data.Overdue = !notification.LimitDate.After(notification.CreateAt)
+	}
+	return data
+}
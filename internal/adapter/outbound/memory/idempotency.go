@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"sync"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// sweepInterval / 期限切れの記録をまとめて削除する間隔
+const sweepInterval = time.Minute
+
+type idempotencyKey struct {
+	userID domain.UserIdentifier
+	key    string
+}
+
+// IdempotencyRepository / プロセス内に冪等キーの記録を保持する。複数のサーバで共有はされない。
+type IdempotencyRepository struct {
+	mu        sync.Mutex
+	records   map[idempotencyKey]domain.IdempotencyRecord
+	lastSweep time.Time
+}
+
+func NewIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{
+		records: map[idempotencyKey]domain.IdempotencyRecord{},
+	}
+}
+
+func (r *IdempotencyRepository) Get(userID domain.UserIdentifier, key string) (*domain.IdempotencyRecord, apperr.AppErr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[idempotencyKey{userID, key}]
+	if !ok || record.IsExpired(time.Now()) {
+		return nil, apperr.NewNotFoundError()
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Reserve(record *domain.IdempotencyRecord) (bool, apperr.AppErr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.sweep(now)
+	k := idempotencyKey{record.UserID, record.Key}
+	if existing, ok := r.records[k]; ok && !existing.IsExpired(now) {
+		return false, nil
+	}
+	r.records[k] = *record
+	return true, nil
+}
+
+func (r *IdempotencyRepository) Complete(record *domain.IdempotencyRecord) apperr.AppErr {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := idempotencyKey{record.UserID, record.Key}
+	if _, ok := r.records[k]; !ok {
+		return apperr.NewNotFoundError()
+	}
+	r.records[k] = *record
+	return nil
+}
+
+// sweep / 期限切れの記録を削除する。呼び出し側でロックを取得しておくこと。
+func (r *IdempotencyRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < sweepInterval {
+		return
+	}
+	for k, record := range r.records {
+		if record.IsExpired(now) {
+			delete(r.records, k)
+		}
+	}
+	r.lastSweep = now
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"sync"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// taskStreamBufferSize / 購読者ごとに受け取りを待てる変更の件数。超えた購読者は打ち切る。
+const taskStreamBufferSize = 64
+
+// TaskStreamBroker / プロセス内でタスクの変更を購読者に届ける。複数のサーバで共有はされず、再起動すると保持している変更は失われる。
+type TaskStreamBroker struct {
+	mu sync.Mutex
+	// size / 保持する変更の件数
+	size        int
+	events      []*domain.TaskStreamEvent
+	lastID      domain.TaskStreamEventIdentifier
+	subscribers map[domain.CompanyIdentifier]map[chan *domain.TaskStreamEvent]struct{}
+}
+
+func NewTaskStreamBroker(size int) *TaskStreamBroker {
+	return &TaskStreamBroker{
+		size:        size,
+		subscribers: map[domain.CompanyIdentifier]map[chan *domain.TaskStreamEvent]struct{}{},
+	}
+}
+
+func (b *TaskStreamBroker) Publish(event *domain.TaskStreamEvent) apperr.AppErr {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	b.events = append(b.events, event)
+	if len(b.events) > b.size {
+		b.events = append(b.events[:0], b.events[len(b.events)-b.size:]...)
+	}
+	for ch := range b.subscribers[event.CompanyID] {
+		select {
+		case ch <- event:
+		default:
+			// 受け取りが追いつかない購読者は打ち切り、再接続時の再送に任せる
+			b.unsubscribe(event.CompanyID, ch)
+		}
+	}
+	return nil
+}
+
+func (b *TaskStreamBroker) Subscribe(companyID domain.CompanyIdentifier) (<-chan *domain.TaskStreamEvent, func(), apperr.AppErr) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *domain.TaskStreamEvent, taskStreamBufferSize)
+	if b.subscribers[companyID] == nil {
+		b.subscribers[companyID] = map[chan *domain.TaskStreamEvent]struct{}{}
+	}
+	b.subscribers[companyID][ch] = struct{}{}
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(companyID, ch)
+	}
+	return ch, cancel, nil
+}
+
+func (b *TaskStreamBroker) ListAfter(companyID domain.CompanyIdentifier, afterID domain.TaskStreamEventIdentifier) ([]*domain.TaskStreamEvent, bool, apperr.AppErr) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// 再起動などで採番し直した後の番号より大きい場合も、間の変更が分からない
+	if afterID > b.lastID {
+		return nil, false, nil
+	}
+	if afterID < b.lastID && afterID+1 < b.events[0].ID {
+		return nil, false, nil
+	}
+	var events []*domain.TaskStreamEvent
+	for _, event := range b.events {
+		if event.ID > afterID && event.CompanyID == companyID {
+			events = append(events, event)
+		}
+	}
+	return events, true, nil
+}
+
+// unsubscribe / 購読をやめてチャネルを閉じる。呼び出し側でロックを取得しておくこと。
+func (b *TaskStreamBroker) unsubscribe(companyID domain.CompanyIdentifier, ch chan *domain.TaskStreamEvent) {
+	if _, ok := b.subscribers[companyID][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[companyID], ch)
+	if len(b.subscribers[companyID]) == 0 {
+		delete(b.subscribers, companyID)
+	}
+	close(ch)
+}
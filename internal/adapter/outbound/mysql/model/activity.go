@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type Activity struct {
+	ID           uint64
+	CompanyID    uint64
+	ActivityType string
+	TaskID       *uint64
+	UserID       *uint64
+	// DomainEventID / 元となったドメインイベント。以前に記録した出来事では NULL。
+	DomainEventID *uint64
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+	ActorID  uint64
+	Actor    User `gorm:"foreignKey:ActorID"`
+}
+
+func (m *Activity) TableName() string {
+	return "activity"
+}
+
+func UnmarshalActivity(d *domain.Activity) *Activity {
+	if d == nil {
+		return nil
+	}
+	m := &Activity{
+		ID:           uint64(d.ID),
+		CompanyID:    uint64(d.CompanyID),
+		ActivityType: d.Type.String(),
+		TaskID:       (*uint64)(d.TaskID),
+		UserID:       (*uint64)(d.UserID),
+		CreateAt:     d.CreateAt,
+		ActorID:      uint64(d.Actor.ID),
+	}
+	if d.DomainEventID != 0 {
+		domainEventID := uint64(d.DomainEventID)
+		m.DomainEventID = &domainEventID
+	}
+	return m
+}
+
+func MarshalActivity(m *Activity) (*domain.Activity, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	activityType, err := marshalActivityType(m.ActivityType)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := MarshalUser(&m.Actor)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.Activity{
+		ID:        domain.ActivityIdentifier(m.ID),
+		CompanyID: domain.CompanyIdentifier(m.CompanyID),
+		Type:      *activityType,
+		TaskID:    (*domain.TaskIdentifier)(m.TaskID),
+		UserID:    (*domain.UserIdentifier)(m.UserID),
+		CreateAt:  m.CreateAt,
+		Actor:     *actor,
+	}
+	if m.DomainEventID != nil {
+		d.DomainEventID = domain.DomainEventIdentifier(*m.DomainEventID)
+	}
+	return d, nil
+}
+
+func marshalActivityType(s string) (*domain.ActivityType, apperr.AppErr) {
+	var activityType domain.ActivityType
+	switch s {
+	case "TASK_CREATED":
+		activityType = domain.ActivityTypeTaskCreated
+	case "TASK_ASSIGNED":
+		activityType = domain.ActivityTypeTaskAssigned
+	case "TASK_COMPLETED":
+		activityType = domain.ActivityTypeTaskCompleted
+	case "TASK_COMMENTED":
+		activityType = domain.ActivityTypeTaskCommented
+	case "USER_CREATED":
+		activityType = domain.ActivityTypeUserCreated
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &activityType, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type Attachment struct {
+	ID          uint64
+	TaskID      uint64
+	CompanyID   uint64
+	FileName    string
+	ContentType string
+	FileSize    int64
+	StorageKey  string
+
+	CreateAt  time.Time `gorm:"autoCreateTime"`
+	CreatorID uint64
+	Creator   User `gorm:"foreignKey:CreatorID"`
+}
+
+func (m *Attachment) TableName() string {
+	return "attachment"
+}
+
+func UnmarshalAttachment(d *domain.Attachment) *Attachment {
+	if d == nil {
+		return nil
+	}
+	return &Attachment{
+		ID:          uint64(d.ID),
+		TaskID:      uint64(d.TaskID),
+		CompanyID:   uint64(d.CompanyID),
+		FileName:    d.FileName,
+		ContentType: d.ContentType,
+		FileSize:    d.Size,
+		StorageKey:  d.StorageKey,
+		CreateAt:    d.CreateAt,
+		CreatorID:   uint64(d.Creator.ID),
+	}
+}
+
+func MarshalAttachment(m *Attachment) (*domain.Attachment, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	creator, err := MarshalUser(&m.Creator)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Attachment{
+		ID:          domain.AttachmentIdentifier(m.ID),
+		TaskID:      domain.TaskIdentifier(m.TaskID),
+		CompanyID:   domain.CompanyIdentifier(m.CompanyID),
+		FileName:    m.FileName,
+		ContentType: m.ContentType,
+		Size:        m.FileSize,
+		StorageKey:  m.StorageKey,
+		CreateAt:    m.CreateAt,
+		Creator:     *creator,
+	}, nil
+}
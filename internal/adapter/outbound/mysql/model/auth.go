@@ -13,6 +13,7 @@ type Auth struct {
 	UserType  string
 	CompanyID uint64
 	Company   Company
+	Version   uint64
 }
 
 func (m *Auth) TableName() string {
@@ -24,12 +25,14 @@ func UnmarshalAuth(d *domain.Auth) *Auth {
 		return nil
 	}
 	return &Auth{
-		ID:       uint64(d.ID),
-		Name:     d.Name,
-		Hash:     d.Hash,
-		Role:     d.Role.String(),
-		UserType: d.UserType.String(),
-		Company:  *UnmarshalCompany(&d.Company),
+		ID:        uint64(d.ID),
+		Name:      d.Name,
+		Hash:      d.Hash,
+		Role:      d.Role.String(),
+		UserType:  d.UserType.String(),
+		CompanyID: uint64(d.Company.ID),
+		Company:   *UnmarshalCompany(&d.Company),
+		Version:   uint64(d.Version),
 	}
 }
 
@@ -53,5 +56,6 @@ func MarshalAuth(m *Auth) (*domain.Auth, apperr.AppErr) {
 		Role:     *role,
 		UserType: *userType,
 		Company:  *MarshalCompany(&m.Company),
+		Version:  domain.Version(m.Version),
 	}, nil
 }
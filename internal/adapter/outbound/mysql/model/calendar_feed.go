@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type CalendarFeed struct {
+	UserID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Token    string
+	CreateAt time.Time
+}
+
+func (m *CalendarFeed) TableName() string {
+	return "calendar_feed"
+}
+
+func UnmarshalCalendarFeed(d *domain.CalendarFeed) *CalendarFeed {
+	if d == nil {
+		return nil
+	}
+	return &CalendarFeed{
+		UserID:   uint64(d.UserID),
+		Token:    d.Token,
+		CreateAt: d.CreateAt,
+	}
+}
+
+func MarshalCalendarFeed(m *CalendarFeed) *domain.CalendarFeed {
+	if m == nil {
+		return nil
+	}
+	return &domain.CalendarFeed{
+		UserID:   domain.UserIdentifier(m.UserID),
+		Token:    m.Token,
+		CreateAt: m.CreateAt,
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+import domain "todo_api/internal/domain/model"
+
+type TaskChecklistItem struct {
+	ID        uint64
+	TaskID    uint64
+	Text      string `gorm:"column:item_text"`
+	Done      bool   `gorm:"column:is_done"`
+	SortOrder int
+}
+
+func (m *TaskChecklistItem) TableName() string {
+	return "task_checklist_item"
+}
+
+// UnmarshalTaskChecklist / タスクのチェックリストの行を生成する
+func UnmarshalTaskChecklist(d *domain.Task) []*TaskChecklistItem {
+	if d == nil {
+		return nil
+	}
+	var rows []*TaskChecklistItem
+	for _, item := range d.Checklist {
+		rows = append(rows, &TaskChecklistItem{
+			TaskID:    uint64(d.ID),
+			Text:      item.Text,
+			Done:      item.Done,
+			SortOrder: item.SortOrder,
+		})
+	}
+	return rows
+}
+
+func MarshalChecklistItem(m *TaskChecklistItem) *domain.ChecklistItem {
+	if m == nil {
+		return nil
+	}
+	return &domain.ChecklistItem{
+		Text:      m.Text,
+		Done:      m.Done,
+		SortOrder: m.SortOrder,
+	}
+}
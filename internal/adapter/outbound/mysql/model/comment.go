@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type Comment struct {
+	ID       uint64
+	TaskID   uint64
+	AuthorID uint64
+	Author   User    `gorm:"foreignKey:AuthorID"`
+	Body     string  `gorm:"column:comment_body"`
+	Mentions []*User `gorm:"many2many:comment_mention;joinForeignKey:CommentID;joinReferences:UserID"`
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+	EditAt   *time.Time
+	DeleteAt *time.Time
+}
+
+func (m *Comment) TableName() string {
+	return "comment"
+}
+
+type CommentMention struct {
+	CommentID uint64 `gorm:"primaryKey"`
+	UserID    uint64 `gorm:"primaryKey"`
+}
+
+func (m *CommentMention) TableName() string {
+	return "comment_mention"
+}
+
+func UnmarshalComment(d *domain.Comment) *Comment {
+	if d == nil {
+		return nil
+	}
+	return &Comment{
+		ID:       uint64(d.ID),
+		TaskID:   uint64(d.TaskID),
+		AuthorID: uint64(d.Author.ID),
+		Body:     d.Body,
+		CreateAt: d.CreateAt,
+		EditAt:   d.EditAt,
+		DeleteAt: d.DeleteAt,
+	}
+}
+
+// UnmarshalCommentMentions / コメントのメンションの中間テーブルの行を生成する
+func UnmarshalCommentMentions(d *domain.Comment) []*CommentMention {
+	if d == nil {
+		return nil
+	}
+	var rows []*CommentMention
+	for _, user := range d.Mentions {
+		rows = append(rows, &CommentMention{
+			CommentID: uint64(d.ID),
+			UserID:    uint64(user.ID),
+		})
+	}
+	return rows
+}
+
+func MarshalComment(m *Comment) (*domain.Comment, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	author, err := MarshalUser(&m.Author)
+	if err != nil {
+		return nil, err
+	}
+	var mentions []*domain.User
+	for _, row := range m.Mentions {
+		user, err := MarshalUser(row)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, user)
+	}
+	return &domain.Comment{
+		ID:       domain.CommentIdentifier(m.ID),
+		TaskID:   domain.TaskIdentifier(m.TaskID),
+		Author:   *author,
+		Body:     m.Body,
+		Mentions: mentions,
+		CreateAt: m.CreateAt,
+		EditAt:   m.EditAt,
+		DeleteAt: m.DeleteAt,
+	}, nil
+}
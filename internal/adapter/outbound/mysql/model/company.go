@@ -3,8 +3,9 @@ package model
 import domain "todo_api/internal/domain/model"
 
 type Company struct {
-	ID   uint64
-	Name string `gorm:"column:company_name"`
+	ID      uint64
+	Name    string `gorm:"column:company_name"`
+	Version uint64
 }
 
 func (m *Company) TableName() string {
@@ -16,8 +17,9 @@ func UnmarshalCompany(d *domain.Company) *Company {
 		return nil
 	}
 	return &Company{
-		ID:   uint64(d.ID),
-		Name: d.Name,
+		ID:      uint64(d.ID),
+		Name:    d.Name,
+		Version: uint64(d.Version),
 	}
 }
 
@@ -26,7 +28,8 @@ func MarshalCompany(m *Company) *domain.Company {
 		return nil
 	}
 	return &domain.Company{
-		ID:   domain.CompanyIdentifier(m.ID),
-		Name: m.Name,
+		ID:      domain.CompanyIdentifier(m.ID),
+		Name:    m.Name,
+		Version: domain.Version(m.Version),
 	}
 }
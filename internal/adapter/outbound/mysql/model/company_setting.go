@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+	domain "todo_api/internal/domain/model"
+)
+
+type CompanySetting struct {
+	CompanyID             uint64 `gorm:"primaryKey"`
+	MaxTaskDepth          int
+	RequireClosedSubtasks bool
+	MaxAttachmentSize     int64
+	// AllowedMimeTypes / カンマ区切りで保存する
+	AllowedMimeTypes string
+	StorageQuota     int64
+}
+
+func (m *CompanySetting) TableName() string {
+	return "company_setting"
+}
+
+func UnmarshalCompanySetting(d *domain.CompanySetting) *CompanySetting {
+	if d == nil {
+		return nil
+	}
+	return &CompanySetting{
+		CompanyID:             uint64(d.CompanyID),
+		MaxTaskDepth:          d.MaxTaskDepth,
+		RequireClosedSubtasks: d.RequireClosedSubtasks,
+		MaxAttachmentSize:     d.MaxAttachmentSize,
+		AllowedMimeTypes:      strings.Join(d.AllowedMimeTypes, ","),
+		StorageQuota:          d.StorageQuota,
+	}
+}
+
+func MarshalCompanySetting(m *CompanySetting) *domain.CompanySetting {
+	if m == nil {
+		return nil
+	}
+	var allowedMimeTypes []string
+	if m.AllowedMimeTypes != "" {
+		allowedMimeTypes = strings.Split(m.AllowedMimeTypes, ",")
+	}
+	return &domain.CompanySetting{
+		CompanyID:             domain.CompanyIdentifier(m.CompanyID),
+		MaxTaskDepth:          m.MaxTaskDepth,
+		RequireClosedSubtasks: m.RequireClosedSubtasks,
+		MaxAttachmentSize:     m.MaxAttachmentSize,
+		AllowedMimeTypes:      allowedMimeTypes,
+		StorageQuota:          m.StorageQuota,
+	}
+}
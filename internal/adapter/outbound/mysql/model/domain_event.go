@@ -0,0 +1,183 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type DomainEvent struct {
+	ID            uint64
+	EventType     string
+	AggregateType string
+	AggregateID   uint64
+	CompanyID     uint64
+	ActorID       *uint64
+	Changes       string
+	OccurredAt    time.Time
+	Imported      bool
+	EventStatus   string
+	Attempts      int
+	NextAttemptAt time.Time
+	DeliveredTo   string
+	LastError     *string
+	DispatchedAt  *time.Time
+}
+
+func (m *DomainEvent) TableName() string {
+	return "domain_event"
+}
+
+type domainEventChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+func UnmarshalDomainEvent(d *domain.DomainEvent) (*DomainEvent, apperr.AppErr) {
+	if d == nil {
+		return nil, nil
+	}
+	changes := []*domainEventChange{}
+	for _, change := range d.Changes {
+		changes = append(changes, &domainEventChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	b, err := json.Marshal(changes)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return &DomainEvent{
+		ID:            uint64(d.ID),
+		EventType:     d.Type.String(),
+		AggregateType: d.AggregateType.String(),
+		AggregateID:   d.AggregateID,
+		CompanyID:     uint64(d.CompanyID),
+		ActorID:       (*uint64)(d.ActorID),
+		Changes:       string(b),
+		OccurredAt:    d.OccurredAt,
+		Imported:      d.Imported,
+		EventStatus:   d.Status.String(),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		DeliveredTo:   strings.Join(d.DeliveredTo, ","),
+		LastError:     d.LastError,
+		DispatchedAt:  d.DispatchedAt,
+	}, nil
+}
+
+func MarshalDomainEvent(m *DomainEvent) (*domain.DomainEvent, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	eventType, err := marshalDomainEventType(m.EventType)
+	if err != nil {
+		return nil, err
+	}
+	aggregateType, err := marshalAggregateType(m.AggregateType)
+	if err != nil {
+		return nil, err
+	}
+	status, err := marshalDomainEventStatus(m.EventStatus)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*domainEventChange
+	if err := json.Unmarshal([]byte(m.Changes), &changes); err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	d := &domain.DomainEvent{
+		ID:            domain.DomainEventIdentifier(m.ID),
+		Type:          *eventType,
+		AggregateType: *aggregateType,
+		AggregateID:   m.AggregateID,
+		CompanyID:     domain.CompanyIdentifier(m.CompanyID),
+		ActorID:       (*domain.UserIdentifier)(m.ActorID),
+		OccurredAt:    m.OccurredAt,
+		Imported:      m.Imported,
+		Status:        *status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		DispatchedAt:  m.DispatchedAt,
+	}
+	for _, change := range changes {
+		d.Changes = append(d.Changes, &domain.DomainEventChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	if m.DeliveredTo != "" {
+		d.DeliveredTo = strings.Split(m.DeliveredTo, ",")
+	}
+	return d, nil
+}
+
+func marshalDomainEventType(s string) (*domain.DomainEventType, apperr.AppErr) {
+	var eventType domain.DomainEventType
+	switch s {
+	case "task.created":
+		eventType = domain.DomainEventTypeTaskCreated
+	case "task.updated":
+		eventType = domain.DomainEventTypeTaskUpdated
+	case "task.status_changed":
+		eventType = domain.DomainEventTypeTaskStatusChanged
+	case "task.deleted":
+		eventType = domain.DomainEventTypeTaskDeleted
+	case "user.created":
+		eventType = domain.DomainEventTypeUserCreated
+	case "user.updated":
+		eventType = domain.DomainEventTypeUserUpdated
+	case "company.created":
+		eventType = domain.DomainEventTypeCompanyCreated
+	case "company.updated":
+		eventType = domain.DomainEventTypeCompanyUpdated
+	case "comment.created":
+		eventType = domain.DomainEventTypeCommentCreated
+	case "comment.updated":
+		eventType = domain.DomainEventTypeCommentUpdated
+	case "comment.deleted":
+		eventType = domain.DomainEventTypeCommentDeleted
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &eventType, nil
+}
+
+func marshalAggregateType(s string) (*domain.AggregateType, apperr.AppErr) {
+	var aggregateType domain.AggregateType
+	switch s {
+	case "TASK":
+		aggregateType = domain.AggregateTypeTask
+	case "USER":
+		aggregateType = domain.AggregateTypeUser
+	case "COMPANY":
+		aggregateType = domain.AggregateTypeCompany
+	case "COMMENT":
+		aggregateType = domain.AggregateTypeComment
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &aggregateType, nil
+}
+
+func marshalDomainEventStatus(s string) (*domain.DomainEventStatus, apperr.AppErr) {
+	var status domain.DomainEventStatus
+	switch s {
+	case "PENDING":
+		status = domain.DomainEventStatusPending
+	case "DISPATCHED":
+		status = domain.DomainEventStatusDispatched
+	case "FAILED":
+		status = domain.DomainEventStatusFailed
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &status, nil
+}
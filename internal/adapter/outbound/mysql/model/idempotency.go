@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type IdempotencyRecord struct {
+	UserID       uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Key          string `gorm:"column:idempotency_key;primaryKey"`
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreateAt     time.Time
+	ExpireAt     time.Time
+}
+
+func (m *IdempotencyRecord) TableName() string {
+	return "idempotency_record"
+}
+
+func UnmarshalIdempotencyRecord(d *domain.IdempotencyRecord) *IdempotencyRecord {
+	if d == nil {
+		return nil
+	}
+	return &IdempotencyRecord{
+		UserID:       uint64(d.UserID),
+		Key:          d.Key,
+		RequestHash:  d.RequestHash,
+		StatusCode:   d.StatusCode,
+		ContentType:  d.ContentType,
+		ResponseBody: d.Body,
+		CreateAt:     d.CreateAt,
+		ExpireAt:     d.ExpireAt,
+	}
+}
+
+func MarshalIdempotencyRecord(m *IdempotencyRecord) *domain.IdempotencyRecord {
+	if m == nil {
+		return nil
+	}
+	return &domain.IdempotencyRecord{
+		UserID:      domain.UserIdentifier(m.UserID),
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		StatusCode:  m.StatusCode,
+		ContentType: m.ContentType,
+		Body:        m.ResponseBody,
+		CreateAt:    m.CreateAt,
+		ExpireAt:    m.ExpireAt,
+	}
+}
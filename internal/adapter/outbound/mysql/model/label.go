@@ -0,0 +1,47 @@
+package model
+
+import domain "todo_api/internal/domain/model"
+
+type Label struct {
+	ID        uint64
+	CompanyID uint64
+	Name      string `gorm:"column:label_name"`
+	Color     string `gorm:"column:label_color"`
+}
+
+func (m *Label) TableName() string {
+	return "label"
+}
+
+type TaskLabel struct {
+	TaskID  uint64 `gorm:"primaryKey"`
+	LabelID uint64 `gorm:"primaryKey"`
+}
+
+func (m *TaskLabel) TableName() string {
+	return "task_label"
+}
+
+func UnmarshalLabel(d *domain.Label) *Label {
+	if d == nil {
+		return nil
+	}
+	return &Label{
+		ID:        uint64(d.ID),
+		CompanyID: uint64(d.CompanyID),
+		Name:      d.Name,
+		Color:     d.Color,
+	}
+}
+
+func MarshalLabel(m *Label) *domain.Label {
+	if m == nil {
+		return nil
+	}
+	return &domain.Label{
+		ID:        domain.LabelIdentifier(m.ID),
+		CompanyID: domain.CompanyIdentifier(m.CompanyID),
+		Name:      m.Name,
+		Color:     m.Color,
+	}
+}
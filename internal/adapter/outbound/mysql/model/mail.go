@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type MailMessage struct {
+	ID             uint64
+	UserID         *uint64
+	ToAddress      string
+	Subject        string
+	Body           string
+	UnsubscribeURL string `gorm:"column:unsubscribe_url"`
+	// DomainEventID, NotificationType / 出来事による通知のメールの場合の元の通知
+	DomainEventID    *uint64
+	NotificationType *string
+	MailStatus       string
+	Attempts         int
+	NextAttemptAt    time.Time
+	LastError        *string
+	CreateAt         time.Time
+	SentAt           *time.Time
+}
+
+func (m *MailMessage) TableName() string {
+	return "mail_outbox"
+}
+
+func UnmarshalMailMessage(d *domain.MailMessage) *MailMessage {
+	if d == nil {
+		return nil
+	}
+	m := &MailMessage{
+		ID:             uint64(d.ID),
+		ToAddress:      d.To,
+		Subject:        d.Subject,
+		Body:           d.Body,
+		UnsubscribeURL: d.UnsubscribeURL,
+		DomainEventID:  (*uint64)(d.DomainEventID),
+		MailStatus:     d.Status.String(),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastError:      d.LastError,
+		CreateAt:       d.CreateAt,
+		SentAt:         d.SentAt,
+	}
+	if d.UserID != 0 {
+		userID := uint64(d.UserID)
+		m.UserID = &userID
+	}
+	if d.NotificationType != nil {
+		notificationType := d.NotificationType.String()
+		m.NotificationType = &notificationType
+	}
+	return m
+}
+
+func MarshalMailMessage(m *MailMessage) (*domain.MailMessage, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	status, err := marshalMailStatus(m.MailStatus)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.MailMessage{
+		ID:             domain.MailMessageIdentifier(m.ID),
+		To:             m.ToAddress,
+		Subject:        m.Subject,
+		Body:           m.Body,
+		UnsubscribeURL: m.UnsubscribeURL,
+		DomainEventID:  (*domain.DomainEventIdentifier)(m.DomainEventID),
+		Status:         *status,
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastError:      m.LastError,
+		CreateAt:       m.CreateAt,
+		SentAt:         m.SentAt,
+	}
+	if m.UserID != nil {
+		d.UserID = domain.UserIdentifier(*m.UserID)
+	}
+	if m.NotificationType != nil {
+		if d.NotificationType, err = marshalNotificationType(*m.NotificationType); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func marshalMailStatus(s string) (*domain.MailStatus, apperr.AppErr) {
+	var status domain.MailStatus
+	switch s {
+	case "PENDING":
+		status = domain.MailStatusPending
+	case "SENT":
+		status = domain.MailStatusSent
+	case "FAILED":
+		status = domain.MailStatusFailed
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &status, nil
+}
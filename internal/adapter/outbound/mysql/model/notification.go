@@ -0,0 +1,95 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type Notification struct {
+	ID               uint64
+	UserID           uint64
+	NotificationType string
+	TaskID           uint64
+	ActorID          *uint64
+	Actor            *User `gorm:"foreignKey:ActorID"`
+	CommentID        *uint64
+	StatusName       *string
+	LimitDate        *time.Time
+	DomainEventID    *uint64
+	ReadAt           *time.Time
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (m *Notification) TableName() string {
+	return "notification"
+}
+
+func UnmarshalNotification(d *domain.Notification) *Notification {
+	if d == nil {
+		return nil
+	}
+	m := &Notification{
+		ID:               uint64(d.ID),
+		UserID:           uint64(d.UserID),
+		NotificationType: d.Type.String(),
+		TaskID:           uint64(d.TaskID),
+		CommentID:        (*uint64)(d.CommentID),
+		StatusName:       d.Status,
+		LimitDate:        d.LimitDate,
+		DomainEventID:    (*uint64)(d.DomainEventID),
+		ReadAt:           d.ReadAt,
+		CreateAt:         d.CreateAt,
+	}
+	if d.Actor != nil {
+		actorID := uint64(d.Actor.ID)
+		m.ActorID = &actorID
+	}
+	return m
+}
+
+func MarshalNotification(m *Notification) (*domain.Notification, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	notificationType, err := marshalNotificationType(m.NotificationType)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.Notification{
+		ID:            domain.NotificationIdentifier(m.ID),
+		UserID:        domain.UserIdentifier(m.UserID),
+		Type:          *notificationType,
+		TaskID:        domain.TaskIdentifier(m.TaskID),
+		CommentID:     (*domain.CommentIdentifier)(m.CommentID),
+		Status:        m.StatusName,
+		LimitDate:     m.LimitDate,
+		DomainEventID: (*domain.DomainEventIdentifier)(m.DomainEventID),
+		ReadAt:        m.ReadAt,
+		CreateAt:      m.CreateAt,
+	}
+	if m.Actor != nil {
+		if d.Actor, err = MarshalUser(m.Actor); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func marshalNotificationType(s string) (*domain.NotificationType, apperr.AppErr) {
+	var notificationType domain.NotificationType
+	switch s {
+	case "ASSIGNED":
+		notificationType = domain.NotificationTypeAssigned
+	case "MENTIONED":
+		notificationType = domain.NotificationTypeMentioned
+	case "STATUS_CHANGED":
+		notificationType = domain.NotificationTypeStatusChanged
+	case "DUE_SOON":
+		notificationType = domain.NotificationTypeDueSoon
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &notificationType, nil
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type NotificationPreference struct {
+	UserID           uint64 `gorm:"primaryKey;autoIncrement:false"`
+	NotificationType string `gorm:"primaryKey"`
+	InApp            bool
+	Email            bool
+}
+
+func (m *NotificationPreference) TableName() string {
+	return "notification_preference"
+}
+
+type NotificationEmail struct {
+	UserID           uint64 `gorm:"primaryKey;autoIncrement:false"`
+	EmailAddress     *string
+	EmailLanguage    string
+	EmailMode        string
+	UnsubscribeToken *string
+	LastDigestAt     *time.Time
+}
+
+func (m *NotificationEmail) TableName() string {
+	return "notification_email"
+}
+
+func UnmarshalNotificationPreference(d *domain.NotificationPreference) ([]*NotificationPreference, *NotificationEmail) {
+	if d == nil {
+		return nil, nil
+	}
+	var rows []*NotificationPreference
+	for _, notificationType := range domain.NotificationTypes {
+		_, hasInApp := d.InApp[notificationType]
+		_, hasEmail := d.Email[notificationType]
+		if !hasInApp && !hasEmail {
+			continue
+		}
+		rows = append(rows, &NotificationPreference{
+			UserID:           uint64(d.UserID),
+			NotificationType: notificationType.String(),
+			InApp:            d.AllowsInApp(notificationType),
+			Email:            !hasEmail || d.Email[notificationType],
+		})
+	}
+	email := &NotificationEmail{
+		UserID:        uint64(d.UserID),
+		EmailAddress:  d.EmailAddress,
+		EmailLanguage: d.EmailLanguage.String(),
+		EmailMode:     d.EmailMode.String(),
+		LastDigestAt:  d.LastDigestAt,
+	}
+	if d.UnsubscribeToken != "" {
+		email.UnsubscribeToken = &d.UnsubscribeToken
+	}
+	return rows, email
+}
+
+// MarshalNotificationPreference / email が nil の場合はメールを送らない設定とする
+func MarshalNotificationPreference(userID domain.UserIdentifier, rows []*NotificationPreference, email *NotificationEmail) (*domain.NotificationPreference, apperr.AppErr) {
+	preference := domain.NewNotificationPreference(userID)
+	for _, row := range rows {
+		notificationType, err := marshalNotificationType(row.NotificationType)
+		if err != nil {
+			return nil, err
+		}
+		preference.InApp[*notificationType] = row.InApp
+		preference.Email[*notificationType] = row.Email
+	}
+	if email == nil {
+		return preference, nil
+	}
+	language, err := marshalLanguage(email.EmailLanguage)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := marshalEmailMode(email.EmailMode)
+	if err != nil {
+		return nil, err
+	}
+	preference.EmailAddress = email.EmailAddress
+	preference.EmailLanguage = *language
+	preference.EmailMode = *mode
+	if email.UnsubscribeToken != nil {
+		preference.UnsubscribeToken = *email.UnsubscribeToken
+	}
+	preference.LastDigestAt = email.LastDigestAt
+	return preference, nil
+}
+
+func marshalLanguage(s string) (*domain.Language, apperr.AppErr) {
+	var language domain.Language
+	switch s {
+	case "ja":
+		language = domain.LanguageJapanese
+	case "en":
+		language = domain.LanguageEnglish
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &language, nil
+}
+
+func marshalEmailMode(s string) (*domain.EmailMode, apperr.AppErr) {
+	var mode domain.EmailMode
+	switch s {
+	case "OFF":
+		mode = domain.EmailModeOff
+	case "IMMEDIATE":
+		mode = domain.EmailModeImmediate
+	case "DIGEST":
+		mode = domain.EmailModeDigest
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &mode, nil
+}
@@ -1,20 +1,37 @@
 package model
 
 import (
+	"sort"
 	"time"
 	domain "todo_api/internal/domain/model"
 	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
 )
 
 type Task struct {
 	ID               uint64
 	Title            string
 	Detail           *string
-	Status           string `gorm:"column:task_status"`
+	StatusID         uint64     `gorm:"column:task_status_id"`
+	Status           TaskStatus `gorm:"foreignKey:StatusID"`
 	Visibility       string
 	PersonInChargeID *uint64
-	PersonInCharge   *User `gorm:"foreignKey:PersonInChargeID"`
+	PersonInCharge   *User         `gorm:"foreignKey:PersonInChargeID"`
+	PriorityID       *uint64       `gorm:"column:task_priority_id"`
+	Priority         *TaskPriority `gorm:"foreignKey:PriorityID"`
+	StartDate        *time.Time
 	LimitDate        *time.Time
+	Labels           []*Label `gorm:"many2many:task_label;joinForeignKey:TaskID;joinReferences:LabelID"`
+	ParentID         *uint64
+	Checklist        []*TaskChecklistItem `gorm:"foreignKey:TaskID"`
+	Assignees        []*User              `gorm:"many2many:task_assignee;joinForeignKey:TaskID;joinReferences:UserID"`
+	Watchers         []*User              `gorm:"many2many:task_watcher;joinForeignKey:TaskID;joinReferences:UserID"`
+	SeriesID         *uint64              `gorm:"column:task_series_id"`
+	Series           *TaskSeries          `gorm:"foreignKey:SeriesID"`
+	Occurrence       *int
+	Version          uint64
+	DeleteAt         gorm.DeletedAt
 
 	CreateAt  time.Time `gorm:"autoCreateTime"`
 	CreatorID uint64
@@ -22,6 +39,9 @@ type Task struct {
 	UpdateAt  time.Time `gorm:"autoUpdateTime"`
 	UpdatorID uint64
 	Updator   User `gorm:"foreignKey:UpdatorID"`
+
+	// ChangeSeq / 保存時に企業の通し番号を採番して別に更新するため、読み込みのみ行う
+	ChangeSeq uint64 `gorm:"->"`
 }
 
 func (m *Task) TableName() string {
@@ -32,26 +52,107 @@ func UnmarshalTask(d *domain.Task) *Task {
 	if d == nil {
 		return nil
 	}
-	return &Task{
-		ID:               uint64(d.ID),
-		Title:            d.Title,
-		Detail:           d.Detail,
-		Status:           d.Status.String(),
-		Visibility:       d.Visibility.String(),
-		PersonInChargeID: (*uint64)(&d.PersonInCharge.ID),
-		LimitDate:        d.LimitDate,
-		CreateAt:         d.CreateAt,
-		CreatorID:        uint64(d.Creator.ID),
-		UpdateAt:         d.UpdateAt,
-		UpdatorID:        uint64(d.Updator.ID),
+	row := &Task{
+		ID:         uint64(d.ID),
+		Title:      d.Title,
+		Detail:     d.Detail,
+		StatusID:   uint64(d.Status.ID),
+		Visibility: d.Visibility.String(),
+		StartDate:  d.StartDate,
+		LimitDate:  d.LimitDate,
+		CreateAt:   d.CreateAt,
+		CreatorID:  uint64(d.Creator.ID),
+		UpdateAt:   d.UpdateAt,
+		UpdatorID:  uint64(d.Updator.ID),
+		Version:    uint64(d.Version),
+	}
+	if d.PersonInCharge != nil {
+		row.PersonInChargeID = (*uint64)(&d.PersonInCharge.ID)
+	}
+	if d.Priority != nil {
+		row.PriorityID = (*uint64)(&d.Priority.ID)
+	}
+	if d.ParentID != nil {
+		row.ParentID = (*uint64)(d.ParentID)
+	}
+	if d.Series != nil {
+		row.SeriesID = (*uint64)(&d.Series.ID)
+		row.Occurrence = &d.Occurrence
+	}
+	if d.DeleteAt != nil {
+		row.DeleteAt = gorm.DeletedAt{Time: *d.DeleteAt, Valid: true}
+	}
+	return row
+}
+
+// UnmarshalTaskLabels / タスクに付与されたラベルの中間テーブルの行を生成する
+func UnmarshalTaskLabels(d *domain.Task) []*TaskLabel {
+	if d == nil {
+		return nil
+	}
+	var rows []*TaskLabel
+	for _, label := range d.Labels {
+		rows = append(rows, &TaskLabel{
+			TaskID:  uint64(d.ID),
+			LabelID: uint64(label.ID),
+		})
+	}
+	return rows
+}
+
+type TaskAssignee struct {
+	TaskID uint64 `gorm:"primaryKey"`
+	UserID uint64 `gorm:"primaryKey"`
+}
+
+func (m *TaskAssignee) TableName() string {
+	return "task_assignee"
+}
+
+type TaskWatcher struct {
+	TaskID uint64 `gorm:"primaryKey"`
+	UserID uint64 `gorm:"primaryKey"`
+}
+
+func (m *TaskWatcher) TableName() string {
+	return "task_watcher"
+}
+
+// UnmarshalTaskAssignees / タスクの担当者の中間テーブルの行を生成する
+func UnmarshalTaskAssignees(d *domain.Task) []*TaskAssignee {
+	if d == nil {
+		return nil
+	}
+	var rows []*TaskAssignee
+	for _, user := range d.Assignees {
+		rows = append(rows, &TaskAssignee{
+			TaskID: uint64(d.ID),
+			UserID: uint64(user.ID),
+		})
+	}
+	return rows
+}
+
+// UnmarshalTaskWatchers / タスクのウォッチャーの中間テーブルの行を生成する
+func UnmarshalTaskWatchers(d *domain.Task) []*TaskWatcher {
+	if d == nil {
+		return nil
+	}
+	var rows []*TaskWatcher
+	for _, user := range d.Watchers {
+		rows = append(rows, &TaskWatcher{
+			TaskID: uint64(d.ID),
+			UserID: uint64(user.ID),
+		})
 	}
+	return rows
 }
 
 func MarshalTask(m *Task) (*domain.Task, apperr.AppErr) {
 	if m == nil {
 		return nil, nil
 	}
-	status, err := marshalTaskStatus(m.Status)
+	status, err := MarshalTaskStatus(&m.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +173,34 @@ func MarshalTask(m *Task) (*domain.Task, apperr.AppErr) {
 	if err != nil {
 		return nil, err
 	}
+	var labels []*domain.Label
+	for _, label := range m.Labels {
+		labels = append(labels, MarshalLabel(label))
+	}
+	var checklist []*domain.ChecklistItem
+	for _, item := range m.Checklist {
+		checklist = append(checklist, MarshalChecklistItem(item))
+	}
+	assignees, err := marshalTaskUsers(m.Assignees)
+	if err != nil {
+		return nil, err
+	}
+	// 主担当者を先頭にする
+	sort.SliceStable(assignees, func(i, j int) bool {
+		return personInCharge != nil && assignees[i].ID == personInCharge.ID && assignees[j].ID != personInCharge.ID
+	})
+	watchers, err := marshalTaskUsers(m.Watchers)
+	if err != nil {
+		return nil, err
+	}
+	series, err := MarshalTaskSeries(m.Series)
+	if err != nil {
+		return nil, err
+	}
+	var occurrence int
+	if m.Occurrence != nil {
+		occurrence = *m.Occurrence
+	}
 	return &domain.Task{
 		ID:             domain.TaskIdentifier(m.ID),
 		Title:          m.Title,
@@ -79,7 +208,18 @@ func MarshalTask(m *Task) (*domain.Task, apperr.AppErr) {
 		Status:         *status,
 		Visibility:     *visibility,
 		PersonInCharge: personInCharge,
+		Priority:       MarshalTaskPriority(m.Priority),
+		StartDate:      m.StartDate,
 		LimitDate:      m.LimitDate,
+		Labels:         labels,
+		ParentID:       (*domain.TaskIdentifier)(m.ParentID),
+		Checklist:      checklist,
+		Assignees:      assignees,
+		Watchers:       watchers,
+		Series:         series,
+		Occurrence:     occurrence,
+		Version:        domain.Version(m.Version),
+		ChangeSeq:      m.ChangeSeq,
 		CreateAt:       m.CreateAt,
 		Creator:        *creator,
 		UpdateAt:       m.UpdateAt,
@@ -87,19 +227,20 @@ func MarshalTask(m *Task) (*domain.Task, apperr.AppErr) {
 	}, nil
 }
 
-func marshalTaskStatus(s string) (*domain.TaskStatus, apperr.AppErr) {
-	var status domain.TaskStatus
-	switch s {
-	case "NEW":
-		status = domain.TaskStatusNew
-	case "PROCESSING":
-		status = domain.TaskStatusProcessing
-	case "DONE":
-		status = domain.TaskStatusDone
-	default:
-		return nil, apperr.NewInternalServerError()
+// marshalTaskUsers / 担当者・ウォッチャーをID順に変換する
+func marshalTaskUsers(rows []*User) ([]*domain.User, apperr.AppErr) {
+	var users []*domain.User
+	for _, row := range rows {
+		user, err := MarshalUser(row)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
 	}
-	return &status, nil
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
 }
 
 func marshalTaskVisibility(s string) (*domain.TaskVisibility, apperr.AppErr) {
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskHistory struct {
+	ID        uint64
+	TaskID    uint64
+	Action    string
+	FieldName string
+	OldValue  *string
+	NewValue  *string
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+	ActorID  uint64
+	Actor    User `gorm:"foreignKey:ActorID"`
+}
+
+func (m *TaskHistory) TableName() string {
+	return "task_history"
+}
+
+// UnmarshalTaskHistories / タスクの保存されていない変更を履歴の行に変換する。変更者はタスクの更新者とする。
+func UnmarshalTaskHistories(d *domain.Task) []*TaskHistory {
+	var rows []*TaskHistory
+	for _, change := range d.Changes {
+		rows = append(rows, &TaskHistory{
+			TaskID:    uint64(d.ID),
+			Action:    change.Action.String(),
+			FieldName: string(change.Field),
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			ActorID:   uint64(d.Updator.ID),
+		})
+	}
+	return rows
+}
+
+func MarshalTaskHistory(m *TaskHistory) (*domain.TaskHistory, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	action, err := marshalTaskChangeAction(m.Action)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := MarshalUser(&m.Actor)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TaskHistory{
+		ID:     domain.TaskHistoryIdentifier(m.ID),
+		TaskID: domain.TaskIdentifier(m.TaskID),
+		TaskChange: domain.TaskChange{
+			Action:   *action,
+			Field:    domain.TaskField(m.FieldName),
+			OldValue: m.OldValue,
+			NewValue: m.NewValue,
+		},
+		CreateAt: m.CreateAt,
+		Actor:    *actor,
+	}, nil
+}
+
+func marshalTaskChangeAction(s string) (*domain.TaskChangeAction, apperr.AppErr) {
+	var action domain.TaskChangeAction
+	switch s {
+	case "CREATE":
+		action = domain.TaskChangeActionCreate
+	case "UPDATE":
+		action = domain.TaskChangeActionUpdate
+	case "STATUS":
+		action = domain.TaskChangeActionStatus
+	case "ASSIGN":
+		action = domain.TaskChangeActionAssign
+	case "DELETE":
+		action = domain.TaskChangeActionDelete
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &action, nil
+}
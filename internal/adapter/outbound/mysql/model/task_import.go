@@ -0,0 +1,339 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskImportJob struct {
+	ID         uint64
+	CompanyID  uint64
+	UserID     uint64
+	Source     string
+	DryRun     bool
+	JobStatus  string
+	JobRows    string
+	Total      int
+	Processed  int
+	Succeeded  int
+	Failed     int
+	Skipped    int
+	Errors     string
+	LastError  *string
+	CreateAt   time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+
+	UnmatchedUsers *string
+}
+
+func (m *TaskImportJob) TableName() string {
+	return "task_import_job"
+}
+
+type TaskExternalRef struct {
+	CompanyID  uint64
+	Source     string
+	ExternalID string
+	TaskID     uint64
+	CreateAt   time.Time `gorm:"->"`
+}
+
+func (m *TaskExternalRef) TableName() string {
+	return "task_external_ref"
+}
+
+func UnmarshalTaskExternalRef(d *domain.TaskExternalRef, taskID domain.TaskIdentifier) *TaskExternalRef {
+	if d == nil {
+		return nil
+	}
+	return &TaskExternalRef{
+		CompanyID:  uint64(d.CompanyID),
+		Source:     d.Source.String(),
+		ExternalID: d.ExternalID,
+		TaskID:     uint64(taskID),
+	}
+}
+
+type taskImportRow struct {
+	Line           int                `json:"line"`
+	Title          string             `json:"title"`
+	Detail         *string            `json:"detail,omitempty"`
+	Visibility     string             `json:"visibility,omitempty"`
+	PersonInCharge *string            `json:"person_in_charge,omitempty"`
+	Assignees      []string           `json:"assignees,omitempty"`
+	Watchers       []string           `json:"watchers,omitempty"`
+	Priority       *string            `json:"priority,omitempty"`
+	StartDate      *time.Time         `json:"start_date,omitempty"`
+	LimitDate      *time.Time         `json:"limit_date,omitempty"`
+	Labels         []string           `json:"labels,omitempty"`
+	ParentID       *uint64            `json:"parent_id,omitempty"`
+	Errors         []*taskImportError `json:"errors,omitempty"`
+
+	Status           *string                    `json:"status,omitempty"`
+	Checklist        []*taskImportChecklistItem `json:"checklist,omitempty"`
+	ExternalID       *string                    `json:"external_id,omitempty"`
+	ParentExternalID *string                    `json:"parent_external_id,omitempty"`
+	Comments         []*taskImportComment       `json:"comments,omitempty"`
+}
+
+type taskImportChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+type taskImportComment struct {
+	Author   string     `json:"author"`
+	Body     string     `json:"body"`
+	CreateAt *time.Time `json:"create_at,omitempty"`
+}
+
+type taskImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func UnmarshalTaskImportJob(d *domain.TaskImportJob) (*TaskImportJob, apperr.AppErr) {
+	if d == nil {
+		return nil, nil
+	}
+	rows := []*taskImportRow{}
+	for _, row := range d.Rows {
+		r := &taskImportRow{
+			Line:           row.Line,
+			Title:          row.Title,
+			Detail:         row.Detail,
+			Visibility:     row.Visibility.String(),
+			PersonInCharge: row.PersonInCharge,
+			Assignees:      row.Assignees,
+			Watchers:       row.Watchers,
+			Priority:       row.Priority,
+			StartDate:      row.StartDate,
+			LimitDate:      row.LimitDate,
+			Labels:         row.Labels,
+			ParentID:       (*uint64)(row.ParentID),
+			Errors:         unmarshalTaskImportErrors(row.Errors),
+
+			Status:           row.Status,
+			ExternalID:       row.ExternalID,
+			ParentExternalID: row.ParentExternalID,
+		}
+		for _, item := range row.Checklist {
+			r.Checklist = append(r.Checklist, &taskImportChecklistItem{Text: item.Text, Done: item.Done})
+		}
+		for _, comment := range row.Comments {
+			r.Comments = append(r.Comments, &taskImportComment{
+				Author:   comment.Author,
+				Body:     comment.Body,
+				CreateAt: comment.CreateAt,
+			})
+		}
+		rows = append(rows, r)
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	errs, aerr := UnmarshalTaskImportErrors(d)
+	if aerr != nil {
+		return nil, aerr
+	}
+	unmatchedUsers, aerr := UnmarshalTaskImportUnmatchedUsers(d)
+	if aerr != nil {
+		return nil, aerr
+	}
+	return &TaskImportJob{
+		ID:         uint64(d.ID),
+		CompanyID:  uint64(d.CompanyID),
+		UserID:     uint64(d.UserID),
+		Source:     d.Source.String(),
+		DryRun:     d.DryRun,
+		JobStatus:  d.Status.String(),
+		JobRows:    string(b),
+		Total:      d.Total,
+		Processed:  d.Processed,
+		Succeeded:  d.Succeeded,
+		Failed:     d.Failed,
+		Skipped:    d.Skipped,
+		Errors:     errs,
+		LastError:  d.LastError,
+		CreateAt:   d.CreateAt,
+		StartedAt:  d.StartedAt,
+		FinishedAt: d.FinishedAt,
+
+		UnmatchedUsers: unmatchedUsers,
+	}, nil
+}
+
+// UnmarshalTaskImportErrors / 行ごとの誤りを JSON にする
+func UnmarshalTaskImportErrors(d *domain.TaskImportJob) (string, apperr.AppErr) {
+	b, err := json.Marshal(unmarshalTaskImportErrors(d.Errors))
+	if err != nil {
+		return "", apperr.NewInternalServerError().Wrap(err)
+	}
+	return string(b), nil
+}
+
+// UnmarshalTaskImportUnmatchedUsers / 一致しなかったユーザを JSON にする。ない場合は nil とする。
+func UnmarshalTaskImportUnmatchedUsers(d *domain.TaskImportJob) (*string, apperr.AppErr) {
+	if len(d.UnmatchedUsers) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(d.UnmatchedUsers)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	s := string(b)
+	return &s, nil
+}
+
+func unmarshalTaskImportErrors(errs []*domain.TaskImportError) []*taskImportError {
+	res := []*taskImportError{}
+	for _, err := range errs {
+		res = append(res, &taskImportError{
+			Line:    err.Line,
+			Field:   err.Field,
+			Message: err.Message,
+		})
+	}
+	return res
+}
+
+// MarshalTaskImportJob / 行を読み込んでいない場合（JobRows が空）は行を含めない
+func MarshalTaskImportJob(m *TaskImportJob) (*domain.TaskImportJob, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	status, err := marshalTaskImportStatus(m.JobStatus)
+	if err != nil {
+		return nil, err
+	}
+	source, err := marshalTaskImportSource(m.Source)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.TaskImportJob{
+		ID:         domain.TaskImportJobIdentifier(m.ID),
+		CompanyID:  domain.CompanyIdentifier(m.CompanyID),
+		UserID:     domain.UserIdentifier(m.UserID),
+		Source:     *source,
+		DryRun:     m.DryRun,
+		Status:     *status,
+		Total:      m.Total,
+		Processed:  m.Processed,
+		Succeeded:  m.Succeeded,
+		Failed:     m.Failed,
+		Skipped:    m.Skipped,
+		LastError:  m.LastError,
+		CreateAt:   m.CreateAt,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+	}
+
+	var errs []*taskImportError
+	if err := json.Unmarshal([]byte(m.Errors), &errs); err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	d.Errors = marshalTaskImportErrors(errs)
+	if m.UnmatchedUsers != nil {
+		if err := json.Unmarshal([]byte(*m.UnmatchedUsers), &d.UnmatchedUsers); err != nil {
+			return nil, apperr.NewInternalServerError().Wrap(err)
+		}
+	}
+
+	if m.JobRows == "" {
+		return d, nil
+	}
+	var rows []*taskImportRow
+	if err := json.Unmarshal([]byte(m.JobRows), &rows); err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	for _, r := range rows {
+		row := &domain.TaskImportRow{
+			Line:           r.Line,
+			Title:          r.Title,
+			Detail:         r.Detail,
+			PersonInCharge: r.PersonInCharge,
+			Assignees:      r.Assignees,
+			Watchers:       r.Watchers,
+			Priority:       r.Priority,
+			StartDate:      r.StartDate,
+			LimitDate:      r.LimitDate,
+			Labels:         r.Labels,
+			ParentID:       (*domain.TaskIdentifier)(r.ParentID),
+			Errors:         marshalTaskImportErrors(r.Errors),
+
+			Status:           r.Status,
+			ExternalID:       r.ExternalID,
+			ParentExternalID: r.ParentExternalID,
+		}
+		for _, item := range r.Checklist {
+			row.Checklist = append(row.Checklist, domain.ChecklistItemDescription{Text: item.Text, Done: item.Done})
+		}
+		for _, comment := range r.Comments {
+			row.Comments = append(row.Comments, &domain.TaskImportComment{
+				Author:   comment.Author,
+				Body:     comment.Body,
+				CreateAt: comment.CreateAt,
+			})
+		}
+		if r.Visibility != "" {
+			visibility, err := marshalTaskVisibility(r.Visibility)
+			if err != nil {
+				return nil, err
+			}
+			row.Visibility = *visibility
+		}
+		d.Rows = append(d.Rows, row)
+	}
+	return d, nil
+}
+
+func marshalTaskImportErrors(errs []*taskImportError) []*domain.TaskImportError {
+	var res []*domain.TaskImportError
+	for _, err := range errs {
+		res = append(res, &domain.TaskImportError{
+			Line:    err.Line,
+			Field:   err.Field,
+			Message: err.Message,
+		})
+	}
+	return res
+}
+
+func marshalTaskImportStatus(s string) (*domain.TaskImportStatus, apperr.AppErr) {
+	var status domain.TaskImportStatus
+	switch s {
+	case "PENDING":
+		status = domain.TaskImportStatusPending
+	case "RUNNING":
+		status = domain.TaskImportStatusRunning
+	case "SUCCEEDED":
+		status = domain.TaskImportStatusSucceeded
+	case "FAILED":
+		status = domain.TaskImportStatusFailed
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &status, nil
+}
+
+func marshalTaskImportSource(s string) (*domain.TaskImportSource, apperr.AppErr) {
+	var source domain.TaskImportSource
+	switch s {
+	case "FILE":
+		source = domain.TaskImportSourceFile
+	case "TRELLO":
+		source = domain.TaskImportSourceTrello
+	case "JIRA":
+		source = domain.TaskImportSourceJira
+	case "GITHUB":
+		source = domain.TaskImportSourceGitHub
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &source, nil
+}
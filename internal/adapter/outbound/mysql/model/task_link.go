@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskLink struct {
+	ID           uint64
+	CompanyID    uint64
+	LinkType     string
+	SourceTaskID uint64
+	TargetTaskID uint64
+
+	CreateAt  time.Time `gorm:"autoCreateTime"`
+	CreatorID uint64
+	Creator   User `gorm:"foreignKey:CreatorID"`
+}
+
+func (m *TaskLink) TableName() string {
+	return "task_link"
+}
+
+func UnmarshalTaskLink(d *domain.TaskLink) *TaskLink {
+	if d == nil {
+		return nil
+	}
+	return &TaskLink{
+		ID:           uint64(d.ID),
+		CompanyID:    uint64(d.CompanyID),
+		LinkType:     d.Type.String(),
+		SourceTaskID: uint64(d.SourceID),
+		TargetTaskID: uint64(d.TargetID),
+		CreateAt:     d.CreateAt,
+		CreatorID:    uint64(d.Creator.ID),
+	}
+}
+
+func MarshalTaskLink(m *TaskLink) (*domain.TaskLink, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	linkType, err := marshalTaskLinkType(m.LinkType)
+	if err != nil {
+		return nil, err
+	}
+	creator, err := MarshalUser(&m.Creator)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TaskLink{
+		ID:        domain.TaskLinkIdentifier(m.ID),
+		CompanyID: domain.CompanyIdentifier(m.CompanyID),
+		Type:      *linkType,
+		SourceID:  domain.TaskIdentifier(m.SourceTaskID),
+		TargetID:  domain.TaskIdentifier(m.TargetTaskID),
+		CreateAt:  m.CreateAt,
+		Creator:   *creator,
+	}, nil
+}
+
+func marshalTaskLinkType(s string) (*domain.TaskLinkType, apperr.AppErr) {
+	var linkType domain.TaskLinkType
+	switch s {
+	case "BLOCKS":
+		linkType = domain.TaskLinkTypeBlocks
+	case "RELATES_TO":
+		linkType = domain.TaskLinkTypeRelatesTo
+	case "DUPLICATES":
+		linkType = domain.TaskLinkTypeDuplicates
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &linkType, nil
+}
@@ -0,0 +1,38 @@
+package model
+
+import domain "todo_api/internal/domain/model"
+
+type TaskPriority struct {
+	ID        uint64
+	CompanyID uint64
+	Name      string `gorm:"column:priority_name"`
+	Level     int    `gorm:"column:priority_level"`
+}
+
+func (m *TaskPriority) TableName() string {
+	return "task_priority"
+}
+
+func UnmarshalTaskPriority(d *domain.TaskPriority) *TaskPriority {
+	if d == nil {
+		return nil
+	}
+	return &TaskPriority{
+		ID:        uint64(d.ID),
+		CompanyID: uint64(d.CompanyID),
+		Name:      d.Name,
+		Level:     d.Level,
+	}
+}
+
+func MarshalTaskPriority(m *TaskPriority) *domain.TaskPriority {
+	if m == nil {
+		return nil
+	}
+	return &domain.TaskPriority{
+		ID:        domain.TaskPriorityIdentifier(m.ID),
+		CompanyID: domain.CompanyIdentifier(m.CompanyID),
+		Name:      m.Name,
+		Level:     m.Level,
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+	domain "todo_api/internal/domain/model"
+)
+
+type TaskReminder struct {
+	ID            uint64
+	TaskID        uint64
+	UserID        uint64
+	OffsetSeconds int
+	LimitDate     time.Time
+	CreateAt      time.Time
+}
+
+func (m *TaskReminder) TableName() string {
+	return "task_reminder"
+}
+
+func UnmarshalTaskReminder(d *domain.TaskReminder) *TaskReminder {
+	if d == nil {
+		return nil
+	}
+	return &TaskReminder{
+		ID:            uint64(d.ID),
+		TaskID:        uint64(d.TaskID),
+		UserID:        uint64(d.UserID),
+		OffsetSeconds: int(d.Offset / time.Second),
+		LimitDate:     d.LimitDate,
+		CreateAt:      d.CreateAt,
+	}
+}
+
+func MarshalTaskReminder(m *TaskReminder) *domain.TaskReminder {
+	if m == nil {
+		return nil
+	}
+	return &domain.TaskReminder{
+		ID:        domain.TaskReminderIdentifier(m.ID),
+		TaskID:    domain.TaskIdentifier(m.TaskID),
+		UserID:    domain.UserIdentifier(m.UserID),
+		Offset:    time.Duration(m.OffsetSeconds) * time.Second,
+		LimitDate: m.LimitDate,
+		CreateAt:  m.CreateAt,
+	}
+}
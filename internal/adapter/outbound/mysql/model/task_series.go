@@ -0,0 +1,109 @@
+package model
+
+import (
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskSeries struct {
+	ID             uint64
+	Frequency      string
+	Interval       int `gorm:"column:recurrence_interval"`
+	Weekdays       string
+	Until          *time.Time `gorm:"column:until_date"`
+	Count          *int       `gorm:"column:max_count"`
+	Anchor         time.Time  `gorm:"column:anchor_date"`
+	TemplateTaskID *uint64
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+	UpdateAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (m *TaskSeries) TableName() string {
+	return "task_series"
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func UnmarshalTaskSeries(d *domain.TaskSeries) *TaskSeries {
+	if d == nil {
+		return nil
+	}
+	var weekdays []string
+	for _, weekday := range d.Recurrence.Weekdays {
+		weekdays = append(weekdays, weekdayNames[weekday])
+	}
+	templateTaskID := uint64(d.TemplateTaskID)
+	return &TaskSeries{
+		ID:             uint64(d.ID),
+		Frequency:      d.Recurrence.Frequency.String(),
+		Interval:       d.Recurrence.Interval,
+		Weekdays:       strings.Join(weekdays, ","),
+		Until:          d.Recurrence.Until,
+		Count:          d.Recurrence.Count,
+		Anchor:         d.Anchor,
+		TemplateTaskID: &templateTaskID,
+	}
+}
+
+func MarshalTaskSeries(m *TaskSeries) (*domain.TaskSeries, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	frequency, err := marshalRecurrenceFrequency(m.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	var weekdays []time.Weekday
+	if m.Weekdays != "" {
+		for _, name := range strings.Split(m.Weekdays, ",") {
+			weekday, err := marshalWeekday(name)
+			if err != nil {
+				return nil, err
+			}
+			weekdays = append(weekdays, *weekday)
+		}
+	}
+	series := &domain.TaskSeries{
+		ID: domain.TaskSeriesIdentifier(m.ID),
+		Recurrence: domain.Recurrence{
+			Frequency: *frequency,
+			Interval:  m.Interval,
+			Weekdays:  weekdays,
+			Until:     m.Until,
+			Count:     m.Count,
+		},
+		Anchor: m.Anchor,
+	}
+	if m.TemplateTaskID != nil {
+		series.TemplateTaskID = domain.TaskIdentifier(*m.TemplateTaskID)
+	}
+	return series, nil
+}
+
+func marshalRecurrenceFrequency(s string) (*domain.RecurrenceFrequency, apperr.AppErr) {
+	var frequency domain.RecurrenceFrequency
+	switch s {
+	case "DAILY":
+		frequency = domain.RecurrenceFrequencyDaily
+	case "WEEKLY":
+		frequency = domain.RecurrenceFrequencyWeekly
+	case "MONTHLY":
+		frequency = domain.RecurrenceFrequencyMonthly
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &frequency, nil
+}
+
+func marshalWeekday(s string) (*time.Weekday, apperr.AppErr) {
+	for i, name := range weekdayNames {
+		if name == s {
+			weekday := time.Weekday(i)
+			return &weekday, nil
+		}
+	}
+	return nil, apperr.NewInternalServerError()
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskStreamEvent struct {
+	ID         uint64
+	CompanyID  uint64
+	EventType  string
+	TaskID     uint64
+	CreatorID  uint64
+	Visibility string
+	ActorID    *uint64
+	Changes    string
+	OccurredAt time.Time
+}
+
+func (m *TaskStreamEvent) TableName() string {
+	return "task_stream_event"
+}
+
+func UnmarshalTaskStreamEvent(d *domain.TaskStreamEvent) (*TaskStreamEvent, apperr.AppErr) {
+	if d == nil {
+		return nil, nil
+	}
+	changes := []*domainEventChange{}
+	for _, change := range d.Changes {
+		changes = append(changes, &domainEventChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	b, err := json.Marshal(changes)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return &TaskStreamEvent{
+		ID:         uint64(d.ID),
+		CompanyID:  uint64(d.CompanyID),
+		EventType:  d.Type.String(),
+		TaskID:     uint64(d.TaskID),
+		CreatorID:  uint64(d.CreatorID),
+		Visibility: d.Visibility.String(),
+		ActorID:    (*uint64)(d.ActorID),
+		Changes:    string(b),
+		OccurredAt: d.OccurredAt,
+	}, nil
+}
+
+func MarshalTaskStreamEvent(m *TaskStreamEvent) (*domain.TaskStreamEvent, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	eventType, err := marshalDomainEventType(m.EventType)
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := marshalTaskVisibility(m.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*domainEventChange
+	if err := json.Unmarshal([]byte(m.Changes), &changes); err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	d := &domain.TaskStreamEvent{
+		ID:         domain.TaskStreamEventIdentifier(m.ID),
+		Type:       *eventType,
+		CompanyID:  domain.CompanyIdentifier(m.CompanyID),
+		TaskID:     domain.TaskIdentifier(m.TaskID),
+		CreatorID:  domain.UserIdentifier(m.CreatorID),
+		Visibility: *visibility,
+		ActorID:    (*domain.UserIdentifier)(m.ActorID),
+		OccurredAt: m.OccurredAt,
+	}
+	for _, change := range changes {
+		d.Changes = append(d.Changes, &domain.DomainEventChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return d, nil
+}
@@ -12,6 +12,7 @@ type User struct {
 	UserType  string
 	CompanyID uint64
 	Company   Company
+	Version   uint64
 }
 
 func (m *User) TableName() string {
@@ -28,6 +29,7 @@ func UnmarshalUser(d *domain.User) *User {
 		Role:      d.Role.String(),
 		UserType:  d.UserType.String(),
 		CompanyID: uint64(d.Company.ID),
+		Version:   uint64(d.Version),
 	}
 }
 
@@ -50,6 +52,7 @@ func MarshalUser(m *User) (*domain.User, apperr.AppErr) {
 		Role:     *role,
 		UserType: *userType,
 		Company:  *MarshalCompany(&m.Company),
+		Version:  domain.Version(m.Version),
 	}, nil
 }
 
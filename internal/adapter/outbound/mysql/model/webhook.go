@@ -0,0 +1,190 @@
+package model
+
+import (
+	"strings"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type Webhook struct {
+	ID         uint64
+	CompanyID  uint64
+	URL        string `gorm:"column:url"`
+	Secret     string
+	EventTypes string
+	Active     bool
+	Failures   int
+	DisabledAt *time.Time
+
+	CreateAt time.Time `gorm:"autoCreateTime"`
+	UpdateAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (m *Webhook) TableName() string {
+	return "webhook"
+}
+
+type WebhookDelivery struct {
+	ID             uint64
+	WebhookID      uint64
+	EventID        string
+	EventType      string
+	Payload        string
+	DeliveryStatus string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseCode   *int
+	LastError      *string
+	RedeliveryOf   *uint64
+	CreateAt       time.Time
+	DeliveredAt    *time.Time
+}
+
+func (m *WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+func UnmarshalWebhook(d *domain.Webhook) *Webhook {
+	if d == nil {
+		return nil
+	}
+	var eventTypes []string
+	for _, eventType := range d.EventTypes {
+		eventTypes = append(eventTypes, eventType.String())
+	}
+	return &Webhook{
+		ID:         uint64(d.ID),
+		CompanyID:  uint64(d.CompanyID),
+		URL:        d.URL,
+		Secret:     d.Secret,
+		EventTypes: strings.Join(eventTypes, ","),
+		Active:     d.Active,
+		Failures:   d.Failures,
+		DisabledAt: d.DisabledAt,
+		CreateAt:   d.CreateAt,
+		UpdateAt:   d.UpdateAt,
+	}
+}
+
+func MarshalWebhook(m *Webhook) (*domain.Webhook, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	var eventTypes []domain.WebhookEventType
+	if m.EventTypes != "" {
+		for _, name := range strings.Split(m.EventTypes, ",") {
+			eventType, err := marshalWebhookEventType(name)
+			if err != nil {
+				return nil, err
+			}
+			eventTypes = append(eventTypes, *eventType)
+		}
+	}
+	return &domain.Webhook{
+		ID:         domain.WebhookIdentifier(m.ID),
+		CompanyID:  domain.CompanyIdentifier(m.CompanyID),
+		URL:        m.URL,
+		Secret:     m.Secret,
+		EventTypes: eventTypes,
+		Active:     m.Active,
+		Failures:   m.Failures,
+		DisabledAt: m.DisabledAt,
+		CreateAt:   m.CreateAt,
+		UpdateAt:   m.UpdateAt,
+	}, nil
+}
+
+func UnmarshalWebhookDelivery(d *domain.WebhookDelivery) *WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	m := &WebhookDelivery{
+		ID:             uint64(d.ID),
+		WebhookID:      uint64(d.WebhookID),
+		EventID:        d.EventID,
+		EventType:      d.EventType.String(),
+		Payload:        d.Payload,
+		DeliveryStatus: d.Status.String(),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseCode:   d.ResponseCode,
+		LastError:      d.LastError,
+		CreateAt:       d.CreateAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.RedeliveryOf != nil {
+		redeliveryOf := uint64(*d.RedeliveryOf)
+		m.RedeliveryOf = &redeliveryOf
+	}
+	return m
+}
+
+func MarshalWebhookDelivery(m *WebhookDelivery) (*domain.WebhookDelivery, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	eventType, err := marshalWebhookEventType(m.EventType)
+	if err != nil {
+		return nil, err
+	}
+	status, err := marshalWebhookDeliveryStatus(m.DeliveryStatus)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.WebhookDelivery{
+		ID:            domain.WebhookDeliveryIdentifier(m.ID),
+		WebhookID:     domain.WebhookIdentifier(m.WebhookID),
+		EventID:       m.EventID,
+		EventType:     *eventType,
+		Payload:       m.Payload,
+		Status:        *status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		ResponseCode:  m.ResponseCode,
+		LastError:     m.LastError,
+		CreateAt:      m.CreateAt,
+		DeliveredAt:   m.DeliveredAt,
+	}
+	if m.RedeliveryOf != nil {
+		redeliveryOf := domain.WebhookDeliveryIdentifier(*m.RedeliveryOf)
+		d.RedeliveryOf = &redeliveryOf
+	}
+	return d, nil
+}
+
+func marshalWebhookEventType(s string) (*domain.WebhookEventType, apperr.AppErr) {
+	var eventType domain.WebhookEventType
+	switch s {
+	case "task.created":
+		eventType = domain.WebhookEventTypeTaskCreated
+	case "task.updated":
+		eventType = domain.WebhookEventTypeTaskUpdated
+	case "task.status_changed":
+		eventType = domain.WebhookEventTypeTaskStatusChanged
+	case "task.deleted":
+		eventType = domain.WebhookEventTypeTaskDeleted
+	case "comment.created":
+		eventType = domain.WebhookEventTypeCommentCreated
+	case "user.created":
+		eventType = domain.WebhookEventTypeUserCreated
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &eventType, nil
+}
+
+func marshalWebhookDeliveryStatus(s string) (*domain.WebhookDeliveryStatus, apperr.AppErr) {
+	var status domain.WebhookDeliveryStatus
+	switch s {
+	case "PENDING":
+		status = domain.WebhookDeliveryStatusPending
+	case "SUCCEEDED":
+		status = domain.WebhookDeliveryStatusSucceeded
+	case "FAILED":
+		status = domain.WebhookDeliveryStatusFailed
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &status, nil
+}
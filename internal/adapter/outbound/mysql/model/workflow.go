@@ -0,0 +1,124 @@
+package model
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskStatus struct {
+	ID        uint64
+	CompanyID uint64
+	Name      string `gorm:"column:status_name"`
+	Category  string `gorm:"column:status_category"`
+	SortOrder int
+}
+
+func (m *TaskStatus) TableName() string {
+	return "task_status"
+}
+
+type TaskStatusTransition struct {
+	ID           uint64
+	CompanyID    uint64
+	FromStatusID uint64
+	ToStatusID   uint64
+	Role         *string `gorm:"column:user_role"`
+	UserType     *string
+}
+
+func (m *TaskStatusTransition) TableName() string {
+	return "task_status_transition"
+}
+
+func UnmarshalTaskStatus(d *domain.TaskStatus) *TaskStatus {
+	if d == nil {
+		return nil
+	}
+	return &TaskStatus{
+		ID:        uint64(d.ID),
+		CompanyID: uint64(d.CompanyID),
+		Name:      d.Name,
+		Category:  d.Category.String(),
+		SortOrder: d.SortOrder,
+	}
+}
+
+func MarshalTaskStatus(m *TaskStatus) (*domain.TaskStatus, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	category, err := marshalTaskStatusCategory(m.Category)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TaskStatus{
+		ID:        domain.TaskStatusIdentifier(m.ID),
+		CompanyID: domain.CompanyIdentifier(m.CompanyID),
+		Name:      m.Name,
+		Category:  *category,
+		SortOrder: m.SortOrder,
+	}, nil
+}
+
+func UnmarshalTaskStatusTransition(d *domain.TaskStatusTransition) *TaskStatusTransition {
+	if d == nil {
+		return nil
+	}
+	row := &TaskStatusTransition{
+		ID:           uint64(d.ID),
+		CompanyID:    uint64(d.CompanyID),
+		FromStatusID: uint64(d.FromStatusID),
+		ToStatusID:   uint64(d.ToStatusID),
+	}
+	if d.Role != nil {
+		role := d.Role.String()
+		row.Role = &role
+	}
+	if d.UserType != nil {
+		userType := d.UserType.String()
+		row.UserType = &userType
+	}
+	return row
+}
+
+func MarshalTaskStatusTransition(m *TaskStatusTransition) (*domain.TaskStatusTransition, apperr.AppErr) {
+	if m == nil {
+		return nil, nil
+	}
+	transition := &domain.TaskStatusTransition{
+		ID:           domain.TaskStatusTransitionIdentifier(m.ID),
+		CompanyID:    domain.CompanyIdentifier(m.CompanyID),
+		FromStatusID: domain.TaskStatusIdentifier(m.FromStatusID),
+		ToStatusID:   domain.TaskStatusIdentifier(m.ToStatusID),
+	}
+	if m.Role != nil {
+		role, err := marshalUserRole(*m.Role)
+		if err != nil {
+			return nil, err
+		}
+		transition.Role = role
+	}
+	if m.UserType != nil {
+		userType, err := marshalUserType(*m.UserType)
+		if err != nil {
+			return nil, err
+		}
+		transition.UserType = userType
+	}
+	return transition, nil
+}
+
+func marshalTaskStatusCategory(s string) (*domain.TaskStatusCategory, apperr.AppErr) {
+	var category domain.TaskStatusCategory
+	switch s {
+	case "OPEN":
+		category = domain.TaskStatusCategoryOpen
+	case "IN_PROGRESS":
+		category = domain.TaskStatusCategoryInProgress
+	case "CLOSED":
+		category = domain.TaskStatusCategoryClosed
+	default:
+		return nil, apperr.NewInternalServerError()
+	}
+	return &category, nil
+}
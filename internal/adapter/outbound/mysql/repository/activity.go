@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{db}
+}
+
+func (r *ActivityRepository) ListByCompanyID(viewer *domain.User, companyID domain.CompanyIdentifier, filter domain.ActivityFilter, cursor domain.ActivityCursor) ([]*domain.Activity, apperr.AppErr) {
+	query := r.db.
+		Preload("Actor.Company").
+		Joins("LEFT JOIN task ON task.id = activity.task_id").
+		Where("activity.company_id", companyID)
+	// Task.IsVisibleTo と同じ条件で、閲覧できないタスクの出来事を除外する
+	if viewer.Company.ID != domain.AdminCompanyID {
+		query = query.Where(
+			"activity.task_id IS NULL OR (task.visibility = ? OR task.creator_id = ?)",
+			domain.TaskVisibilityCompany.String(), viewer.ID,
+		)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("activity.actor_id", *filter.ActorID)
+	}
+	if filter.TaskID != nil {
+		query = query.Where("activity.task_id", *filter.TaskID)
+	}
+	if len(filter.Types) > 0 {
+		var types []string
+		for _, activityType := range filter.Types {
+			types = append(types, activityType.String())
+		}
+		query = query.Where("activity.activity_type", types)
+	}
+	if cursor.After != 0 {
+		query = query.Where("activity.id < ?", cursor.After)
+	}
+
+	var rows []*model.Activity
+	if err := query.
+		Order("activity.id DESC").
+		Limit(cursor.Limit).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var activities []*domain.Activity
+	for _, row := range rows {
+		activity, aerr := model.MarshalActivity(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		activities = append(activities, activity)
+	}
+	return activities, nil
+}
+
+func (r *ActivityRepository) Create(activities ...*domain.Activity) apperr.AppErr {
+	if len(activities) == 0 {
+		return nil
+	}
+	var rows []*model.Activity
+	for _, activity := range activities {
+		rows = append(rows, model.UnmarshalActivity(activity))
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
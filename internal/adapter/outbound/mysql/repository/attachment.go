@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db}
+}
+
+func (r *AttachmentRepository) Get(id domain.AttachmentIdentifier) (*domain.Attachment, apperr.AppErr) {
+	var row *model.Attachment
+	if err := r.db.Preload("Creator.Company").First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalAttachment(row)
+}
+
+func (r *AttachmentRepository) ListByTaskID(taskID domain.TaskIdentifier) ([]*domain.Attachment, apperr.AppErr) {
+	var rows []*model.Attachment
+	if err := r.db.Preload("Creator.Company").
+		Where("task_id", taskID).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var attachments []*domain.Attachment
+	for _, row := range rows {
+		attachment, aerr := model.MarshalAttachment(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) SumSizeByCompanyID(companyID domain.CompanyIdentifier) (int64, apperr.AppErr) {
+	total, err := sumAttachmentSize(r.db, companyID)
+	if err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	return total, nil
+}
+
+func (r *AttachmentRepository) Create(attachment *domain.Attachment, quota int64) (*domain.AttachmentIdentifier, apperr.AppErr) {
+	row := model.UnmarshalAttachment(attachment)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 企業の行をロックして登録を直列にし、同時の登録で合計容量が上限を超えないようにする
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Company{}, attachment.CompanyID).Error; err != nil {
+			return err
+		}
+		total, err := sumAttachmentSize(tx, attachment.CompanyID)
+		if err != nil {
+			return err
+		}
+		if aerr := attachment.ValidateQuota(total, quota); aerr != nil {
+			return aerr
+		}
+		return tx.Create(&row).Error
+	}); err != nil {
+		return nil, apperr.FromError(err)
+	}
+	id := domain.AttachmentIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *AttachmentRepository) Delete(id domain.AttachmentIdentifier) apperr.AppErr {
+	if err := r.db.Delete(&model.Attachment{}, id).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func sumAttachmentSize(db *gorm.DB, companyID domain.CompanyIdentifier) (int64, error) {
+	var total int64
+	err := db.Model(&model.Attachment{}).
+		Where("company_id", companyID).
+		Select("COALESCE(SUM(file_size), 0)").
+		Scan(&total).Error
+	return total, err
+}
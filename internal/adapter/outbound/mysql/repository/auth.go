@@ -34,17 +34,31 @@ func (r *AuthRepository) Get(id domain.UserIdentifier) (*domain.Auth, apperr.App
 
 func (r *AuthRepository) Create(auth *domain.Auth) (*domain.UserIdentifier, apperr.AppErr) {
 	row := model.UnmarshalAuth(auth)
-	if err := r.db.Create(&row).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, auth.Events, row.ID)
+	}); err != nil {
 		return nil, apperr.NewInternalServerError().Wrap(err)
 	}
+	auth.Events = nil
 	id := domain.UserIdentifier(row.ID)
 	return &id, nil
 }
 
 func (r *AuthRepository) Update(auth *domain.Auth) apperr.AppErr {
 	row := model.UnmarshalAuth(auth)
-	if err := r.db.Save(&row).Error; err != nil {
-		return apperr.NewInternalServerError().Wrap(err)
+	row.Version++
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, row, auth.Version); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, auth.Events, uint64(auth.ID))
+	}); err != nil {
+		return apperr.FromError(err)
 	}
+	auth.Events = nil
+	auth.Version++
 	return nil
 }
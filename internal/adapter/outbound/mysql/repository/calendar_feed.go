@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db}
+}
+
+func (r *CalendarFeedRepository) Get(userID domain.UserIdentifier) (*domain.CalendarFeed, apperr.AppErr) {
+	var feed *model.CalendarFeed
+	if err := r.db.Where("user_id", userID).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalCalendarFeed(feed), nil
+}
+
+func (r *CalendarFeedRepository) GetByToken(token string) (*domain.CalendarFeed, apperr.AppErr) {
+	var feed *model.CalendarFeed
+	if err := r.db.Where("token", token).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalCalendarFeed(feed), nil
+}
+
+func (r *CalendarFeedRepository) Save(feed *domain.CalendarFeed) apperr.AppErr {
+	if err := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(model.UnmarshalCalendarFeed(feed)).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db}
+}
+
+func (r *CommentRepository) Get(id domain.CommentIdentifier) (*domain.Comment, apperr.AppErr) {
+	var row *model.Comment
+	if err := r.db.
+		Preload("Author.Company").
+		Preload("Mentions.Company").
+		First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalComment(row)
+}
+
+func (r *CommentRepository) ListByTaskID(taskID domain.TaskIdentifier, page domain.Page) ([]*domain.Comment, int, apperr.AppErr) {
+	query := r.db.Model(&model.Comment{}).
+		Where("task_id", taskID).
+		Where("delete_at IS NULL").
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	var rows []*model.Comment
+	if err := query.
+		Preload("Author.Company").
+		Preload("Mentions.Company").
+		Order("id").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Find(&rows).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	var comments []*domain.Comment
+	for _, row := range rows {
+		comment, aerr := model.MarshalComment(row)
+		if aerr != nil {
+			return nil, 0, aerr
+		}
+		comments = append(comments, comment)
+	}
+	return comments, int(total), nil
+}
+
+func (r *CommentRepository) Create(comment *domain.Comment) (*domain.CommentIdentifier, apperr.AppErr) {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createComment(tx, comment)
+	}); err != nil {
+		return nil, apperr.FromError(err)
+	}
+	comment.Events = nil
+	id := comment.ID
+	return &id, nil
+}
+
+func (r *CommentRepository) Update(comment *domain.Comment) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Comment{}).
+			Where("id", comment.ID).
+			Updates(map[string]interface{}{
+				"comment_body": comment.Body,
+				"edit_at":      comment.EditAt,
+				"delete_at":    comment.DeleteAt,
+			}).Error; err != nil {
+			return err
+		}
+		if err := saveCommentMentions(tx, comment); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, comment.Events, uint64(comment.ID))
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	comment.Events = nil
+	return nil
+}
+
+// createComment / コメントとメンションを保存し、出来事を記録する
+func createComment(tx *gorm.DB, comment *domain.Comment) error {
+	row := model.UnmarshalComment(comment)
+	if err := tx.Create(&row).Error; err != nil {
+		return err
+	}
+	comment.ID = domain.CommentIdentifier(row.ID)
+	if err := saveCommentMentions(tx, comment); err != nil {
+		return err
+	}
+	return saveDomainEvents(tx, comment.Events, row.ID)
+}
+
+// saveCommentMentions / コメントのメンションを置き換える
+func saveCommentMentions(tx *gorm.DB, comment *domain.Comment) error {
+	if err := tx.Where("comment_id", comment.ID).Delete(&model.CommentMention{}).Error; err != nil {
+		return err
+	}
+	rows := model.UnmarshalCommentMentions(comment)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
@@ -31,17 +31,57 @@ func (r *CompanyRepository) Get(id domain.CompanyIdentifier) (*domain.Company, a
 
 func (r *CompanyRepository) Create(company *domain.Company) (*domain.CompanyIdentifier, apperr.AppErr) {
 	row := model.UnmarshalCompany(company)
-	if err := r.db.Create(&row).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		if err := createCompanyDefaults(tx, domain.CompanyIdentifier(row.ID)); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, company.Events, row.ID)
+	}); err != nil {
 		return nil, apperr.NewInternalServerError().Wrap(err)
 	}
+	company.Events = nil
 	id := domain.CompanyIdentifier(row.ID)
 	return &id, nil
 }
 
+// createCompanyDefaults / 企業と同じトランザクションで既定のステータスと遷移、優先度、設定を登録する
+func createCompanyDefaults(tx *gorm.DB, companyID domain.CompanyIdentifier) error {
+	statuses := domain.NewDefaultTaskStatuses(companyID)
+	for _, status := range statuses {
+		row := model.UnmarshalTaskStatus(status)
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		status.ID = domain.TaskStatusIdentifier(row.ID)
+	}
+	for _, transition := range domain.NewDefaultTaskStatusTransitions(statuses) {
+		if err := tx.Create(model.UnmarshalTaskStatusTransition(transition)).Error; err != nil {
+			return err
+		}
+	}
+	for _, priority := range domain.NewDefaultTaskPriorities(companyID) {
+		if err := tx.Create(model.UnmarshalTaskPriority(priority)).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Create(model.UnmarshalCompanySetting(domain.NewDefaultCompanySetting(companyID))).Error
+}
+
 func (r *CompanyRepository) Update(company *domain.Company) apperr.AppErr {
 	row := model.UnmarshalCompany(company)
-	if err := r.db.Save(&row).Error; err != nil {
-		return apperr.NewInternalServerError().Wrap(err)
+	row.Version++
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, row, company.Version); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, company.Events, uint64(company.ID))
+	}); err != nil {
+		return apperr.FromError(err)
 	}
+	company.Events = nil
+	company.Version++
 	return nil
 }
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type CompanySettingRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanySettingRepository(db *gorm.DB) *CompanySettingRepository {
+	return &CompanySettingRepository{db}
+}
+
+func (r *CompanySettingRepository) Get(companyID domain.CompanyIdentifier) (*domain.CompanySetting, apperr.AppErr) {
+	var row *model.CompanySetting
+	if err := r.db.Where("company_id", companyID).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewDefaultCompanySetting(companyID), nil
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalCompanySetting(row), nil
+}
+
+func (r *CompanySettingRepository) Save(setting *domain.CompanySetting) apperr.AppErr {
+	row := model.UnmarshalCompanySetting(setting)
+	if err := r.db.Save(&row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
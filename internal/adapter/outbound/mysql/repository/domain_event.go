@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// maxDomainEventErrorLength / 記録する配信の失敗の理由の長さの上限
+const maxDomainEventErrorLength = 1000
+
+type DomainEventRepository struct {
+	db *gorm.DB
+}
+
+func NewDomainEventRepository(db *gorm.DB) *DomainEventRepository {
+	return &DomainEventRepository{db}
+}
+
+func (r *DomainEventRepository) ListPending(now time.Time, limit int) ([]*domain.DomainEvent, apperr.AppErr) {
+	// 再送を待つ集約の出来事で上限の件数が埋まり、他の集約の出来事が配信されなくなることを防ぐ
+	waiting := r.db.Table("domain_event AS waiting").
+		Select("1").
+		Where("waiting.event_status = ?", domain.DomainEventStatusPending.String()).
+		Where("waiting.aggregate_type = domain_event.aggregate_type").
+		Where("waiting.aggregate_id = domain_event.aggregate_id").
+		Where("waiting.id <= domain_event.id").
+		Where("waiting.next_attempt_at > ?", now)
+	var rows []*model.DomainEvent
+	if err := r.db.
+		Where("event_status", domain.DomainEventStatusPending.String()).
+		Where("NOT EXISTS (?)", waiting).
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var events []*domain.DomainEvent
+	for _, row := range rows {
+		event, aerr := model.MarshalDomainEvent(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (r *DomainEventRepository) Update(event *domain.DomainEvent) apperr.AppErr {
+	row, aerr := model.UnmarshalDomainEvent(event)
+	if aerr != nil {
+		return aerr
+	}
+	if row.LastError != nil && utf8.RuneCountInString(*row.LastError) > maxDomainEventErrorLength {
+		lastError := string([]rune(*row.LastError)[:maxDomainEventErrorLength])
+		row.LastError = &lastError
+	}
+	if err := r.db.Model(row).
+		Select("event_status", "attempts", "next_attempt_at", "delivered_to", "last_error", "dispatched_at").
+		Updates(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+// saveDomainEvents / 集約の保存と同じトランザクションで出来事をアウトボックスに記録する。
+// 作成の出来事には保存した集約のIDを設定する。
+func saveDomainEvents(tx *gorm.DB, events []*domain.DomainEvent, aggregateID uint64) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var rows []*model.DomainEvent
+	for _, event := range events {
+		if event.AggregateID == 0 {
+			event.AggregateID = aggregateID
+		}
+		if event.AggregateType == domain.AggregateTypeCompany && event.CompanyID == 0 {
+			event.CompanyID = domain.CompanyIdentifier(aggregateID)
+		}
+		row, aerr := model.UnmarshalDomainEvent(event)
+		if aerr != nil {
+			return aerr
+		}
+		rows = append(rows, row)
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return err
+	}
+	for i, row := range rows {
+		events[i].ID = domain.DomainEventIdentifier(row.ID)
+	}
+	return nil
+}
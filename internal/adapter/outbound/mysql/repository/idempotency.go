@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db}
+}
+
+func (r *IdempotencyRepository) Get(userID domain.UserIdentifier, key string) (*domain.IdempotencyRecord, apperr.AppErr) {
+	var row *model.IdempotencyRecord
+	if err := r.db.
+		Where("user_id", userID).
+		Where("idempotency_key", key).
+		Where("expire_at > ?", time.Now()).
+		First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalIdempotencyRecord(row), nil
+}
+
+func (r *IdempotencyRepository) Reserve(record *domain.IdempotencyRecord) (bool, apperr.AppErr) {
+	row := model.UnmarshalIdempotencyRecord(record)
+	var reserved bool
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 期限切れの記録は再利用できるように先に削除する
+		if err := tx.
+			Where("user_id", record.UserID).
+			Where("idempotency_key", record.Key).
+			Where("expire_at <= ?", time.Now()).
+			Delete(&model.IdempotencyRecord{}).Error; err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+		if result.Error != nil {
+			return result.Error
+		}
+		reserved = result.RowsAffected > 0
+		return nil
+	}); err != nil {
+		return false, apperr.NewInternalServerError().Wrap(err)
+	}
+	return reserved, nil
+}
+
+func (r *IdempotencyRepository) Complete(record *domain.IdempotencyRecord) apperr.AppErr {
+	if err := r.db.Model(&model.IdempotencyRecord{}).
+		Where("user_id", record.UserID).
+		Where("idempotency_key", record.Key).
+		Updates(map[string]interface{}{
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.Body,
+		}).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type LabelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *LabelRepository {
+	return &LabelRepository{db}
+}
+
+func (r *LabelRepository) Get(id domain.LabelIdentifier) (*domain.Label, apperr.AppErr) {
+	var row *model.Label
+	if err := r.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalLabel(row), nil
+}
+
+func (r *LabelRepository) ListByCompanyID(companyID domain.CompanyIdentifier) ([]*domain.Label, apperr.AppErr) {
+	var rows []*model.Label
+	if err := r.db.Where("company_id", companyID).
+		Order("label_name").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var labels []*domain.Label
+	for _, row := range rows {
+		labels = append(labels, model.MarshalLabel(row))
+	}
+	return labels, nil
+}
+
+func (r *LabelRepository) ListByIDs(ids []domain.LabelIdentifier) ([]*domain.Label, apperr.AppErr) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []*model.Label
+	if err := r.db.Where("id", ids).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var labels []*domain.Label
+	for _, row := range rows {
+		labels = append(labels, model.MarshalLabel(row))
+	}
+	return labels, nil
+}
+
+func (r *LabelRepository) Create(label *domain.Label) (*domain.LabelIdentifier, apperr.AppErr) {
+	row := model.UnmarshalLabel(label)
+	if err := r.db.Create(&row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.LabelIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *LabelRepository) Update(label *domain.Label) apperr.AppErr {
+	row := model.UnmarshalLabel(label)
+	if err := r.db.Save(&row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *LabelRepository) Delete(id domain.LabelIdentifier, tasks []*domain.Task) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// ラベルを外したタスクを版数、通し番号、履歴、出来事とともに保存する
+		for _, task := range tasks {
+			if err := saveTask(tx, task); err != nil {
+				return err
+			}
+		}
+		// 読み込んだ後に付与されたタスクは変更を記録できないため競合とする
+		var count int64
+		if err := tx.Model(&model.Task{}).
+			Where("id IN (?)", tx.Model(&model.TaskLabel{}).Select("task_id").Where("label_id", id)).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperr.NewConflictError()
+		}
+		// 削除済みのタスクからは記録せずに外す
+		if err := tx.Where("label_id", id).Delete(&model.TaskLabel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Label{}, id).Error
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	for _, task := range tasks {
+		task.Changes = nil
+		task.Events = nil
+		task.Version++
+	}
+	return nil
+}
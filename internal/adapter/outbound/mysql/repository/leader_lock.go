@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+// LeaderLock / MySQL の GET_LOCK による名前付きのロック。
+// ロックは接続に紐づくため、保持している間は専用の接続を確保し続ける。接続が切れた場合は次の TryAcquire で取り直す。
+type LeaderLock struct {
+	db   *gorm.DB
+	name string
+	conn *sql.Conn
+}
+
+func NewLeaderLock(db *gorm.DB, name string) *LeaderLock {
+	return &LeaderLock{db: db, name: name}
+}
+
+func (l *LeaderLock) TryAcquire() (bool, apperr.AppErr) {
+	ctx := context.Background()
+	if l.conn != nil {
+		var held sql.NullBool
+		if err := l.conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", l.name).Scan(&held); err == nil && held.Valid && held.Bool {
+			return true, nil
+		}
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return false, apperr.NewInternalServerError().Wrap(err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, apperr.NewInternalServerError().Wrap(err)
+	}
+	// 待たずに取得を試みる。1 は取得、0 は他の接続が保持している。
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", l.name).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, apperr.NewInternalServerError().Wrap(err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *LeaderLock) Release() apperr.AppErr {
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+	if _, err := l.conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", l.name); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
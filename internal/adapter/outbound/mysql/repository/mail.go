@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// maxMailErrorLength / 記録する送信の失敗の理由の長さの上限
+const maxMailErrorLength = 1000
+
+type MailRepository struct {
+	db *gorm.DB
+}
+
+func NewMailRepository(db *gorm.DB) *MailRepository {
+	return &MailRepository{db}
+}
+
+func (r *MailRepository) Create(messages ...*domain.MailMessage) apperr.AppErr {
+	if len(messages) == 0 {
+		return nil
+	}
+	var rows []*model.MailMessage
+	for _, message := range messages {
+		rows = append(rows, model.UnmarshalMailMessage(message))
+	}
+	if err := r.db.Create(&rows).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	for i, row := range rows {
+		messages[i].ID = domain.MailMessageIdentifier(row.ID)
+	}
+	return nil
+}
+
+func (r *MailRepository) ListDue(now time.Time, limit int) ([]*domain.MailMessage, apperr.AppErr) {
+	var rows []*model.MailMessage
+	if err := r.db.
+		Where("mail_status", domain.MailStatusPending.String()).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var messages []*domain.MailMessage
+	for _, row := range rows {
+		message, aerr := model.MarshalMailMessage(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (r *MailRepository) Update(message *domain.MailMessage) apperr.AppErr {
+	row := model.UnmarshalMailMessage(message)
+	if row.LastError != nil && utf8.RuneCountInString(*row.LastError) > maxMailErrorLength {
+		lastError := string([]rune(*row.LastError)[:maxMailErrorLength])
+		row.LastError = &lastError
+	}
+	if err := r.db.Model(row).
+		Select("mail_status", "attempts", "next_attempt_at", "last_error", "sent_at").
+		Updates(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
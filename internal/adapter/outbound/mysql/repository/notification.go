@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db}
+}
+
+func (r *NotificationRepository) ListByUserID(userID domain.UserIdentifier, page domain.Page) ([]*domain.Notification, int, apperr.AppErr) {
+	query := r.db.Model(&model.Notification{}).
+		Where("user_id", userID).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	var rows []*model.Notification
+	if err := query.
+		Preload("Actor.Company").
+		Order("read_at IS NOT NULL").
+		Order("id DESC").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Find(&rows).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	var notifications []*domain.Notification
+	for _, row := range rows {
+		notification, aerr := model.MarshalNotification(row)
+		if aerr != nil {
+			return nil, 0, aerr
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, int(total), nil
+}
+
+func (r *NotificationRepository) ListSince(userID domain.UserIdentifier, since time.Time) ([]*domain.Notification, apperr.AppErr) {
+	var rows []*model.Notification
+	if err := r.db.
+		Preload("Actor.Company").
+		Where("user_id", userID).
+		Where("create_at > ?", since).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var notifications []*domain.Notification
+	for _, row := range rows {
+		notification, aerr := model.MarshalNotification(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountUnread(userID domain.UserIdentifier) (int, apperr.AppErr) {
+	var count int64
+	if err := r.db.Model(&model.Notification{}).
+		Where("user_id", userID).
+		Where("read_at IS NULL").
+		Count(&count).Error; err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	return int(count), nil
+}
+
+func (r *NotificationRepository) Get(id domain.NotificationIdentifier) (*domain.Notification, apperr.AppErr) {
+	var row *model.Notification
+	if err := r.db.
+		Preload("Actor.Company").
+		First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalNotification(row)
+}
+
+func (r *NotificationRepository) Create(notifications []*domain.Notification, messages []*domain.MailMessage) apperr.AppErr {
+	if len(notifications) == 0 && len(messages) == 0 {
+		return nil
+	}
+	var rows []*model.Notification
+	for _, notification := range notifications {
+		rows = append(rows, model.UnmarshalNotification(notification))
+	}
+	var messageRows []*model.MailMessage
+	for _, message := range messages {
+		messageRows = append(messageRows, model.UnmarshalMailMessage(message))
+	}
+	// 一部の行が重複して保存されない場合に連番のIDが行と対応しないため、保存したIDは設定しない
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		if len(messageRows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&messageRows).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) Update(notification *domain.Notification) apperr.AppErr {
+	if err := r.db.Model(&model.Notification{}).
+		Where("id", notification.ID).
+		Update("read_at", notification.ReadAt).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) ReadAll(userID domain.UserIdentifier, now time.Time) apperr.AppErr {
+	if err := r.db.Model(&model.Notification{}).
+		Where("user_id", userID).
+		Where("read_at IS NULL").
+		Update("read_at", now).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db}
+}
+
+func (r *NotificationPreferenceRepository) Get(userID domain.UserIdentifier) (*domain.NotificationPreference, apperr.AppErr) {
+	var emails []*model.NotificationEmail
+	if err := r.db.Where("user_id", userID).Find(&emails).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var email *model.NotificationEmail
+	if len(emails) > 0 {
+		email = emails[0]
+	}
+	return r.marshal(userID, email)
+}
+
+func (r *NotificationPreferenceRepository) GetByUnsubscribeToken(token string) (*domain.NotificationPreference, apperr.AppErr) {
+	var email *model.NotificationEmail
+	if err := r.db.Where("unsubscribe_token", token).First(&email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.marshal(domain.UserIdentifier(email.UserID), email)
+}
+
+func (r *NotificationPreferenceRepository) ListDigest() ([]*domain.NotificationPreference, apperr.AppErr) {
+	var emails []*model.NotificationEmail
+	if err := r.db.
+		Where("email_mode", domain.EmailModeDigest.String()).
+		Where("email_address IS NOT NULL").
+		Order("user_id").
+		Find(&emails).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var preferences []*domain.NotificationPreference
+	for _, email := range emails {
+		preference, aerr := r.marshal(domain.UserIdentifier(email.UserID), email)
+		if aerr != nil {
+			return nil, aerr
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// marshal / 種類ごとの設定を読み込んでメールの設定と合わせる
+func (r *NotificationPreferenceRepository) marshal(userID domain.UserIdentifier, email *model.NotificationEmail) (*domain.NotificationPreference, apperr.AppErr) {
+	var rows []*model.NotificationPreference
+	if err := r.db.Where("user_id", userID).Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalNotificationPreference(userID, rows, email)
+}
+
+func (r *NotificationPreferenceRepository) Save(preference *domain.NotificationPreference) apperr.AppErr {
+	rows, email := model.UnmarshalNotificationPreference(preference)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&email).Error
+	}); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"errors"
+	"time"
 	"todo_api/internal/adapter/outbound/mysql/model"
 	domain "todo_api/internal/domain/model"
 	"todo_api/internal/lib/apperr"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository struct {
@@ -18,9 +20,24 @@ func NewTaskRepository(db *gorm.DB) *TaskRepository {
 }
 
 func (r *TaskRepository) Get(id domain.TaskIdentifier) (*domain.Task, apperr.AppErr) {
+	return r.get(r.db, id)
+}
+
+func (r *TaskRepository) GetIncludingDeleted(id domain.TaskIdentifier) (*domain.Task, apperr.AppErr) {
+	return r.get(r.db.Unscoped(), id)
+}
+
+func (r *TaskRepository) get(db *gorm.DB, id domain.TaskIdentifier) (*domain.Task, apperr.AppErr) {
 	var row *model.Task
-	if err := r.db.
+	if err := db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
 		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
 		Preload("Creator.Company").
 		Preload("Updator.Company").
 		First(&row, id).Error; err != nil {
@@ -33,25 +50,28 @@ func (r *TaskRepository) Get(id domain.TaskIdentifier) (*domain.Task, apperr.App
 	if aerr != nil {
 		return nil, aerr
 	}
+	if aerr := r.summarize(task); aerr != nil {
+		return nil, aerr
+	}
 	return task, nil
 }
 
 func (r *TaskRepository) Find(userID domain.UserIdentifier, id domain.TaskIdentifier) (*domain.Task, apperr.AppErr) {
-	var rowUser *model.User
-	if err := r.db.Preload("Company").First(&rowUser, userID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperr.NewNotFoundError().Wrap(err)
-		}
-		return nil, apperr.NewInternalServerError().Wrap(err)
-	}
-	user, aerr := model.MarshalUser(rowUser)
+	user, aerr := r.getViewer(userID)
 	if aerr != nil {
 		return nil, aerr
 	}
 
 	var rowTask *model.Task
 	if err := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
 		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
 		Preload("Creator.Company").
 		Preload("Updator.Company").
 		First(&rowTask, id).Error; err != nil {
@@ -64,32 +84,40 @@ func (r *TaskRepository) Find(userID domain.UserIdentifier, id domain.TaskIdenti
 	if aerr != nil {
 		return nil, aerr
 	}
+	if aerr := r.summarize(task); aerr != nil {
+		return nil, aerr
+	}
 
 	// 閲覧可能かどうかのチェック
-	if user.Company.ID != domain.AdminCompanyID {
-		// 自社のタスクでない場合は閲覧不可
-		if task.Creator.Company.ID != user.Company.ID {
-			return nil, apperr.NewForbiddenError()
-		}
-		// 作成者が自身でなく、会社に公開でない場合は閲覧不可
-		if task.Creator.ID != user.ID && task.Visibility == domain.TaskVisibilityMe {
-			return nil, apperr.NewForbiddenError()
-		}
+	if !task.IsVisibleTo(user) {
+		return nil, apperr.NewForbiddenError()
 	}
 
 	return task, nil
 }
 
-func (r *TaskRepository) ListByAssignedUserID(userID, assignedUserID domain.UserIdentifier) ([]*domain.Task, apperr.AppErr) {
+func (r *TaskRepository) ListByAssignedUserID(userID, assignedUserID domain.UserIdentifier, filter domain.TaskFilter) ([]*domain.Task, apperr.AppErr) {
+	viewer, aerr := r.getViewer(userID)
+	if aerr != nil {
+		return nil, aerr
+	}
 	var rows []*model.Task
 	query := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
 		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
 		Preload("Creator.Company").
 		Preload("Updator.Company")
-	if userID != assignedUserID {
-		query = query.Where("visibility", "COMPANY")
-	}
-	if err := query.Where("person_in_charge_id", assignedUserID).
+	query = r.whereVisibleTo(query, viewer)
+	query = applyTaskFilter(query, filter)
+	// 主担当者に限らず、いずれかの担当者であるタスクを取得する
+	assigned := r.db.Model(&model.TaskAssignee{}).Select("task_id").Where("user_id", assignedUserID)
+	if err := query.Where("task.id IN (?)", assigned).
 		Find(&rows).Error; err != nil {
 		return nil, apperr.NewInternalServerError().Wrap(err)
 	}
@@ -104,26 +132,86 @@ func (r *TaskRepository) ListByAssignedUserID(userID, assignedUserID domain.User
 		}
 		tasks = append(tasks, task)
 	}
+	if aerr := r.summarize(tasks...); aerr != nil {
+		return nil, aerr
+	}
 	return tasks, nil
 }
 
-func (r *TaskRepository) ListByCompanyID(userID domain.UserIdentifier, companyID domain.CompanyIdentifier) ([]*domain.Task, apperr.AppErr) {
+func (r *TaskRepository) ListByIDs(ids []domain.TaskIdentifier) ([]*domain.Task, apperr.AppErr) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []*model.Task
+	if err := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
+		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
+		Preload("Creator.Company").
+		Preload("Updator.Company").
+		Where("task.id", ids).
+		Order("task.id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var tasks []*domain.Task
+	for _, row := range rows {
+		task, aerr := model.MarshalTask(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		tasks = append(tasks, task)
+	}
+	if aerr := r.summarize(tasks...); aerr != nil {
+		return nil, aerr
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) ListByLabelID(labelID domain.LabelIdentifier) ([]*domain.Task, apperr.AppErr) {
+	var ids []domain.TaskIdentifier
+	if err := r.db.Model(&model.Task{}).
+		Where("id IN (?)", r.db.Model(&model.TaskLabel{}).Select("task_id").Where("label_id", labelID)).
+		Order("id").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.ListByIDs(ids)
+}
+
+func (r *TaskRepository) ListByCompanyID(userID domain.UserIdentifier, companyID domain.CompanyIdentifier, filter domain.TaskFilter) ([]*domain.Task, apperr.AppErr) {
+	viewer, aerr := r.getViewer(userID)
+	if aerr != nil {
+		return nil, aerr
+	}
 	var companyUserIDs []uint64
 	if err := r.db.Table("user").
 		Where("company_id", companyID).
-		Select("id").Take(&companyUserIDs).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperr.NewNotFoundError().Wrap(err)
-		}
+		Pluck("id", &companyUserIDs).Error; err != nil {
 		return nil, apperr.NewInternalServerError().Wrap(err)
 	}
+	if len(companyUserIDs) == 0 {
+		return nil, apperr.NewNotFoundError()
+	}
 	var rows []*model.Task
-	if err := r.db.
+	query := applyTaskFilter(r.whereVisibleTo(r.db, viewer), filter)
+	if err := query.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
 		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
 		Preload("Creator.Company").
 		Preload("Updator.Company").
-		Where("person_in_charge_id = ? or visibility = ?", userID, "COMPANY").
-		Where("creator_id", companyUserIDs).Find(&rows).Error; err != nil {
+		Where("task.creator_id", companyUserIDs).Find(&rows).Error; err != nil {
 		return nil, apperr.NewInternalServerError().Wrap(err)
 	}
 	if len(rows) == 0 {
@@ -137,31 +225,607 @@ func (r *TaskRepository) ListByCompanyID(userID domain.UserIdentifier, companyID
 		}
 		tasks = append(tasks, task)
 	}
+	if aerr := r.summarize(tasks...); aerr != nil {
+		return nil, aerr
+	}
 	return tasks, nil
 }
 
+// applyTaskFilter / タスク一覧の絞り込み条件と並び順をクエリに適用する
+func applyTaskFilter(query *gorm.DB, filter domain.TaskFilter) *gorm.DB {
+	if len(filter.StatusIDs) > 0 {
+		query = query.Where("task.task_status_id", filter.StatusIDs)
+	}
+	if len(filter.PriorityIDs) > 0 {
+		query = query.Where("task.task_priority_id", filter.PriorityIDs)
+	}
+	if filter.StartDateFrom != nil {
+		query = query.Where("task.start_date >= ?", *filter.StartDateFrom)
+	}
+	if filter.StartDateTo != nil {
+		query = query.Where("task.start_date <= ?", *filter.StartDateTo)
+	}
+	if filter.LimitDateFrom != nil {
+		query = query.Where("task.limit_date >= ?", *filter.LimitDateFrom)
+	}
+	if filter.LimitDateTo != nil {
+		query = query.Where("task.limit_date <= ?", *filter.LimitDateTo)
+	}
+	if filter.ParentID != nil {
+		query = query.Where("task.parent_id", *filter.ParentID)
+	}
+	if len(filter.LabelIDs) > 0 {
+		labeled := query.Session(&gorm.Session{NewDB: true}).
+			Model(&model.TaskLabel{}).
+			Select("task_id").
+			Where("label_id", filter.LabelIDs)
+		if filter.LabelMatch == domain.LabelMatchAll {
+			labeled = labeled.Group("task_id").
+				Having("COUNT(DISTINCT label_id) = ?", len(filter.LabelIDs))
+		}
+		query = query.Where("task.id IN (?)", labeled)
+	}
+
+	for _, sort := range filter.Sorts {
+		var column string
+		switch sort.Key {
+		case domain.TaskSortKeyCreateAt:
+			column = "task.create_at"
+		case domain.TaskSortKeyUpdateAt:
+			column = "task.update_at"
+		case domain.TaskSortKeyStartDate:
+			column = "task.start_date"
+		case domain.TaskSortKeyLimitDate:
+			column = "task.limit_date"
+		case domain.TaskSortKeyPriority:
+			query = query.Select("task.*").
+				Joins("LEFT JOIN task_priority ON task_priority.id = task.task_priority_id")
+			column = "task_priority.priority_level"
+		default:
+			continue
+		}
+		// 未設定の値は並び順に関わらず末尾とする
+		query = query.Order(column + " IS NULL")
+		if sort.Desc {
+			query = query.Order(column + " DESC")
+		} else {
+			query = query.Order(column)
+		}
+	}
+	return query.Order("task.id")
+}
+
 func (r *TaskRepository) Create(task *domain.Task) (*domain.TaskIdentifier, apperr.AppErr) {
-	row := model.UnmarshalTask(task)
-	if err := r.db.Create(&row).Error; err != nil {
-		return nil, apperr.NewInternalServerError().Wrap(err)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createTask(tx, task)
+	}); err != nil {
+		return nil, apperr.FromError(err)
 	}
-	id := domain.TaskIdentifier(row.ID)
+	task.Changes = nil
+	task.Events = nil
+	task.ExternalRef = nil
+	id := task.ID
 	return &id, nil
 }
 
+func (r *TaskRepository) CreateOccurrence(task *domain.Task) (bool, apperr.AppErr) {
+	created := false
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 系列の行をロックし、同じ回を同時に生成しないようにする
+		var series *model.TaskSeries
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, task.Series.ID).Error; err != nil {
+			return err
+		}
+		// 削除された回も生成済みとして扱う
+		var count int64
+		if err := tx.Unscoped().Model(&model.Task{}).
+			Where("task_series_id", task.Series.ID).
+			Where("occurrence", task.Occurrence).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		created = true
+		return createTask(tx, task)
+	}); err != nil {
+		return false, apperr.NewInternalServerError().Wrap(err)
+	}
+	if created {
+		task.Changes = nil
+		task.Events = nil
+	}
+	return created, nil
+}
+
+// createTask / タスクとラベル、チェックリスト、担当者、履歴を保存する
+func createTask(tx *gorm.DB, task *domain.Task) error {
+	row := model.UnmarshalTask(task)
+	if err := tx.Omit(clause.Associations).Create(&row).Error; err != nil {
+		return err
+	}
+	task.ID = domain.TaskIdentifier(row.ID)
+	if err := saveTaskLabels(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskChecklist(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskMembers(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskHistory(tx, task); err != nil {
+		return err
+	}
+	if err := saveDomainEvents(tx, task.Events, uint64(task.ID)); err != nil {
+		return err
+	}
+	if err := saveTaskExternalRef(tx, task); err != nil {
+		return err
+	}
+	return saveTaskChangeSeq(tx, task)
+}
+
 func (r *TaskRepository) Update(task *domain.Task) apperr.AppErr {
+	return r.UpdateAll(task)
+}
+
+func (r *TaskRepository) UpdateAll(tasks ...*domain.Task) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return saveTasks(tx, tasks)
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	markTasksSaved(tasks)
+	return nil
+}
+
+// saveTasks / 複数のタスクを saveTask で保存する
+func saveTasks(tx *gorm.DB, tasks []*domain.Task) error {
+	for _, task := range tasks {
+		if err := saveTask(tx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markTasksSaved / コミットしたタスクの保存済みの変更と出来事を消し、版数を進める
+func markTasksSaved(tasks []*domain.Task) {
+	for _, task := range tasks {
+		task.Changes = nil
+		task.Events = nil
+		task.Version++
+	}
+}
+
+// saveTask / 版数を検証してタスクとラベル、チェックリスト、履歴を保存する
+func saveTask(tx *gorm.DB, task *domain.Task) error {
 	row := model.UnmarshalTask(task)
-	if err := r.db.Save(&row).Error; err != nil {
-		return apperr.NewInternalServerError().Wrap(err)
+	row.Version++
+	if err := updateVersioned(tx, row, task.Version); err != nil {
+		return err
+	}
+	if err := saveTaskLabels(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskChecklist(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskMembers(tx, task); err != nil {
+		return err
+	}
+	if err := saveTaskHistory(tx, task); err != nil {
+		return err
+	}
+	if err := saveDomainEvents(tx, task.Events, uint64(task.ID)); err != nil {
+		return err
+	}
+	return saveTaskChangeSeq(tx, task)
+}
+
+// saveTaskLabels / タスクに付与されたラベルを置き換える
+func saveTaskLabels(tx *gorm.DB, task *domain.Task) error {
+	if err := tx.Where("task_id", task.ID).Delete(&model.TaskLabel{}).Error; err != nil {
+		return err
+	}
+	rows := model.UnmarshalTaskLabels(task)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// saveTaskChecklist / タスクのチェックリストを置き換える
+func saveTaskChecklist(tx *gorm.DB, task *domain.Task) error {
+	if err := tx.Where("task_id", task.ID).Delete(&model.TaskChecklistItem{}).Error; err != nil {
+		return err
+	}
+	rows := model.UnmarshalTaskChecklist(task)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// saveTaskMembers / タスクの担当者とウォッチャーを置き換える
+func saveTaskMembers(tx *gorm.DB, task *domain.Task) error {
+	if err := tx.Where("task_id", task.ID).Delete(&model.TaskAssignee{}).Error; err != nil {
+		return err
+	}
+	if rows := model.UnmarshalTaskAssignees(task); len(rows) > 0 {
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("task_id", task.ID).Delete(&model.TaskWatcher{}).Error; err != nil {
+		return err
+	}
+	if rows := model.UnmarshalTaskWatchers(task); len(rows) > 0 {
+		return tx.Create(&rows).Error
 	}
 	return nil
 }
 
-func (r *TaskRepository) UpdateStatus(id domain.TaskIdentifier, status domain.TaskStatus) apperr.AppErr {
+// saveTaskHistory / タスクの保存されていない変更を履歴に追記する
+func saveTaskHistory(tx *gorm.DB, task *domain.Task) error {
+	rows := model.UnmarshalTaskHistories(task)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// saveTaskExternalRef / 他のツールから取り込んだ課題との対応を記録する。同じ課題を重ねて取り込んだ場合は一意制約により失敗する。
+func saveTaskExternalRef(tx *gorm.DB, task *domain.Task) error {
+	if task.ExternalRef == nil {
+		return nil
+	}
+	row := model.UnmarshalTaskExternalRef(task.ExternalRef, task.ID)
+	return tx.Create(&row).Error
+}
+
+// saveTaskChangeSeq / 企業の通し番号を採番してタスクに設定する。
+// 採番で更新した企業の行のロックはトランザクションの終了まで保持されるため、同じ企業の変更は通し番号の順に確定する。
+func saveTaskChangeSeq(tx *gorm.DB, task *domain.Task) error {
+	if err := tx.Exec(
+		"INSERT INTO company_change_seq (company_id, seq) VALUES (?, LAST_INSERT_ID(1)) ON DUPLICATE KEY UPDATE seq = LAST_INSERT_ID(seq + 1)",
+		task.Creator.Company.ID,
+	).Error; err != nil {
+		return err
+	}
+	var seq uint64
+	if err := tx.Raw("SELECT LAST_INSERT_ID()").Scan(&seq).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&model.Task{}).
+		Where("id", task.ID).
+		UpdateColumn("change_seq", seq).Error; err != nil {
+		return err
+	}
+	task.ChangeSeq = seq
+	return nil
+}
+
+func orderChecklist(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order")
+}
+
+// summarize / タスクの子タスクの件数と未完了のブロッカーを集計する
+func (r *TaskRepository) summarize(tasks ...*domain.Task) apperr.AppErr {
+	if err := r.summarizeSubtasks(tasks...); err != nil {
+		return err
+	}
+	return r.summarizeBlockers(tasks...)
+}
+
+// summarizeSubtasks / タスクの子タスクの件数を集計する
+func (r *TaskRepository) summarizeSubtasks(tasks ...*domain.Task) apperr.AppErr {
+	if len(tasks) == 0 {
+		return nil
+	}
+	var ids []domain.TaskIdentifier
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	var counts []struct {
+		ParentID uint64
+		Total    int
+		Closed   int
+	}
 	if err := r.db.Model(&model.Task{}).
-		Where("id", id).Update("task_status", status.String()).
-		Error; err != nil {
+		Select("task.parent_id, COUNT(*) AS total, "+
+			"SUM(CASE WHEN task_status.status_category = ? THEN 1 ELSE 0 END) AS closed", "CLOSED").
+		Joins("JOIN task_status ON task_status.id = task.task_status_id").
+		Where("task.parent_id", ids).
+		Group("task.parent_id").
+		Scan(&counts).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	for _, task := range tasks {
+		for _, count := range counts {
+			if domain.TaskIdentifier(count.ParentID) == task.ID {
+				task.Subtasks = domain.SubtaskSummary{Total: count.Total, Closed: count.Closed}
+			}
+		}
+	}
+	return nil
+}
+
+// summarizeBlockers / タスクをブロックしている未完了のタスクを集計する
+func (r *TaskRepository) summarizeBlockers(tasks ...*domain.Task) apperr.AppErr {
+	if len(tasks) == 0 {
+		return nil
+	}
+	var ids []domain.TaskIdentifier
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	var blockers []struct {
+		SourceTaskID uint64
+		TargetTaskID uint64
+	}
+	if err := r.db.Model(&model.TaskLink{}).
+		Select("task_link.source_task_id, task_link.target_task_id").
+		Joins("JOIN task ON task.id = task_link.source_task_id").
+		Joins("JOIN task_status ON task_status.id = task.task_status_id").
+		Where("task_link.link_type", domain.TaskLinkTypeBlocks.String()).
+		Where("task_link.target_task_id", ids).
+		Where("task.delete_at IS NULL").
+		Where("task_status.status_category <> ?", "CLOSED").
+		Order("task_link.source_task_id").
+		Scan(&blockers).Error; err != nil {
 		return apperr.NewInternalServerError().Wrap(err)
 	}
+	for _, task := range tasks {
+		for _, blocker := range blockers {
+			if domain.TaskIdentifier(blocker.TargetTaskID) == task.ID {
+				task.OpenBlockerIDs = append(task.OpenBlockerIDs, domain.TaskIdentifier(blocker.SourceTaskID))
+			}
+		}
+	}
+	return nil
+}
+
+func (r *TaskRepository) ListAncestorIDs(id domain.TaskIdentifier) ([]domain.TaskIdentifier, apperr.AppErr) {
+	var ancestorIDs []domain.TaskIdentifier
+	seen := map[domain.TaskIdentifier]bool{id: true}
+	for {
+		var parentIDs []*uint64
+		if err := r.db.Model(&model.Task{}).
+			Where("id", id).
+			Pluck("parent_id", &parentIDs).Error; err != nil {
+			return nil, apperr.NewInternalServerError().Wrap(err)
+		}
+		if len(parentIDs) == 0 || parentIDs[0] == nil {
+			return ancestorIDs, nil
+		}
+		id = domain.TaskIdentifier(*parentIDs[0])
+		// 不正なデータで循環している場合に無限に辿らないようにする
+		if seen[id] {
+			return nil, apperr.NewInternalServerError().SetMessage("task hierarchy has a cycle")
+		}
+		seen[id] = true
+		ancestorIDs = append(ancestorIDs, id)
+	}
+}
+
+func (r *TaskRepository) GetDescendantDepth(id domain.TaskIdentifier) (int, apperr.AppErr) {
+	depth := 0
+	ids := []uint64{uint64(id)}
+	seen := map[uint64]bool{uint64(id): true}
+	for {
+		var childIDs []uint64
+		if err := r.db.Model(&model.Task{}).
+			Where("parent_id", ids).
+			Pluck("id", &childIDs).Error; err != nil {
+			return 0, apperr.NewInternalServerError().Wrap(err)
+		}
+		if len(childIDs) == 0 {
+			return depth, nil
+		}
+		for _, childID := range childIDs {
+			if seen[childID] {
+				return 0, apperr.NewInternalServerError().SetMessage("task hierarchy has a cycle")
+			}
+			seen[childID] = true
+		}
+		depth++
+		ids = childIDs
+	}
+}
+
+func (r *TaskRepository) ListBySeriesID(seriesID domain.TaskSeriesIdentifier) ([]*domain.Task, apperr.AppErr) {
+	var rows []*model.Task
+	if err := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
+		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
+		Preload("Creator.Company").
+		Preload("Updator.Company").
+		Where("task.task_series_id", seriesID).
+		Order("task.occurrence").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.marshalTasks(rows)
+}
+
+func (r *TaskRepository) ListDueOccurrences(now time.Time) ([]*domain.Task, apperr.AppErr) {
+	var rows []*model.Task
+	if err := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
+		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
+		Preload("Creator.Company").
+		Preload("Updator.Company").
+		Where("task.task_series_id IS NOT NULL").
+		Where("task.limit_date <= ?", now).
+		// 後の回が生成済み（削除済みを含む）の回は対象外
+		Where("NOT EXISTS (SELECT 1 FROM task AS later WHERE later.task_series_id = task.task_series_id AND later.occurrence > task.occurrence)").
+		Order("task.id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.marshalTasks(rows)
+}
+
+func (r *TaskRepository) ListOpenByLimitDate(from, to time.Time) ([]*domain.Task, apperr.AppErr) {
+	var rows []*model.Task
+	if err := r.db.
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
+		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
+		Preload("Creator.Company").
+		Preload("Updator.Company").
+		Joins("JOIN task_status ON task_status.id = task.task_status_id").
+		Where("task_status.status_category <> ?", "CLOSED").
+		Where("task.limit_date > ?", from).
+		Where("task.limit_date <= ?", to).
+		Order("task.limit_date").
+		Order("task.id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.marshalTasks(rows)
+}
+
+func (r *TaskRepository) GetChangeSeq(companyID domain.CompanyIdentifier) (uint64, apperr.AppErr) {
+	var seqs []uint64
+	if err := r.db.Table("company_change_seq").
+		Where("company_id", companyID).
+		Pluck("seq", &seqs).Error; err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	if len(seqs) == 0 {
+		return 0, nil
+	}
+	return seqs[0], nil
+}
+
+func (r *TaskRepository) FindByExternalRef(ref domain.TaskExternalRef) (*domain.TaskIdentifier, apperr.AppErr) {
+	var ids []uint64
+	if err := r.db.Model(&model.TaskExternalRef{}).
+		Where("company_id", ref.CompanyID).
+		Where("source", ref.Source.String()).
+		Where("external_id", ref.ExternalID).
+		Pluck("task_id", &ids).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	id := domain.TaskIdentifier(ids[0])
+	return &id, nil
+}
+
+func (r *TaskRepository) ListChanged(companyID domain.CompanyIdentifier, since, until uint64, limit int) ([]*domain.Task, apperr.AppErr) {
+	var rows []*model.Task
+	if err := r.db.
+		Unscoped().
+		Preload("Status").
+		Preload("Priority").
+		Preload("Labels").
+		Preload("Checklist", orderChecklist).
+		Preload("PersonInCharge.Company").
+		Preload("Assignees.Company").
+		Preload("Watchers.Company").
+		Preload("Series").
+		Preload("Creator.Company").
+		Preload("Updator.Company").
+		Where("task.creator_id IN (?)", r.db.Model(&model.User{}).Select("id").Where("company_id", companyID)).
+		Where("task.change_seq > ?", since).
+		Where("task.change_seq <= ?", until).
+		Order("task.change_seq").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return r.marshalTasks(rows)
+}
+
+// getViewer / タスクの閲覧の可否を判定するユーザを取得する
+func (r *TaskRepository) getViewer(userID domain.UserIdentifier) (*domain.User, apperr.AppErr) {
+	var row *model.User
+	if err := r.db.Preload("Company").First(&row, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalUser(row)
+}
+
+// whereVisibleTo / Task.IsVisibleTo と同じ条件で、ユーザが閲覧できるタスクに絞り込む
+func (r *TaskRepository) whereVisibleTo(query *gorm.DB, viewer *domain.User) *gorm.DB {
+	if viewer.Company.ID == domain.AdminCompanyID {
+		return query
+	}
+	return query.
+		Where("task.creator_id IN (?)", r.db.Model(&model.User{}).Select("id").Where("company_id", viewer.Company.ID)).
+		Where("task.visibility = ? OR task.creator_id = ?", domain.TaskVisibilityCompany.String(), viewer.ID)
+}
+
+// marshalTasks / 行をタスクに変換し、子タスクとブロッカーを集計する
+func (r *TaskRepository) marshalTasks(rows []*model.Task) ([]*domain.Task, apperr.AppErr) {
+	var tasks []*domain.Task
+	for _, row := range rows {
+		task, aerr := model.MarshalTask(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		tasks = append(tasks, task)
+	}
+	if aerr := r.summarize(tasks...); aerr != nil {
+		return nil, aerr
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) UpdateStatus(task *domain.Task) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).
+			Where("id", task.ID).
+			Where("version", task.Version).
+			Updates(map[string]interface{}{
+				"task_status_id": task.Status.ID,
+				"updator_id":     task.Updator.ID,
+				"version":        gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperr.NewPreconditionFailedError()
+		}
+		if err := saveTaskHistory(tx, task); err != nil {
+			return err
+		}
+		if err := saveDomainEvents(tx, task.Events, uint64(task.ID)); err != nil {
+			return err
+		}
+		return saveTaskChangeSeq(tx, task)
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	task.Changes = nil
+	task.Events = nil
+	task.Version++
 	return nil
 }
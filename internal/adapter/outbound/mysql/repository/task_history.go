@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type TaskHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskHistoryRepository(db *gorm.DB) *TaskHistoryRepository {
+	return &TaskHistoryRepository{db}
+}
+
+func (r *TaskHistoryRepository) ListByTaskID(taskID domain.TaskIdentifier, page domain.Page) ([]*domain.TaskHistory, int, apperr.AppErr) {
+	query := r.db.Model(&model.TaskHistory{}).
+		Where("task_id", taskID).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	var rows []*model.TaskHistory
+	if err := query.
+		Preload("Actor.Company").
+		Order("id").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Find(&rows).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	var histories []*domain.TaskHistory
+	for _, row := range rows {
+		history, aerr := model.MarshalTaskHistory(row)
+		if aerr != nil {
+			return nil, 0, aerr
+		}
+		histories = append(histories, history)
+	}
+	return histories, int(total), nil
+}
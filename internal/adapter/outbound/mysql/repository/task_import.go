@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// maxTaskImportErrorLength / 記録する取り込みの失敗の理由の長さの上限
+const maxTaskImportErrorLength = 1000
+
+type TaskImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskImportJobRepository(db *gorm.DB) *TaskImportJobRepository {
+	return &TaskImportJobRepository{db}
+}
+
+func (r *TaskImportJobRepository) Get(id domain.TaskImportJobIdentifier) (*domain.TaskImportJob, apperr.AppErr) {
+	var row *model.TaskImportJob
+	if err := r.db.Omit("job_rows").First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalTaskImportJob(row)
+}
+
+func (r *TaskImportJobRepository) GetNext() (*domain.TaskImportJob, apperr.AppErr) {
+	var rows []*model.TaskImportJob
+	if err := r.db.
+		Where("job_status", []string{domain.TaskImportStatusPending.String(), domain.TaskImportStatusRunning.String()}).
+		Order("id").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return model.MarshalTaskImportJob(rows[0])
+}
+
+func (r *TaskImportJobRepository) Create(job *domain.TaskImportJob) (*domain.TaskImportJobIdentifier, apperr.AppErr) {
+	row, aerr := model.UnmarshalTaskImportJob(job)
+	if aerr != nil {
+		return nil, aerr
+	}
+	truncateTaskImportError(row)
+	if err := r.db.Create(row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.TaskImportJobIdentifier(row.ID)
+	job.ID = id
+	return &id, nil
+}
+
+func (r *TaskImportJobRepository) UpdateProgress(job *domain.TaskImportJob) apperr.AppErr {
+	if err := saveTaskImportProgress(r.db, job); err != nil {
+		return apperr.FromError(err)
+	}
+	return nil
+}
+
+func (r *TaskImportJobRepository) CreateTask(job *domain.TaskImportJob, task *domain.Task, comments []*domain.Comment) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createTask(tx, task); err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			comment.TaskID = task.ID
+			if err := createComment(tx, comment); err != nil {
+				return err
+			}
+		}
+		// 件数が少なくその場で処理する取り込みは、終えてから保存する
+		if job.ID == 0 {
+			return nil
+		}
+		return saveTaskImportProgress(tx, job)
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	task.Changes = nil
+	task.Events = nil
+	task.ExternalRef = nil
+	for _, comment := range comments {
+		comment.Events = nil
+	}
+	return nil
+}
+
+// saveTaskImportProgress / 取り込みの状態と進捗、行の誤りのみを更新する
+func saveTaskImportProgress(tx *gorm.DB, job *domain.TaskImportJob) error {
+	errs, aerr := model.UnmarshalTaskImportErrors(job)
+	if aerr != nil {
+		return aerr
+	}
+	unmatchedUsers, aerr := model.UnmarshalTaskImportUnmatchedUsers(job)
+	if aerr != nil {
+		return aerr
+	}
+	row := &model.TaskImportJob{
+		ID:         uint64(job.ID),
+		JobStatus:  job.Status.String(),
+		Processed:  job.Processed,
+		Succeeded:  job.Succeeded,
+		Failed:     job.Failed,
+		Skipped:    job.Skipped,
+		Errors:     errs,
+		LastError:  job.LastError,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+
+		UnmatchedUsers: unmatchedUsers,
+	}
+	truncateTaskImportError(row)
+	return tx.Model(row).
+		Select("job_status", "processed", "succeeded", "failed", "skipped", "errors", "unmatched_users", "last_error", "started_at", "finished_at").
+		Updates(row).Error
+}
+
+func truncateTaskImportError(row *model.TaskImportJob) {
+	if row.LastError != nil && utf8.RuneCountInString(*row.LastError) > maxTaskImportErrorLength {
+		lastError := string([]rune(*row.LastError)[:maxTaskImportErrorLength])
+		row.LastError = &lastError
+	}
+}
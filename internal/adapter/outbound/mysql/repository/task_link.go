@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskLinkRepository(db *gorm.DB) *TaskLinkRepository {
+	return &TaskLinkRepository{db}
+}
+
+func (r *TaskLinkRepository) Get(id domain.TaskLinkIdentifier) (*domain.TaskLink, apperr.AppErr) {
+	var row *model.TaskLink
+	if err := r.db.Preload("Creator.Company").First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalTaskLink(row)
+}
+
+func (r *TaskLinkRepository) ListByCompanyID(companyID domain.CompanyIdentifier) (domain.TaskLinks, apperr.AppErr) {
+	return listTaskLinks(r.db, companyID)
+}
+
+func (r *TaskLinkRepository) Create(link *domain.TaskLink) (*domain.TaskLinkIdentifier, apperr.AppErr) {
+	row := model.UnmarshalTaskLink(link)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 企業の行をロックして関連の作成を直列にし、同時に作成された関連で循環しないように検証し直す
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Company{}, link.CompanyID).Error; err != nil {
+			return err
+		}
+		links, aerr := listTaskLinks(tx, link.CompanyID)
+		if aerr != nil {
+			return aerr
+		}
+		if aerr = link.Validate(links); aerr != nil {
+			return aerr
+		}
+		return tx.Create(&row).Error
+	}); err != nil {
+		return nil, apperr.FromError(err)
+	}
+	id := domain.TaskLinkIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *TaskLinkRepository) Delete(id domain.TaskLinkIdentifier) apperr.AppErr {
+	if err := r.db.Delete(&model.TaskLink{}, id).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func listTaskLinks(db *gorm.DB, companyID domain.CompanyIdentifier) (domain.TaskLinks, apperr.AppErr) {
+	var rows []*model.TaskLink
+	if err := db.Preload("Creator.Company").
+		Where("company_id", companyID).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var links domain.TaskLinks
+	for _, row := range rows {
+		link, aerr := model.MarshalTaskLink(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
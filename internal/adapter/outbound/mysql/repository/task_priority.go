@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type TaskPriorityRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskPriorityRepository(db *gorm.DB) *TaskPriorityRepository {
+	return &TaskPriorityRepository{db}
+}
+
+func (r *TaskPriorityRepository) ListByCompanyID(companyID domain.CompanyIdentifier) (domain.TaskPriorities, apperr.AppErr) {
+	var rows []*model.TaskPriority
+	if err := r.db.Where("company_id", companyID).
+		Order("priority_level").Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var priorities domain.TaskPriorities
+	for _, row := range rows {
+		priorities = append(priorities, model.MarshalTaskPriority(row))
+	}
+	return priorities, nil
+}
+
+func (r *TaskPriorityRepository) Create(priority *domain.TaskPriority) (*domain.TaskPriorityIdentifier, apperr.AppErr) {
+	row := model.UnmarshalTaskPriority(priority)
+	if err := r.db.Create(&row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.TaskPriorityIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *TaskPriorityRepository) Update(priority *domain.TaskPriority) apperr.AppErr {
+	row := model.UnmarshalTaskPriority(priority)
+	if err := r.db.Save(&row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *TaskPriorityRepository) Delete(id domain.TaskPriorityIdentifier) apperr.AppErr {
+	return apperr.FromError(r.db.Transaction(func(tx *gorm.DB) error {
+		// 削除済みのタスクも外部キーで参照しているため数える
+		var count int64
+		if err := tx.Unscoped().Model(&model.Task{}).
+			Where("task_priority_id", id).
+			Count(&count).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		if count > 0 {
+			return apperr.NewBadRequestError().SetMessage("task priority is in use")
+		}
+		if err := tx.Delete(&model.TaskPriority{}, id).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		return nil
+	}))
+}
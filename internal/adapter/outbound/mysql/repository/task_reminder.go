@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskReminderRepository(db *gorm.DB) *TaskReminderRepository {
+	return &TaskReminderRepository{db}
+}
+
+func (r *TaskReminderRepository) Create(reminder *domain.TaskReminder) (bool, apperr.AppErr) {
+	row := model.UnmarshalTaskReminder(reminder)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+	if result.Error != nil {
+		return false, apperr.NewInternalServerError().Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	reminder.ID = domain.TaskReminderIdentifier(row.ID)
+	return true, nil
+}
+
+func (r *TaskReminderRepository) Delete(id domain.TaskReminderIdentifier) apperr.AppErr {
+	if err := r.db.Delete(&model.TaskReminder{}, id).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskSeriesRepository(db *gorm.DB) *TaskSeriesRepository {
+	return &TaskSeriesRepository{db}
+}
+
+func (r *TaskSeriesRepository) Get(id domain.TaskSeriesIdentifier) (*domain.TaskSeries, apperr.AppErr) {
+	var row *model.TaskSeries
+	if err := r.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalTaskSeries(row)
+}
+
+func (r *TaskSeriesRepository) Create(series *domain.TaskSeries, task *domain.Task) (*domain.TaskSeriesIdentifier, apperr.AppErr) {
+	row := model.UnmarshalTaskSeries(series)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		series.ID = domain.TaskSeriesIdentifier(row.ID)
+		// 元のタスクを最初の回とする。同時に他の系列に加えられた場合は版数の検証で失敗する。
+		return saveTask(tx, task)
+	}); err != nil {
+		series.ID = 0
+		return nil, apperr.FromError(err)
+	}
+	markTasksSaved([]*domain.Task{task})
+	id := series.ID
+	return &id, nil
+}
+
+func (r *TaskSeriesRepository) Update(series *domain.TaskSeries, tasks ...*domain.Task) apperr.AppErr {
+	row := model.UnmarshalTaskSeries(series)
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(row).Select("*").Omit("create_at").Updates(row).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		return saveTasks(tx, tasks)
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	markTasksSaved(tasks)
+	return nil
+}
+
+func (r *TaskSeriesRepository) Delete(series *domain.TaskSeries, tasks []*domain.Task) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 系列の行をロックし、削除する間に次の回を生成しないようにする
+		var row *model.TaskSeries
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&row, series.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.NewNotFoundError().Wrap(err)
+			}
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		if err := saveTasks(tx, tasks); err != nil {
+			return err
+		}
+		// 削除済みの回は履歴を残さずに系列から外す
+		if err := tx.Unscoped().Model(&model.Task{}).
+			Where("task_series_id", series.ID).
+			Updates(map[string]interface{}{
+				"task_series_id": nil,
+				"occurrence":     nil,
+				"version":        gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		if err := tx.Delete(&model.TaskSeries{}, series.ID).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		return nil
+	}); err != nil {
+		return apperr.FromError(err)
+	}
+	markTasksSaved(tasks)
+	return nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"sync"
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// taskStreamBufferSize / 購読者ごとに受け取りを待てる変更の件数。超えた購読者は打ち切る。
+	taskStreamBufferSize = 64
+	// taskStreamPollSize / 一度のポーリングで取得する変更の件数の上限
+	taskStreamPollSize = 500
+)
+
+// TaskStreamBroker / タスクの変更を task_stream_event テーブルに記録し、各サーバがポーリングして自身の購読者に届ける。
+// 変更は出来事の配信を担う一つのサーバが順に記録するため、ID の順に確定する前提で、前回より大きい ID のみを読む。
+type TaskStreamBroker struct {
+	db *gorm.DB
+	// size / 保持する変更の件数
+	size     int
+	interval time.Duration
+
+	mu          sync.Mutex
+	polling     bool
+	subscribers map[domain.CompanyIdentifier]map[chan *domain.TaskStreamEvent]struct{}
+}
+
+func NewTaskStreamBroker(db *gorm.DB, size int, interval time.Duration) *TaskStreamBroker {
+	return &TaskStreamBroker{
+		db:          db,
+		size:        size,
+		interval:    interval,
+		subscribers: map[domain.CompanyIdentifier]map[chan *domain.TaskStreamEvent]struct{}{},
+	}
+}
+
+func (b *TaskStreamBroker) Publish(event *domain.TaskStreamEvent) apperr.AppErr {
+	row, aerr := model.UnmarshalTaskStreamEvent(event)
+	if aerr != nil {
+		return aerr
+	}
+	if err := b.db.Create(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	event.ID = domain.TaskStreamEventIdentifier(row.ID)
+	if row.ID > uint64(b.size) {
+		if err := b.db.Where("id <= ?", row.ID-uint64(b.size)).Delete(&model.TaskStreamEvent{}).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Subscribe / 最初の購読でポーリングを始める。届けるのは購読を始めた後にポーリングで読んだ変更のみ。
+func (b *TaskStreamBroker) Subscribe(companyID domain.CompanyIdentifier) (<-chan *domain.TaskStreamEvent, func(), apperr.AppErr) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.polling {
+		cursor, err := b.lastID()
+		if err != nil {
+			return nil, nil, err
+		}
+		b.polling = true
+		go b.poll(cursor)
+	}
+
+	ch := make(chan *domain.TaskStreamEvent, taskStreamBufferSize)
+	if b.subscribers[companyID] == nil {
+		b.subscribers[companyID] = map[chan *domain.TaskStreamEvent]struct{}{}
+	}
+	b.subscribers[companyID][ch] = struct{}{}
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(companyID, ch)
+	}
+	return ch, cancel, nil
+}
+
+func (b *TaskStreamBroker) ListAfter(companyID domain.CompanyIdentifier, afterID domain.TaskStreamEventIdentifier) ([]*domain.TaskStreamEvent, bool, apperr.AppErr) {
+	var bounds struct {
+		MinID *uint64
+		MaxID *uint64
+	}
+	if err := b.db.Model(&model.TaskStreamEvent{}).
+		Select("MIN(id) AS min_id, MAX(id) AS max_id").
+		Scan(&bounds).Error; err != nil {
+		return nil, false, apperr.NewInternalServerError().Wrap(err)
+	}
+	if bounds.MaxID == nil || uint64(afterID) >= *bounds.MaxID {
+		return nil, bounds.MaxID != nil && uint64(afterID) == *bounds.MaxID, nil
+	}
+	if uint64(afterID)+1 < *bounds.MinID {
+		return nil, false, nil
+	}
+
+	var rows []*model.TaskStreamEvent
+	if err := b.db.
+		Where("company_id = ? AND id > ?", companyID, afterID).
+		Order("id").
+		Limit(b.size).
+		Find(&rows).Error; err != nil {
+		return nil, false, apperr.NewInternalServerError().Wrap(err)
+	}
+	events, aerr := marshalTaskStreamEvents(rows)
+	if aerr != nil {
+		return nil, false, aerr
+	}
+	return events, true, nil
+}
+
+// poll / 一定の間隔で新しい変更を読み、購読者に届ける
+func (b *TaskStreamBroker) poll(cursor uint64) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		var rows []*model.TaskStreamEvent
+		err := b.db.
+			Where("id > ?", cursor).
+			Order("id").
+			Limit(taskStreamPollSize).
+			Find(&rows).Error
+
+		b.mu.Lock()
+		if err != nil {
+			// 読めなかった間の変更は再接続時の再送に任せる
+			for companyID, subscribers := range b.subscribers {
+				for ch := range subscribers {
+					b.unsubscribe(companyID, ch)
+				}
+			}
+		}
+		for _, row := range rows {
+			cursor = row.ID
+			// 読めない変更は飛ばす
+			event, aerr := model.MarshalTaskStreamEvent(row)
+			if aerr != nil {
+				continue
+			}
+			for ch := range b.subscribers[event.CompanyID] {
+				select {
+				case ch <- event:
+				default:
+					// 受け取りが追いつかない購読者は打ち切り、再接続時の再送に任せる
+					b.unsubscribe(event.CompanyID, ch)
+				}
+			}
+		}
+		b.mu.Unlock()
+	}
+}
+
+func (b *TaskStreamBroker) lastID() (uint64, apperr.AppErr) {
+	var id *uint64
+	if err := b.db.Model(&model.TaskStreamEvent{}).Select("MAX(id)").Scan(&id).Error; err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	if id == nil {
+		return 0, nil
+	}
+	return *id, nil
+}
+
+// unsubscribe / 購読をやめてチャネルを閉じる。呼び出し側でロックを取得しておくこと。
+func (b *TaskStreamBroker) unsubscribe(companyID domain.CompanyIdentifier, ch chan *domain.TaskStreamEvent) {
+	if _, ok := b.subscribers[companyID][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[companyID], ch)
+	if len(b.subscribers[companyID]) == 0 {
+		delete(b.subscribers, companyID)
+	}
+	close(ch)
+}
+
+func marshalTaskStreamEvents(rows []*model.TaskStreamEvent) ([]*domain.TaskStreamEvent, apperr.AppErr) {
+	var events []*domain.TaskStreamEvent
+	for _, row := range rows {
+		event, aerr := model.MarshalTaskStreamEvent(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
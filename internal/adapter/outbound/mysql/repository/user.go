@@ -32,6 +32,74 @@ func (r *UserRepository) Get(id domain.UserIdentifier) (*domain.User, apperr.App
 	return user, nil
 }
 
+func (r *UserRepository) ListByNames(companyID domain.CompanyIdentifier, names []string) ([]*domain.User, apperr.AppErr) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var rows []*model.User
+	if err := r.db.Preload("Company").
+		Where("company_id", companyID).
+		Where("user_name", names).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var users []*domain.User
+	for _, row := range rows {
+		user, aerr := model.MarshalUser(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) ListByEmails(companyID domain.CompanyIdentifier, emails []string) ([]*domain.User, apperr.AppErr) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var rows []*model.User
+	if err := r.db.Preload("Company").
+		Where("company_id", companyID).
+		Where("id IN (?)", r.db.Table("notification_email").Select("user_id").Where("email_address", emails)).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var users []*domain.User
+	for _, row := range rows {
+		user, aerr := model.MarshalUser(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) ListByIDs(ids []domain.UserIdentifier) ([]*domain.User, apperr.AppErr) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []*model.User
+	if err := r.db.Preload("Company").
+		Where("id", ids).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var users []*domain.User
+	for _, row := range rows {
+		user, aerr := model.MarshalUser(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
 func (r *UserRepository) Create(user *domain.User) (*domain.UserIdentifier, apperr.AppErr) {
 	row := model.UnmarshalUser(user)
 	if err := r.db.Create(&row).Error; err != nil {
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// updateVersioned / 版数が一致する場合のみ行を更新する。行には加算後の版数を設定しておく。
+// 一致する行がない場合は読み込んだ後に他の更新があったものとして扱う。
+func updateVersioned(tx *gorm.DB, row interface{}, version domain.Version) error {
+	result := tx.Model(row).
+		Where("version", version).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(row)
+	if result.Error != nil {
+		return apperr.NewInternalServerError().Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NewPreconditionFailedError()
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// maxWebhookErrorLength / 記録する配信の失敗の理由の長さの上限
+const maxWebhookErrorLength = 1000
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+func (r *WebhookRepository) Get(id domain.WebhookIdentifier) (*domain.Webhook, apperr.AppErr) {
+	var row *model.Webhook
+	if err := r.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalWebhook(row)
+}
+
+func (r *WebhookRepository) ListByCompanyID(companyID domain.CompanyIdentifier) ([]*domain.Webhook, apperr.AppErr) {
+	return r.list(r.db.Where("company_id", companyID))
+}
+
+func (r *WebhookRepository) ListActive(companyID domain.CompanyIdentifier) ([]*domain.Webhook, apperr.AppErr) {
+	return r.list(r.db.Where("company_id", companyID).Where("active", true))
+}
+
+func (r *WebhookRepository) list(query *gorm.DB) ([]*domain.Webhook, apperr.AppErr) {
+	var rows []*model.Webhook
+	if err := query.Order("id").Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var webhooks []*domain.Webhook
+	for _, row := range rows {
+		webhook, aerr := model.MarshalWebhook(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Create(webhook *domain.Webhook) (*domain.WebhookIdentifier, apperr.AppErr) {
+	row := model.UnmarshalWebhook(webhook)
+	if err := r.db.Create(&row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.WebhookIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *WebhookRepository) Update(webhook *domain.Webhook) apperr.AppErr {
+	row := model.UnmarshalWebhook(webhook)
+	if err := r.db.Model(row).
+		Select("url", "event_types", "active", "failures", "disabled_at").
+		Updates(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) UpdateFailures(webhook *domain.Webhook) apperr.AppErr {
+	row := model.UnmarshalWebhook(webhook)
+	if err := r.db.Model(row).
+		Select("active", "failures", "disabled_at").
+		Updates(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(id domain.WebhookIdentifier) apperr.AppErr {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Webhook{}, id).Error
+	}); err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db}
+}
+
+func (r *WebhookDeliveryRepository) Get(id domain.WebhookDeliveryIdentifier) (*domain.WebhookDelivery, apperr.AppErr) {
+	var row *model.WebhookDelivery
+	if err := r.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NewNotFoundError().Wrap(err)
+		}
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return model.MarshalWebhookDelivery(row)
+}
+
+func (r *WebhookDeliveryRepository) ListByWebhookID(webhookID domain.WebhookIdentifier, page domain.Page) ([]*domain.WebhookDelivery, int, apperr.AppErr) {
+	query := r.db.Model(&model.WebhookDelivery{}).
+		Where("webhook_id", webhookID).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	var rows []*model.WebhookDelivery
+	if err := query.
+		Order("id DESC").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Find(&rows).Error; err != nil {
+		return nil, 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	deliveries, aerr := marshalWebhookDeliveries(rows)
+	if aerr != nil {
+		return nil, 0, aerr
+	}
+	return deliveries, int(total), nil
+}
+
+func (r *WebhookDeliveryRepository) ListDue(now time.Time, limit int) ([]*domain.WebhookDelivery, apperr.AppErr) {
+	var rows []*model.WebhookDelivery
+	if err := r.db.
+		Where("delivery_status", domain.WebhookDeliveryStatusPending.String()).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	return marshalWebhookDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepository) Create(deliveries ...*domain.WebhookDelivery) apperr.AppErr {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	var rows []*model.WebhookDelivery
+	for _, delivery := range deliveries {
+		rows = append(rows, model.UnmarshalWebhookDelivery(delivery))
+	}
+	if err := r.db.Create(&rows).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	for i, row := range rows {
+		deliveries[i].ID = domain.WebhookDeliveryIdentifier(row.ID)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) Update(delivery *domain.WebhookDelivery) apperr.AppErr {
+	row := model.UnmarshalWebhookDelivery(delivery)
+	if row.LastError != nil && utf8.RuneCountInString(*row.LastError) > maxWebhookErrorLength {
+		lastError := string([]rune(*row.LastError)[:maxWebhookErrorLength])
+		row.LastError = &lastError
+	}
+	if err := r.db.Model(row).
+		Select("delivery_status", "attempts", "next_attempt_at", "response_code", "last_error", "delivered_at").
+		Updates(row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func marshalWebhookDeliveries(rows []*model.WebhookDelivery) ([]*domain.WebhookDelivery, apperr.AppErr) {
+	var deliveries []*domain.WebhookDelivery
+	for _, row := range rows {
+		delivery, aerr := model.MarshalWebhookDelivery(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
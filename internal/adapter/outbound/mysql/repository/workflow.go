@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"todo_api/internal/adapter/outbound/mysql/model"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+
+	"gorm.io/gorm"
+)
+
+type WorkflowRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRepository(db *gorm.DB) *WorkflowRepository {
+	return &WorkflowRepository{db}
+}
+
+func (r *WorkflowRepository) Get(companyID domain.CompanyIdentifier) (*domain.Workflow, apperr.AppErr) {
+	var statusRows []*model.TaskStatus
+	if err := r.db.Where("company_id", companyID).
+		Order("sort_order").Order("id").
+		Find(&statusRows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	var transitionRows []*model.TaskStatusTransition
+	if err := r.db.Where("company_id", companyID).
+		Order("id").
+		Find(&transitionRows).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	workflow := &domain.Workflow{
+		CompanyID: companyID,
+	}
+	for _, row := range statusRows {
+		status, aerr := model.MarshalTaskStatus(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		workflow.Statuses = append(workflow.Statuses, status)
+	}
+	for _, row := range transitionRows {
+		transition, aerr := model.MarshalTaskStatusTransition(row)
+		if aerr != nil {
+			return nil, aerr
+		}
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+	return workflow, nil
+}
+
+func (r *WorkflowRepository) CreateStatus(status *domain.TaskStatus) (*domain.TaskStatusIdentifier, apperr.AppErr) {
+	row := model.UnmarshalTaskStatus(status)
+	if err := r.db.Create(&row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.TaskStatusIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *WorkflowRepository) UpdateStatus(status *domain.TaskStatus) apperr.AppErr {
+	row := model.UnmarshalTaskStatus(status)
+	if err := r.db.Save(&row).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
+
+func (r *WorkflowRepository) DeleteStatus(id domain.TaskStatusIdentifier) apperr.AppErr {
+	return apperr.FromError(r.db.Transaction(func(tx *gorm.DB) error {
+		// 削除済みのタスクも外部キーで参照しているため数える
+		var count int64
+		if err := tx.Unscoped().Model(&model.Task{}).
+			Where("task_status_id", id).
+			Count(&count).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		if count > 0 {
+			return apperr.NewBadRequestError().SetMessage("task status is in use")
+		}
+		if err := tx.Where("from_status_id = ? or to_status_id = ?", id, id).
+			Delete(&model.TaskStatusTransition{}).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		if err := tx.Delete(&model.TaskStatus{}, id).Error; err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		return nil
+	}))
+}
+
+func (r *WorkflowRepository) CreateTransition(transition *domain.TaskStatusTransition) (*domain.TaskStatusTransitionIdentifier, apperr.AppErr) {
+	row := model.UnmarshalTaskStatusTransition(transition)
+	if err := r.db.Create(&row).Error; err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+	id := domain.TaskStatusTransitionIdentifier(row.ID)
+	return &id, nil
+}
+
+func (r *WorkflowRepository) DeleteTransition(id domain.TaskStatusTransitionIdentifier) apperr.AppErr {
+	if err := r.db.Delete(&model.TaskStatusTransition{}, id).Error; err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// HTTPSender / 署名を付けて Webhook の URL に POST する
+type HTTPSender struct {
+	client *http.Client
+}
+
+// errWebhookAddrNotAllowed / 名前から解決したアドレスが外部に公開されていない
+var errWebhookAddrNotAllowed = errors.New("webhook address is not allowed")
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// 登録時に検証できない名前の解決結果を、接続の直前に検証する
+		Control: func(network, address string, c syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !domain.IsPublicWebhookAddr(addrPort.Addr()) {
+				return errWebhookAddrNotAllowed
+			}
+			return nil
+		},
+	}
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// プロキシを経由すると接続先のアドレスを検証できないため、環境変数のプロキシは使わない
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				ForceAttemptHTTP2:     true,
+				MaxIdleConns:          100,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ExpectContinueTimeout: 1 * time.Second,
+			},
+			// 転送先には署名を送らない
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, apperr.AppErr) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo_api-webhook")
+	req.Header.Set("X-Webhook-Event", delivery.EventType.String())
+	req.Header.Set("X-Webhook-Event-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+webhook.Sign(timestamp, delivery.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, apperr.NewInternalServerError().Wrap(err)
+	}
+	defer res.Body.Close()
+	// 接続を再利用するために応答を読み捨てる
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, apperr.NewInternalServerError().Wrap(fmt.Errorf("unexpected status: %s", res.Status))
+	}
+	return res.StatusCode, nil
+}
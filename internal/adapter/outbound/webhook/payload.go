@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+	domain "todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// JSONEncoder / 出来事を JSON のペイロードにする
+type JSONEncoder struct{}
+
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{}
+}
+
+type payload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	CompanyID  uint64    `json:"company_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Actor      user      `json:"actor"`
+	Data       data      `json:"data"`
+}
+
+type data struct {
+	Task    *task     `json:"task,omitempty"`
+	Changes []*change `json:"changes,omitempty"`
+	Comment *comment  `json:"comment,omitempty"`
+	User    *user     `json:"user,omitempty"`
+}
+
+type user struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role,omitempty"`
+	UserType string `json:"user_type,omitempty"`
+}
+
+type task struct {
+	ID               uint64     `json:"id"`
+	Title            string     `json:"title"`
+	Detail           *string    `json:"detail,omitempty"`
+	Status           string     `json:"status"`
+	StatusCategory   string     `json:"status_category"`
+	Priority         *string    `json:"priority,omitempty"`
+	PersonInChargeID *uint64    `json:"person_in_charge_id,omitempty"`
+	AssigneeIDs      []uint64   `json:"assignee_ids"`
+	WatcherIDs       []uint64   `json:"watcher_ids"`
+	Labels           []string   `json:"labels"`
+	ParentID         *uint64    `json:"parent_id,omitempty"`
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	LimitDate        *time.Time `json:"limit_date,omitempty"`
+	Version          uint64     `json:"version"`
+	CreatorID        uint64     `json:"creator_id"`
+	DeleteAt         *time.Time `json:"delete_at,omitempty"`
+}
+
+type change struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+type comment struct {
+	ID       uint64 `json:"id"`
+	TaskID   uint64 `json:"task_id"`
+	AuthorID uint64 `json:"author_id"`
+	Body     string `json:"body"`
+}
+
+func (e *JSONEncoder) Encode(event *domain.WebhookEvent) (string, apperr.AppErr) {
+	p := payload{
+		ID:         event.ID,
+		Type:       event.Type.String(),
+		CompanyID:  uint64(event.CompanyID),
+		OccurredAt: event.OccurredAt,
+		Actor:      user{ID: uint64(event.Actor.ID), Name: event.Actor.Name},
+		Data: data{
+			Task:    newTask(event.Task),
+			Comment: newComment(event.Comment),
+		},
+	}
+	for _, c := range event.Changes {
+		p.Data.Changes = append(p.Data.Changes, &change{
+			Field:    string(c.Field),
+			OldValue: c.OldValue,
+			NewValue: c.NewValue,
+		})
+	}
+	if event.User != nil {
+		p.Data.User = &user{
+			ID:       uint64(event.User.ID),
+			Name:     event.User.Name,
+			Role:     event.User.Role.String(),
+			UserType: event.User.UserType.String(),
+		}
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", apperr.NewInternalServerError().Wrap(err)
+	}
+	return string(b), nil
+}
+
+func newTask(d *domain.Task) *task {
+	if d == nil {
+		return nil
+	}
+	t := &task{
+		ID:             uint64(d.ID),
+		Title:          d.Title,
+		Detail:         d.Detail,
+		Status:         d.Status.Name,
+		StatusCategory: d.Status.Category.String(),
+		AssigneeIDs:    []uint64{},
+		WatcherIDs:     []uint64{},
+		Labels:         []string{},
+		StartDate:      d.StartDate,
+		LimitDate:      d.LimitDate,
+		Version:        uint64(d.Version),
+		CreatorID:      uint64(d.Creator.ID),
+		DeleteAt:       d.DeleteAt,
+	}
+	if d.Priority != nil {
+		t.Priority = &d.Priority.Name
+	}
+	if d.PersonInCharge != nil {
+		id := uint64(d.PersonInCharge.ID)
+		t.PersonInChargeID = &id
+	}
+	if d.ParentID != nil {
+		id := uint64(*d.ParentID)
+		t.ParentID = &id
+	}
+	for _, u := range d.Assignees {
+		t.AssigneeIDs = append(t.AssigneeIDs, uint64(u.ID))
+	}
+	for _, u := range d.Watchers {
+		t.WatcherIDs = append(t.WatcherIDs, uint64(u.ID))
+	}
+	for _, label := range d.Labels {
+		t.Labels = append(t.Labels, label.Name)
+	}
+	return t
+}
+
+func newComment(d *domain.Comment) *comment {
+	if d == nil {
+		return nil
+	}
+	return &comment{
+		ID:       uint64(d.ID),
+		TaskID:   uint64(d.TaskID),
+		AuthorID: uint64(d.Author.ID),
+		Body:     d.Body,
+	}
+}
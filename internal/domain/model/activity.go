@@ -0,0 +1,139 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+)
+
+// Activity / 企業のタイムラインに表示する出来事
+type Activity struct {
+	ID        ActivityIdentifier
+	CompanyID CompanyIdentifier
+	Type      ActivityType
+	TaskID    *TaskIdentifier
+	// UserID / 出来事の対象のユーザ。担当者の変更では新しい担当者、ユーザの作成では作成されたユーザ。
+	UserID *UserIdentifier
+	// DomainEventID / 元となったドメインイベント。同じ出来事が再び配信された場合に重複して記録しないために使う。
+	DomainEventID DomainEventIdentifier
+
+	CreateAt time.Time
+	Actor    User
+}
+
+type ActivityIdentifier uint64
+
+type ActivityType int
+
+const (
+	ActivityTypeTaskCreated ActivityType = iota + 1
+	ActivityTypeTaskAssigned
+	ActivityTypeTaskCompleted
+	ActivityTypeTaskCommented
+	ActivityTypeUserCreated
+)
+
+// ActivityFilter / タイムラインの絞り込み条件。未指定の条件は絞り込まない。
+type ActivityFilter struct {
+	ActorID *UserIdentifier
+	TaskID  *TaskIdentifier
+	Types   []ActivityType
+}
+
+// ActivityCursor / タイムラインの取得位置。新しい順に After より古い出来事を Limit 件取得する。
+type ActivityCursor struct {
+	// After / 前のページの最後の出来事のID。0 の場合は最新から取得する。
+	After ActivityIdentifier
+	Limit int
+}
+
+// NewActivityCursor / 範囲外の件数を補正して取得位置を生成する
+func NewActivityCursor(after ActivityIdentifier, limit int) ActivityCursor {
+	if limit < 1 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+	return ActivityCursor{After: after, Limit: limit}
+}
+
+// NewTaskActivities / タスクの出来事から生成する。作成、担当者の設定、完了への変更を対象とする。
+// task は配信する時点のタスクで、変更後のステータスが現在も完了のステータスである場合に完了とする。
+func NewTaskActivities(event *DomainEvent, task *Task, actor User) []*Activity {
+	var activities []*Activity
+	newActivity := func(activityType ActivityType) *Activity {
+		taskID := task.ID
+		return &Activity{
+			CompanyID:     task.Creator.Company.ID,
+			Type:          activityType,
+			TaskID:        &taskID,
+			DomainEventID: event.ID,
+			Actor:         actor,
+		}
+	}
+	if event.Type == DomainEventTypeTaskCreated {
+		activities = append(activities, newActivity(ActivityTypeTaskCreated))
+	}
+	for _, change := range event.TaskChanges() {
+		switch {
+		case change.Field == TaskFieldPersonInCharge && change.NewValue != nil:
+			userID, err := strconv.ParseUint(*change.NewValue, 10, 64)
+			if err != nil {
+				continue
+			}
+			activity := newActivity(ActivityTypeTaskAssigned)
+			personInChargeID := UserIdentifier(userID)
+			activity.UserID = &personInChargeID
+			activities = append(activities, activity)
+		case change.Field == TaskFieldStatus && task.Status.IsClosed() && equalValue(change.NewValue, stringValue(task.Status.Name)):
+			activities = append(activities, newActivity(ActivityTypeTaskCompleted))
+		}
+	}
+	return activities
+}
+
+// NewCommentActivity / コメントの投稿の出来事を生成する
+func NewCommentActivity(event *DomainEvent, task *Task, comment *Comment) *Activity {
+	taskID := task.ID
+	return &Activity{
+		CompanyID:     task.Creator.Company.ID,
+		Type:          ActivityTypeTaskCommented,
+		TaskID:        &taskID,
+		DomainEventID: event.ID,
+		Actor:         comment.Author,
+	}
+}
+
+// NewUserActivity / ユーザの作成の出来事を生成する
+func NewUserActivity(event *DomainEvent, user *User, creator User) *Activity {
+	userID := user.ID
+	return &Activity{
+		CompanyID:     user.Company.ID,
+		Type:          ActivityTypeUserCreated,
+		UserID:        &userID,
+		DomainEventID: event.ID,
+		Actor:         creator,
+	}
+}
+
+func (e ActivityType) String() string {
+	switch e {
+	case ActivityTypeTaskCreated:
+		return "TASK_CREATED"
+	case ActivityTypeTaskAssigned:
+		return "TASK_ASSIGNED"
+	case ActivityTypeTaskCompleted:
+		return "TASK_COMPLETED"
+	case ActivityTypeTaskCommented:
+		return "TASK_COMMENTED"
+	case ActivityTypeUserCreated:
+		return "USER_CREATED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidAttachmentFileName = errors.New("Attachment File Name must be 1 to 255 characters")
+	errInvalidAttachmentEmpty    = errors.New("Attachment must not be empty")
+	errInvalidAttachmentSize     = errors.New("Attachment exceeds the maximum size of the company")
+	errInvalidAttachmentMimeType = errors.New("Attachment type is not allowed by the company")
+	errInvalidAttachmentQuota    = errors.New("Attachment exceeds the storage quota of the company")
+)
+
+const (
+	minAttachmentFileNameLength = 1
+	maxAttachmentFileNameLength = 255
+)
+
+// Attachment / タスクの添付ファイル。実体は StorageKey でストレージに保存する。
+type Attachment struct {
+	ID          AttachmentIdentifier
+	TaskID      TaskIdentifier
+	CompanyID   CompanyIdentifier
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+
+	CreateAt time.Time
+	Creator  User
+}
+
+type AttachmentDescription struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Setting     *CompanySetting
+	// UsedBytes / 企業の添付ファイルの合計容量
+	UsedBytes int64
+}
+
+type AttachmentIdentifier uint64
+
+func NewAttachment(task *Task, creator *User, desc AttachmentDescription) (*Attachment, apperr.AppErr) {
+	desc.FileName = filepath.Base(strings.ReplaceAll(desc.FileName, `\`, "/"))
+	if err := desc.validate(); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+
+	key, err := newStorageKey(task)
+	if err != nil {
+		return nil, apperr.NewInternalServerError().Wrap(err)
+	}
+
+	return &Attachment{
+		TaskID:      task.ID,
+		CompanyID:   task.Creator.Company.ID,
+		FileName:    desc.FileName,
+		ContentType: desc.ContentType,
+		Size:        desc.Size,
+		StorageKey:  key,
+		Creator:     *creator,
+	}, nil
+}
+
+func (d *AttachmentDescription) validate() error {
+	fileNameLength := utf8.RuneCountInString(d.FileName)
+	if d.FileName == "." || d.FileName == "/" || fileNameLength < minAttachmentFileNameLength || fileNameLength > maxAttachmentFileNameLength {
+		return errInvalidAttachmentFileName
+	}
+	if d.Size <= 0 {
+		return errInvalidAttachmentEmpty
+	}
+	if d.Size > d.Setting.MaxAttachmentSize {
+		return errInvalidAttachmentSize
+	}
+	if !d.Setting.AllowsMimeType(d.ContentType) {
+		return errInvalidAttachmentMimeType
+	}
+	if d.UsedBytes+d.Size > d.Setting.StorageQuota {
+		return errInvalidAttachmentQuota
+	}
+	return nil
+}
+
+// ValidateQuota / 企業の添付ファイルの合計容量に加えても上限を超えないことを検証する。
+// 同時の登録で上限を超えないように、リポジトリで登録と同じトランザクションで検証する。
+func (m *Attachment) ValidateQuota(usedBytes, quota int64) apperr.AppErr {
+	if usedBytes+m.Size > quota {
+		return apperr.NewBadRequestError().Wrap(errInvalidAttachmentQuota)
+	}
+	return nil
+}
+
+// newStorageKey / 推測できないストレージのキーを生成する。ファイル名はキーに含めない。
+func newStorageKey(task *Task) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%d/%s", task.Creator.Company.ID, task.ID, hex.EncodeToString(b)), nil
+}
@@ -23,6 +23,10 @@ type Auth struct {
 	Role     UserRole
 	UserType UserType
 	Company  Company
+	// Version / 更新のたびに加算される版数
+	Version Version
+	// Events / 保存されていない出来事。リポジトリで保存と同じトランザクションでアウトボックスに記録される。
+	Events []*DomainEvent
 }
 
 type AuthDescription struct {
@@ -31,6 +35,8 @@ type AuthDescription struct {
 	Role     UserRole
 	UserType UserType
 	Company  *Company
+	// Updator / 作成・更新したユーザ。出来事に記録する。
+	Updator *User
 }
 
 func NewAuth(desc AuthDescription) (*Auth, apperr.AppErr) {
@@ -47,6 +53,8 @@ func (m *Auth) Update(desc AuthDescription) apperr.AppErr {
 		return apperr.NewBadRequestError().Wrap(err)
 	}
 
+	before := m.snapshot()
+	hash := m.Hash
 	m.Name = desc.Name
 	if desc.Password != nil {
 		m.Hash = PasswordToHash(*desc.Password)
@@ -56,18 +64,47 @@ func (m *Auth) Update(desc AuthDescription) apperr.AppErr {
 	if desc.Company != nil {
 		m.Company = *desc.Company
 	}
+
+	changes := diffEventChanges(before, m.snapshot())
+	eventType := DomainEventTypeUserUpdated
+	if m.ID == 0 {
+		eventType = DomainEventTypeUserCreated
+	} else if m.Hash != hash {
+		// パスワードは変更したことのみを記録する
+		changes = append(changes, &DomainEventChange{Field: "password"})
+	}
+	if len(changes) > 0 {
+		event := newDomainEvent(eventType, AggregateTypeUser, uint64(m.ID), m.Company.ID, changes)
+		if desc.Updator != nil {
+			actorID := desc.Updator.ID
+			event.ActorID = &actorID
+		}
+		m.Events = append(m.Events, event)
+	}
 	return nil
 }
 
+// snapshot / 出来事で変更を記録する項目の表示用の値
+func (m *Auth) snapshot() []*DomainEventChange {
+	return []*DomainEventChange{
+		{Field: "name", NewValue: stringValue(m.Name)},
+		{Field: "role", NewValue: stringValue(m.Role.String())},
+		{Field: "user_type", NewValue: stringValue(m.UserType.String())},
+	}
+}
+
 func (d *AuthDescription) validate() error {
 	nameLength := utf8.RuneCountInString(d.Name)
 	if nameLength < minUserNameLength || nameLength > maxUserNameLength {
 		return errInvalidUserNameLength
 	}
 
-	passwordLength := utf8.RuneCountInString(*d.Password)
-	if passwordLength < minUserPasswordLength {
-		return errInvalidPasswordLength
+	// 更新時にパスワードが指定されていない場合は変更しない
+	if d.Password != nil {
+		passwordLength := utf8.RuneCountInString(*d.Password)
+		if passwordLength < minUserPasswordLength {
+			return errInvalidPasswordLength
+		}
 	}
 
 	return nil
@@ -85,6 +122,18 @@ func getSHA256Binary(s string) []byte {
 }
 
 // IsSuperAdmin / 管理会社の管理者かどうかを示す。任意の操作が可能。
+// User / 認証情報をユーザ情報として取得する
+func (m *Auth) User() User {
+	return User{
+		ID:       m.ID,
+		Name:     m.Name,
+		Role:     m.Role,
+		UserType: m.UserType,
+		Company:  m.Company,
+		Version:  m.Version,
+	}
+}
+
 func (m *Auth) IsSuperAdmin() bool {
 	return m.Company.ID == AdminCompanyID &&
 		m.UserType == UserTypeAdmin
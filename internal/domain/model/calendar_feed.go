@@ -0,0 +1,69 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+const calendarFeedTokenBytes = 32
+
+// CalendarFeed / ユーザごとのカレンダーの購読。トークンを知っていれば認証なしでタスクの期限を取得できる。
+type CalendarFeed struct {
+	UserID UserIdentifier
+	// Token / 購読の URL でユーザを識別する秘密のトークン
+	Token    string
+	CreateAt time.Time
+}
+
+// CalendarFeedScope / カレンダーに載せるタスクの範囲
+type CalendarFeedScope int
+
+const (
+	// CalendarFeedScopeAssigned / 自身が担当するタスク
+	CalendarFeedScopeAssigned CalendarFeedScope = iota + 1
+	// CalendarFeedScopeCompany / 企業のタスクのうち閲覧できるもの
+	CalendarFeedScopeCompany
+)
+
+// NewCalendarFeed / 新しいトークンでカレンダーの購読を生成する
+func NewCalendarFeed(userID UserIdentifier, now time.Time) (*CalendarFeed, apperr.AppErr) {
+	feed := &CalendarFeed{
+		UserID: userID,
+	}
+	if err := feed.Regenerate(now); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// Regenerate / トークンを発行し直す。以前の URL では取得できなくなる。
+func (m *CalendarFeed) Regenerate(now time.Time) apperr.AppErr {
+	token, err := newCalendarFeedToken()
+	if err != nil {
+		return apperr.NewInternalServerError().Wrap(err)
+	}
+	m.Token = token
+	m.CreateAt = now
+	return nil
+}
+
+func (e CalendarFeedScope) String() string {
+	switch e {
+	case CalendarFeedScopeAssigned:
+		return "ASSIGNED"
+	case CalendarFeedScopeCompany:
+		return "COMPANY"
+	default:
+		return ""
+	}
+}
+
+func newCalendarFeedToken() (string, error) {
+	b := make([]byte, calendarFeedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,171 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidCommentBodyLength = errors.New("Comment Body must be 1 to 2000 characters")
+	errInvalidCommentMention    = errors.New("Comment can mention only users of the same company")
+	errInvalidCommentDeleted    = errors.New("Comment is already deleted")
+)
+
+const (
+	minCommentBodyLength = 1
+	maxCommentBodyLength = 2000
+)
+
+// mentionPattern / 本文中の @ユーザ名。ユーザ名は空白、@ と句読点や括弧などの記号の手前までとする。
+// ユーザ名に使われる . _ - は含め、末尾の . は文の終わりとして除く。
+var mentionPattern = regexp.MustCompile("@([^\\s@,;:!?()\\[\\]{}<>\"'`、。，．！？：；「」『』（）【】〈〉《》・…]+)")
+
+// CommentFieldMentions / 出来事でメンションされたユーザの変更を記録する項目
+const CommentFieldMentions = "mention_ids"
+
+// mentionHonorifics / メンションのユーザ名に付けられる敬称。敬称を除いた名前もユーザ名の候補とする。
+var mentionHonorifics = []string{"さん", "様", "さま", "くん", "君", "ちゃん", "氏"}
+
+// Comment / タスクへのコメント。削除は論理削除とする。
+type Comment struct {
+	ID       CommentIdentifier
+	TaskID   TaskIdentifier
+	Author   User
+	Body     string
+	Mentions []*User
+
+	CreateAt time.Time
+	EditAt   *time.Time
+	DeleteAt *time.Time
+	// Events / 保存されていない出来事。リポジトリで保存と同じトランザクションでアウトボックスに記録される。
+	Events []*DomainEvent
+}
+
+type CommentDescription struct {
+	Body string
+	// Mentions / 本文中でメンションされたユーザ。ParseMentions の結果から解決する。
+	Mentions []*User
+}
+
+type CommentIdentifier uint64
+
+func NewComment(task *Task, author *User, desc CommentDescription) (*Comment, apperr.AppErr) {
+	comment := &Comment{
+		TaskID: task.ID,
+		Author: *author,
+	}
+	if err := desc.validate(task.Creator.Company.ID); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+
+	before := comment.snapshot()
+	comment.Body = desc.Body
+	comment.Mentions = desc.Mentions
+	comment.raiseEvent(DomainEventTypeCommentCreated, task.Creator.Company.ID, author, diffEventChanges(before, comment.snapshot()))
+	return comment, nil
+}
+
+// Update / コメントを編集する。コメントの投稿者のみ編集できる。
+func (m *Comment) Update(editor *User, desc CommentDescription) apperr.AppErr {
+	if m.Author.ID != editor.ID {
+		return apperr.NewForbiddenError()
+	}
+	if m.IsDeleted() {
+		return apperr.NewBadRequestError().Wrap(errInvalidCommentDeleted)
+	}
+	if err := desc.validate(m.Author.Company.ID); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	now := time.Now()
+	before := m.snapshot()
+	m.Body = desc.Body
+	m.Mentions = desc.Mentions
+	m.EditAt = &now
+	if changes := diffEventChanges(before, m.snapshot()); len(changes) > 0 {
+		m.raiseEvent(DomainEventTypeCommentUpdated, m.Author.Company.ID, editor, changes)
+	}
+	return nil
+}
+
+// Delete / コメントを論理削除する。コメントの投稿者のみ削除できる。
+func (m *Comment) Delete(user *User) apperr.AppErr {
+	if m.Author.ID != user.ID {
+		return apperr.NewForbiddenError()
+	}
+	if m.IsDeleted() {
+		return apperr.NewBadRequestError().Wrap(errInvalidCommentDeleted)
+	}
+
+	now := time.Now()
+	m.DeleteAt = &now
+	m.raiseEvent(DomainEventTypeCommentDeleted, m.Author.Company.ID, user, []*DomainEventChange{
+		{Field: "delete_at", NewValue: timeValue(&now)},
+	})
+	return nil
+}
+
+func (m *Comment) IsDeleted() bool {
+	return m.DeleteAt != nil
+}
+
+// MarkImported / 保存されていない出来事を他のツールからの取り込みによるものとする
+func (m *Comment) MarkImported() {
+	markImported(m.Events)
+}
+
+// snapshot / 出来事で変更を記録する項目の表示用の値
+func (m *Comment) snapshot() []*DomainEventChange {
+	return []*DomainEventChange{
+		{Field: "body", NewValue: stringValue(m.Body)},
+		{Field: CommentFieldMentions, NewValue: userIDsValue(m.Mentions)},
+	}
+}
+
+// raiseEvent / コメントの出来事を保存されていない出来事に追加する
+func (m *Comment) raiseEvent(eventType DomainEventType, companyID CompanyIdentifier, actor *User, changes []*DomainEventChange) {
+	event := newDomainEvent(eventType, AggregateTypeComment, uint64(m.ID), companyID, changes)
+	actorID := actor.ID
+	event.ActorID = &actorID
+	m.Events = append(m.Events, event)
+}
+
+func (d *CommentDescription) validate(companyID CompanyIdentifier) error {
+	bodyLength := utf8.RuneCountInString(d.Body)
+	if bodyLength < minCommentBodyLength || bodyLength > maxCommentBodyLength {
+		return errInvalidCommentBodyLength
+	}
+	for _, user := range d.Mentions {
+		if user.Company.ID != companyID {
+			return errInvalidCommentMention
+		}
+	}
+	return nil
+}
+
+// ParseMentions / 本文中でメンションされたユーザ名の候補を重複なく取得する。
+// 敬称で終わる場合は敬称を除いた名前も候補に含め、存在するユーザ名のみをメンションとして扱う。
+func ParseMentions(body string) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.TrimRight(match[1], ".")
+		add(name)
+		for _, honorific := range mentionHonorifics {
+			if strings.HasSuffix(name, honorific) {
+				add(strings.TrimSuffix(name, honorific))
+			}
+		}
+	}
+	return names
+}
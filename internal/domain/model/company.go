@@ -20,6 +20,10 @@ const (
 type Company struct {
 	ID   CompanyIdentifier
 	Name string
+	// Version / 更新のたびに加算される版数
+	Version Version
+	// Events / 保存されていない出来事。リポジトリで保存と同じトランザクションでアウトボックスに記録される。
+	Events []*DomainEvent
 }
 
 type CompanyDescipriton struct {
@@ -42,10 +46,26 @@ func (m *Company) Update(desc CompanyDescipriton) apperr.AppErr {
 		return apperr.NewBadRequestError().Wrap(err)
 	}
 
+	before := m.snapshot()
 	m.Name = desc.Name
+
+	eventType := DomainEventTypeCompanyUpdated
+	if m.ID == 0 {
+		eventType = DomainEventTypeCompanyCreated
+	}
+	if changes := diffEventChanges(before, m.snapshot()); len(changes) > 0 {
+		m.Events = append(m.Events, newDomainEvent(eventType, AggregateTypeCompany, uint64(m.ID), m.ID, changes))
+	}
 	return nil
 }
 
+// snapshot / 出来事で変更を記録する項目の表示用の値
+func (m *Company) snapshot() []*DomainEventChange {
+	return []*DomainEventChange{
+		{Field: "name", NewValue: stringValue(m.Name)},
+	}
+}
+
 func (d *CompanyDescipriton) validate() error {
 	nameLength := utf8.RuneCountInString(d.Name)
 	if nameLength < minCompanyNameLength || nameLength > maxCompanyNameLength {
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"errors"
+	"mime"
+	"strings"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errInvalidMaxTaskDepth      = errors.New("Max Task Depth must be 1 to 10")
+	errInvalidMaxAttachmentSize = errors.New("Max Attachment Size must be 1 byte to 100 MiB")
+	errInvalidStorageQuota      = errors.New("Storage Quota must be 1 byte to 100 GiB and not less than Max Attachment Size")
+	errInvalidAllowedMimeTypes  = errors.New("Allowed Mime Types must be 1 to 50 media types such as image/png or image/*")
+)
+
+const (
+	defaultMaxTaskDepth = 3
+	minMaxTaskDepth     = 1
+	maxMaxTaskDepth     = 10
+
+	defaultMaxAttachmentSize int64 = 10 << 20
+	maxMaxAttachmentSize     int64 = 100 << 20
+	defaultStorageQuota      int64 = 1 << 30
+	maxStorageQuota          int64 = 100 << 30
+	maxAllowedMimeTypes            = 50
+)
+
+// defaultAllowedMimeTypes / 企業の作成時に許可する添付ファイルの種類
+var defaultAllowedMimeTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"application/pdf",
+	"text/plain",
+}
+
+// CompanySetting / 企業ごとのタスクの設定
+type CompanySetting struct {
+	CompanyID CompanyIdentifier
+	// MaxTaskDepth / サブタスクの階層の深さの上限。最上位のタスクの深さは0。
+	MaxTaskDepth int
+	// RequireClosedSubtasks / 未完了の子タスクがある親タスクを完了にできないようにするか
+	RequireClosedSubtasks bool
+	// MaxAttachmentSize / 添付ファイル1件あたりの容量の上限（バイト）
+	MaxAttachmentSize int64
+	// AllowedMimeTypes / 添付できるファイルの種類。image/* のようにサブタイプを省略できる。
+	AllowedMimeTypes []string
+	// StorageQuota / 企業の添付ファイルの合計容量の上限（バイト）
+	StorageQuota int64
+}
+
+type CompanySettingDescription struct {
+	MaxTaskDepth          int
+	RequireClosedSubtasks bool
+	MaxAttachmentSize     int64
+	AllowedMimeTypes      []string
+	StorageQuota          int64
+}
+
+// NewDefaultCompanySetting / 企業の既定の設定を生成する
+func NewDefaultCompanySetting(companyID CompanyIdentifier) *CompanySetting {
+	return &CompanySetting{
+		CompanyID:         companyID,
+		MaxTaskDepth:      defaultMaxTaskDepth,
+		MaxAttachmentSize: defaultMaxAttachmentSize,
+		AllowedMimeTypes:  append([]string{}, defaultAllowedMimeTypes...),
+		StorageQuota:      defaultStorageQuota,
+	}
+}
+
+func (m *CompanySetting) Update(desc CompanySettingDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	m.MaxTaskDepth = desc.MaxTaskDepth
+	m.RequireClosedSubtasks = desc.RequireClosedSubtasks
+	m.MaxAttachmentSize = desc.MaxAttachmentSize
+	m.AllowedMimeTypes = normalizeMimeTypes(desc.AllowedMimeTypes)
+	m.StorageQuota = desc.StorageQuota
+	return nil
+}
+
+// AllowsMimeType / 添付ファイルの種類が許可されているかどうか
+func (m *CompanySetting) AllowsMimeType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range m.AllowedMimeTypes {
+		if allowed == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *CompanySettingDescription) validate() error {
+	if d.MaxTaskDepth < minMaxTaskDepth || d.MaxTaskDepth > maxMaxTaskDepth {
+		return errInvalidMaxTaskDepth
+	}
+	if d.MaxAttachmentSize < 1 || d.MaxAttachmentSize > maxMaxAttachmentSize {
+		return errInvalidMaxAttachmentSize
+	}
+	if d.StorageQuota < d.MaxAttachmentSize || d.StorageQuota > maxStorageQuota {
+		return errInvalidStorageQuota
+	}
+	if len(d.AllowedMimeTypes) < 1 || len(d.AllowedMimeTypes) > maxAllowedMimeTypes {
+		return errInvalidAllowedMimeTypes
+	}
+	for _, mimeType := range normalizeMimeTypes(d.AllowedMimeTypes) {
+		if !isValidMimeType(mimeType) {
+			return errInvalidAllowedMimeTypes
+		}
+	}
+	return nil
+}
+
+// isValidMimeType / type/subtype または type/* の形式であるかどうか
+func isValidMimeType(mimeType string) bool {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil || len(params) > 0 || mediaType != mimeType {
+		return false
+	}
+	mainType, subType, ok := strings.Cut(mediaType, "/")
+	return ok && mainType != "*" && mainType != "" && subType != ""
+}
+
+func normalizeMimeTypes(mimeTypes []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, mimeType := range mimeTypes {
+		mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+		if !seen[mimeType] {
+			seen[mimeType] = true
+			normalized = append(normalized, mimeType)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,286 @@
+package model
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxDomainEventAttempts / 一つの出来事の配信を試みる回数の上限。超えた場合は失敗として後続の出来事を配信する。
+	maxDomainEventAttempts = 10
+	// domainEventRetryBase / 再送までの間隔の初期値。失敗のたびに倍にする。
+	domainEventRetryBase = time.Second
+	domainEventRetryMax  = 10 * time.Minute
+)
+
+// DomainEvent / 集約の変更を表す出来事。集約の保存と同じトランザクションでアウトボックスに記録し、購読者に少なくとも一度配信する。
+type DomainEvent struct {
+	// ID / アウトボックスでの記録順。同じ集約の出来事はこの順に配信する。
+	ID            DomainEventIdentifier
+	Type          DomainEventType
+	AggregateType AggregateType
+	// AggregateID / 集約のID。作成の出来事ではリポジトリで保存時に設定する。
+	AggregateID uint64
+	CompanyID   CompanyIdentifier
+	// ActorID / 変更したユーザ。分からない場合は nil。
+	ActorID *UserIdentifier
+	// Changes / 変更された項目
+	Changes    []*DomainEventChange
+	OccurredAt time.Time
+	// Imported / 他のツールからの取り込みによる出来事。通知しない。
+	Imported bool
+
+	Status DomainEventStatus
+	// Attempts / 配信を試みた回数
+	Attempts      int
+	NextAttemptAt time.Time
+	// DeliveredTo / 配信済みの購読者の名前。再送ではこれらの購読者には送らない。
+	DeliveredTo  []string
+	LastError    *string
+	DispatchedAt *time.Time
+}
+
+// DomainEventChange / 出来事で変更された1項目。値は表示用の文字列で、未設定の場合は nil。
+type DomainEventChange struct {
+	Field    string
+	OldValue *string
+	NewValue *string
+}
+
+type DomainEventIdentifier uint64
+
+type DomainEventType int
+
+const (
+	DomainEventTypeTaskCreated DomainEventType = iota + 1
+	DomainEventTypeTaskUpdated
+	DomainEventTypeTaskStatusChanged
+	DomainEventTypeTaskDeleted
+	DomainEventTypeUserCreated
+	DomainEventTypeUserUpdated
+	DomainEventTypeCompanyCreated
+	DomainEventTypeCompanyUpdated
+	DomainEventTypeCommentCreated
+	DomainEventTypeCommentUpdated
+	DomainEventTypeCommentDeleted
+)
+
+type AggregateType int
+
+const (
+	AggregateTypeTask AggregateType = iota + 1
+	AggregateTypeUser
+	AggregateTypeCompany
+	AggregateTypeComment
+)
+
+type DomainEventStatus int
+
+const (
+	DomainEventStatusPending DomainEventStatus = iota + 1
+	DomainEventStatusDispatched
+	DomainEventStatusFailed
+)
+
+func newDomainEvent(eventType DomainEventType, aggregateType AggregateType, aggregateID uint64, companyID CompanyIdentifier, changes []*DomainEventChange) *DomainEvent {
+	now := time.Now()
+	return &DomainEvent{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		CompanyID:     companyID,
+		Changes:       changes,
+		OccurredAt:    now,
+		Status:        DomainEventStatusPending,
+		NextAttemptAt: now,
+	}
+}
+
+// markImported / 保存されていない出来事を取り込みによるものとする
+func markImported(events []*DomainEvent) {
+	for _, event := range events {
+		event.Imported = true
+	}
+}
+
+// AggregateKey / 配信の順序を保つ単位となる集約の識別子
+func (m *DomainEvent) AggregateKey() string {
+	return m.AggregateType.String() + ":" + strconv.FormatUint(m.AggregateID, 10)
+}
+
+// IsDeliveredTo / 購読者に配信済みかどうか
+func (m *DomainEvent) IsDeliveredTo(subscriber string) bool {
+	return slices.Contains(m.DeliveredTo, subscriber)
+}
+
+// Deliver / 購読者への配信を記録する
+func (m *DomainEvent) Deliver(subscriber string) {
+	if !m.IsDeliveredTo(subscriber) {
+		m.DeliveredTo = append(m.DeliveredTo, subscriber)
+	}
+}
+
+// Dispatched / すべての購読者に配信済みにする
+func (m *DomainEvent) Dispatched(now time.Time) {
+	m.Attempts++
+	m.Status = DomainEventStatusDispatched
+	m.LastError = nil
+	m.DispatchedAt = &now
+}
+
+// Fail / 配信の失敗を記録し、指数的に間隔を空けて再送する。上限の回数に達した場合は失敗とする。
+func (m *DomainEvent) Fail(reason string, now time.Time) {
+	m.Attempts++
+	m.LastError = &reason
+	if m.Attempts >= maxDomainEventAttempts {
+		m.Status = DomainEventStatusFailed
+		return
+	}
+	delay := domainEventRetryBase << (m.Attempts - 1)
+	if delay > domainEventRetryMax {
+		delay = domainEventRetryMax
+	}
+	m.NextAttemptAt = now.Add(delay)
+}
+
+// TaskChanges / タスクの出来事で変更された項目をタスクの変更に戻す
+func (m *DomainEvent) TaskChanges() []*TaskChange {
+	var changes []*TaskChange
+	for _, change := range m.Changes {
+		field := TaskField(change.Field)
+		action := changeAction(TaskIdentifier(m.AggregateID), field)
+		switch m.Type {
+		case DomainEventTypeTaskCreated:
+			action = TaskChangeActionCreate
+		case DomainEventTypeTaskDeleted:
+			action = TaskChangeActionDelete
+		}
+		changes = append(changes, &TaskChange{
+			Action:   action,
+			Field:    field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return changes
+}
+
+// FindChange / 変更された項目を名前で取得する。変更されていない場合は nil を返す。
+func (m *DomainEvent) FindChange(field string) *DomainEventChange {
+	for _, change := range m.Changes {
+		if change.Field == field {
+			return change
+		}
+	}
+	return nil
+}
+
+// diffEventChanges / 変更前後の項目の値を比較し、変更された項目を返す。before と after は同じ順に同じ項目を持つ。
+func diffEventChanges(before, after []*DomainEventChange) []*DomainEventChange {
+	var changes []*DomainEventChange
+	for i := range after {
+		if equalValue(before[i].NewValue, after[i].NewValue) {
+			continue
+		}
+		changes = append(changes, &DomainEventChange{
+			Field:    after[i].Field,
+			OldValue: before[i].NewValue,
+			NewValue: after[i].NewValue,
+		})
+	}
+	return changes
+}
+
+// raiseEvents / タスクの変更から出来事を生成し、保存されていない出来事に追加する
+func (m *Task) raiseEvents(changes []*TaskChange) {
+	if len(changes) == 0 {
+		return
+	}
+	var eventChanges []*DomainEventChange
+	for _, change := range changes {
+		eventChanges = append(eventChanges, &DomainEventChange{
+			Field:    string(change.Field),
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	var eventTypes []DomainEventType
+	switch changes[0].Action {
+	case TaskChangeActionCreate:
+		eventTypes = append(eventTypes, DomainEventTypeTaskCreated)
+	case TaskChangeActionDelete:
+		eventTypes = append(eventTypes, DomainEventTypeTaskDeleted)
+	default:
+		eventTypes = append(eventTypes, DomainEventTypeTaskUpdated)
+		if slices.ContainsFunc(changes, func(c *TaskChange) bool { return c.Field == TaskFieldStatus }) {
+			eventTypes = append(eventTypes, DomainEventTypeTaskStatusChanged)
+		}
+	}
+	for _, eventType := range eventTypes {
+		event := newDomainEvent(eventType, AggregateTypeTask, uint64(m.ID), m.Creator.Company.ID, eventChanges)
+		if m.Updator.ID != 0 {
+			actorID := m.Updator.ID
+			event.ActorID = &actorID
+		}
+		m.Events = append(m.Events, event)
+	}
+}
+
+func (e DomainEventType) String() string {
+	switch e {
+	case DomainEventTypeTaskCreated:
+		return "task.created"
+	case DomainEventTypeTaskUpdated:
+		return "task.updated"
+	case DomainEventTypeTaskStatusChanged:
+		return "task.status_changed"
+	case DomainEventTypeTaskDeleted:
+		return "task.deleted"
+	case DomainEventTypeUserCreated:
+		return "user.created"
+	case DomainEventTypeUserUpdated:
+		return "user.updated"
+	case DomainEventTypeCompanyCreated:
+		return "company.created"
+	case DomainEventTypeCompanyUpdated:
+		return "company.updated"
+	case DomainEventTypeCommentCreated:
+		return "comment.created"
+	case DomainEventTypeCommentUpdated:
+		return "comment.updated"
+	case DomainEventTypeCommentDeleted:
+		return "comment.deleted"
+	default:
+		return ""
+	}
+}
+
+func (e AggregateType) String() string {
+	switch e {
+	case AggregateTypeTask:
+		return "TASK"
+	case AggregateTypeUser:
+		return "USER"
+	case AggregateTypeCompany:
+		return "COMPANY"
+	case AggregateTypeComment:
+		return "COMMENT"
+	default:
+		return ""
+	}
+}
+
+func (e DomainEventStatus) String() string {
+	switch e {
+	case DomainEventStatusPending:
+		return "PENDING"
+	case DomainEventStatusDispatched:
+		return "DISPATCHED"
+	case DomainEventStatusFailed:
+		return "FAILED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+	"time"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidIdempotencyKeyLength = errors.New("Idempotency-Key must be 1 to 255 characters")
+	errIdempotencyKeyReused        = errors.New("Idempotency-Key has already been used for a different request")
+	errIdempotencyKeyInProgress    = errors.New("a request with the same Idempotency-Key is in progress")
+)
+
+const (
+	minIdempotencyKeyLength = 1
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyRecord / 冪等キーに対する最初のリクエストとそのレスポンス。ユーザごとに保持し、有効期限を過ぎたものは存在しないものとして扱う。
+type IdempotencyRecord struct {
+	UserID UserIdentifier
+	Key    string
+	// RequestHash / リクエストのメソッド・パス・本文のハッシュ。同じキーで異なるリクエストが送られたことの検出に利用する。
+	RequestHash string
+	// StatusCode / レスポンスのステータス。0 の場合は処理中。
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreateAt    time.Time
+	ExpireAt    time.Time
+}
+
+// NewIdempotencyRecord / 処理中の記録を生成する
+func NewIdempotencyRecord(userID UserIdentifier, key, requestHash string, now time.Time, ttl time.Duration) (*IdempotencyRecord, apperr.AppErr) {
+	keyLength := utf8.RuneCountInString(key)
+	if keyLength < minIdempotencyKeyLength || keyLength > maxIdempotencyKeyLength {
+		return nil, apperr.NewBadRequestError().Wrap(errInvalidIdempotencyKeyLength)
+	}
+	return &IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreateAt:    now,
+		ExpireAt:    now.Add(ttl),
+	}, nil
+}
+
+// IsCompleted / レスポンスが記録済みかどうか
+func (m *IdempotencyRecord) IsCompleted() bool {
+	return m.StatusCode != 0
+}
+
+// IsExpired / 有効期限を過ぎているかどうか
+func (m *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(m.ExpireAt)
+}
+
+// Complete / レスポンスを記録する
+func (m *IdempotencyRecord) Complete(statusCode int, contentType string, body []byte) {
+	m.StatusCode = statusCode
+	m.ContentType = contentType
+	m.Body = body
+}
+
+// ValidateReplay / 記録済みのレスポンスを返せることを検証する。異なるリクエストでの再利用と処理中の重複を拒否する。
+func (m *IdempotencyRecord) ValidateReplay(requestHash string) apperr.AppErr {
+	if m.RequestHash != requestHash {
+		return apperr.NewUnprocessableEntityError().Wrap(errIdempotencyKeyReused)
+	}
+	if !m.IsCompleted() {
+		return apperr.NewConflictError().Wrap(errIdempotencyKeyInProgress)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidLabelNameLength = errors.New("Label Name must be 1 to 20 characters")
+	errInvalidLabelColor      = errors.New("Label Color must be a hex color code such as #1A2B3C")
+	errInvalidTaskLabel       = errors.New("Task cannot have labels of other companies")
+)
+
+const (
+	minLabelNameLength = 1
+	maxLabelNameLength = 20
+)
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Label / 企業ごとに定義されるタスクの分類
+type Label struct {
+	ID        LabelIdentifier
+	CompanyID CompanyIdentifier
+	Name      string
+	Color     string
+}
+
+type LabelDescription struct {
+	Name  string
+	Color string
+}
+
+type LabelIdentifier uint64
+
+// LabelMatch / ラベルによる絞り込みの方法
+type LabelMatch int
+
+const (
+	// LabelMatchAny / いずれかのラベルを持つタスク
+	LabelMatchAny LabelMatch = iota + 1
+	// LabelMatchAll / すべてのラベルを持つタスク
+	LabelMatchAll
+)
+
+func NewLabel(companyID CompanyIdentifier, desc LabelDescription) (*Label, apperr.AppErr) {
+	label := &Label{
+		CompanyID: companyID,
+	}
+	if err := label.Update(desc); err != nil {
+		return nil, err
+	}
+
+	return label, nil
+}
+
+func (m *Label) Update(desc LabelDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	m.Name = desc.Name
+	m.Color = desc.Color
+	return nil
+}
+
+func (d *LabelDescription) validate() error {
+	nameLength := utf8.RuneCountInString(d.Name)
+	if nameLength < minLabelNameLength || nameLength > maxLabelNameLength {
+		return errInvalidLabelNameLength
+	}
+	if !labelColorPattern.MatchString(d.Color) {
+		return errInvalidLabelColor
+	}
+	return nil
+}
+
+func (e LabelMatch) String() string {
+	switch e {
+	case LabelMatchAny:
+		return "ANY"
+	case LabelMatchAll:
+		return "ALL"
+	default:
+		return ""
+	}
+}
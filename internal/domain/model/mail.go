@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	// maxMailAttempts / 送信を試みる回数の上限。超えた場合は失敗とする。
+	maxMailAttempts = 8
+	// mailRetryBase / 再送までの間隔の初期値。失敗のたびに倍にする。
+	mailRetryBase = time.Minute
+	mailRetryMax  = 6 * time.Hour
+)
+
+// MailMessage / 送信待ちのメール。送信はアウトボックスを介して非同期に行う。
+type MailMessage struct {
+	ID      MailMessageIdentifier
+	UserID  UserIdentifier
+	To      string
+	Subject string
+	Body    string
+	// UnsubscribeURL / List-Unsubscribe ヘッダに設定する配信停止のURL
+	UnsubscribeURL string
+	// DomainEventID, NotificationType / 出来事による通知のメールの場合の元の通知。同じ出来事が再び配信された場合に重複して送らないために使う。
+	DomainEventID    *DomainEventIdentifier
+	NotificationType *NotificationType
+	Status           MailStatus
+	// Attempts / 送信を試みた回数
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreateAt      time.Time
+	SentAt        *time.Time
+}
+
+type MailMessageIdentifier uint64
+
+type MailStatus int
+
+const (
+	MailStatusPending MailStatus = iota + 1
+	MailStatusSent
+	MailStatusFailed
+)
+
+// NewMailMessage / すぐに送る送信待ちのメールを生成する
+func NewMailMessage(userID UserIdentifier, to, subject, body, unsubscribeURL string, now time.Time) *MailMessage {
+	return &MailMessage{
+		UserID:         userID,
+		To:             to,
+		Subject:        subject,
+		Body:           body,
+		UnsubscribeURL: unsubscribeURL,
+		Status:         MailStatusPending,
+		NextAttemptAt:  now,
+		CreateAt:       now,
+	}
+}
+
+// NewNotificationMailMessage / 通知をすぐに送る送信待ちのメールを生成する
+func NewNotificationMailMessage(notification *Notification, to, subject, body, unsubscribeURL string, now time.Time) *MailMessage {
+	message := NewMailMessage(notification.UserID, to, subject, body, unsubscribeURL, now)
+	if notification.DomainEventID != nil {
+		eventID := *notification.DomainEventID
+		notificationType := notification.Type
+		message.DomainEventID = &eventID
+		message.NotificationType = &notificationType
+	}
+	return message
+}
+
+// Sent / 送信済みにする
+func (m *MailMessage) Sent(now time.Time) {
+	m.Attempts++
+	m.Status = MailStatusSent
+	m.SentAt = &now
+	m.LastError = nil
+}
+
+// Fail / 送信の失敗を記録し、指数的に間隔を空けて再送する。上限の回数に達した場合は失敗とする。
+func (m *MailMessage) Fail(reason string, now time.Time) {
+	m.Attempts++
+	m.LastError = &reason
+	if m.Attempts >= maxMailAttempts {
+		m.Status = MailStatusFailed
+		return
+	}
+	delay := mailRetryBase << (m.Attempts - 1)
+	if delay > mailRetryMax {
+		delay = mailRetryMax
+	}
+	m.NextAttemptAt = now.Add(delay)
+}
+
+func (e MailStatus) String() string {
+	switch e {
+	case MailStatusPending:
+		return "PENDING"
+	case MailStatusSent:
+		return "SENT"
+	case MailStatusFailed:
+		return "FAILED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification / ユーザごとのアプリ内の通知
+type Notification struct {
+	ID     NotificationIdentifier
+	UserID UserIdentifier
+	Type   NotificationType
+	TaskID TaskIdentifier
+	// Actor / 通知のきっかけとなったユーザ。期限の通知では nil。
+	Actor *User
+	// CommentID / メンションされたコメント
+	CommentID *CommentIdentifier
+	// Status / ステータスの変更の通知での変更後のステータスの名前
+	Status *string
+	// LimitDate / 期限の通知の時点の期限
+	LimitDate *time.Time
+	// DomainEventID / 通知のきっかけとなった出来事。同じ出来事が再び配信された場合に重複して通知しないために使う。期限の通知では nil。
+	DomainEventID *DomainEventIdentifier
+	ReadAt        *time.Time
+	CreateAt      time.Time
+}
+
+type NotificationIdentifier uint64
+
+type NotificationType int
+
+const (
+	// NotificationTypeAssigned / タスクの担当者に設定された
+	NotificationTypeAssigned NotificationType = iota + 1
+	// NotificationTypeMentioned / コメントでメンションされた
+	NotificationTypeMentioned
+	// NotificationTypeStatusChanged / ウォッチしているタスクのステータスが変更された
+	NotificationTypeStatusChanged
+	// NotificationTypeDueSoon / 担当するタスクの期限が近づいた、または過ぎた
+	NotificationTypeDueSoon
+)
+
+// NotificationTypes / 通知の種類の一覧
+var NotificationTypes = []NotificationType{
+	NotificationTypeAssigned,
+	NotificationTypeMentioned,
+	NotificationTypeStatusChanged,
+	NotificationTypeDueSoon,
+}
+
+// NewTaskNotifications / タスクの出来事から通知を生成する。新たに担当者となったユーザと、ステータスの変更をウォッチャーに通知する。
+// task は配信する時点のタスクで、担当者とウォッチャーは現在も設定されているユーザに限る。
+// 変更したユーザ自身と、タスクを閲覧できないユーザには通知しない。
+func NewTaskNotifications(event *DomainEvent, task *Task, actor User) []*Notification {
+	var notifications []*Notification
+	newNotification := func(user *User, notificationType NotificationType) *Notification {
+		eventID := event.ID
+		return &Notification{
+			UserID:        user.ID,
+			Type:          notificationType,
+			TaskID:        task.ID,
+			Actor:         &actor,
+			DomainEventID: &eventID,
+		}
+	}
+	shouldNotify := func(user *User) bool {
+		return user.ID != actor.ID && task.IsVisibleTo(user)
+	}
+	for _, change := range event.TaskChanges() {
+		switch {
+		case change.Field == TaskFieldAssignees:
+			added := addedUserIDs(change.OldValue, change.NewValue)
+			for _, assignee := range task.Assignees {
+				if !slices.Contains(added, assignee.ID) || !shouldNotify(assignee) {
+					continue
+				}
+				notifications = append(notifications, newNotification(assignee, NotificationTypeAssigned))
+			}
+		case change.Field == TaskFieldStatus && change.Action == TaskChangeActionStatus:
+			for _, watcher := range task.Watchers {
+				if !shouldNotify(watcher) {
+					continue
+				}
+				notification := newNotification(watcher, NotificationTypeStatusChanged)
+				notification.Status = change.NewValue
+				notifications = append(notifications, notification)
+			}
+		}
+	}
+	return notifications
+}
+
+// NewMentionNotifications / コメントの出来事で新たにメンションされたユーザに通知する。
+// comment は配信する時点のコメントで、現在もメンションされているユーザに限る。
+func NewMentionNotifications(event *DomainEvent, task *Task, comment *Comment) []*Notification {
+	change := event.FindChange(CommentFieldMentions)
+	if change == nil {
+		return nil
+	}
+	added := addedUserIDs(change.OldValue, change.NewValue)
+	var notifications []*Notification
+	for _, user := range comment.Mentions {
+		if !slices.Contains(added, user.ID) || user.ID == comment.Author.ID || !task.IsVisibleTo(user) {
+			continue
+		}
+		commentID := comment.ID
+		author := comment.Author
+		eventID := event.ID
+		notifications = append(notifications, &Notification{
+			UserID:        user.ID,
+			Type:          NotificationTypeMentioned,
+			TaskID:        task.ID,
+			Actor:         &author,
+			CommentID:     &commentID,
+			DomainEventID: &eventID,
+		})
+	}
+	return notifications
+}
+
+// NewDueSoonNotification / リマインダーを通知に変換する
+func NewDueSoonNotification(reminder *TaskReminder) *Notification {
+	limitDate := reminder.LimitDate
+	return &Notification{
+		UserID:    reminder.UserID,
+		Type:      NotificationTypeDueSoon,
+		TaskID:    reminder.TaskID,
+		LimitDate: &limitDate,
+		CreateAt:  reminder.CreateAt,
+	}
+}
+
+// addedUserIDs / userIDsValue の値の変更で追加されたユーザID
+func addedUserIDs(oldValue, newValue *string) []UserIdentifier {
+	before := parseUserIDsValue(oldValue)
+	var added []UserIdentifier
+	for _, id := range parseUserIDsValue(newValue) {
+		if !slices.Contains(before, id) {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// Read / 既読にする。既読の場合は何もしない。
+func (m *Notification) Read(now time.Time) {
+	if m.ReadAt == nil {
+		m.ReadAt = &now
+	}
+}
+
+// parseUserIDsValue / userIDsValue の値をユーザIDに戻す
+func parseUserIDsValue(value *string) []UserIdentifier {
+	if value == nil {
+		return nil
+	}
+	var ids []UserIdentifier
+	for _, s := range strings.Split(*value, ", ") {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, UserIdentifier(id))
+	}
+	return ids
+}
+
+func (e NotificationType) String() string {
+	switch e {
+	case NotificationTypeAssigned:
+		return "ASSIGNED"
+	case NotificationTypeMentioned:
+		return "MENTIONED"
+	case NotificationTypeStatusChanged:
+		return "STATUS_CHANGED"
+	case NotificationTypeDueSoon:
+		return "DUE_SOON"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errInvalidEmailAddress   = errors.New("Email Address is invalid")
+	errInvalidEmailLanguage  = errors.New("Email Language must be ja or en")
+	errInvalidEmailMode      = errors.New("Email Mode must be OFF, IMMEDIATE or DIGEST")
+	errInvalidEmailNoAddress = errors.New("Email Address is required to receive emails")
+)
+
+const unsubscribeTokenBytes = 32
+
+// NotificationPreference / ユーザごとの通知の設定。種類ごとの設定のない種類は通知する。
+type NotificationPreference struct {
+	UserID UserIdentifier
+	// InApp / 種類ごとにアプリ内で通知するかどうか
+	InApp map[NotificationType]bool
+	// Email / 種類ごとにメールで通知するかどうか。EmailMode が OFF の場合は送らない。
+	Email map[NotificationType]bool
+
+	// EmailAddress / 通知を送るメールアドレス
+	EmailAddress  *string
+	EmailLanguage Language
+	EmailMode     EmailMode
+	// UnsubscribeToken / メール中の配信停止のリンクでユーザを識別するトークン
+	UnsubscribeToken string
+	// LastDigestAt / 最後にダイジェストを送った日時
+	LastDigestAt *time.Time
+}
+
+// NotificationPreferenceDescription / 通知の設定の更新内容。nil の項目は変更しない。
+type NotificationPreferenceDescription struct {
+	InApp         map[NotificationType]bool
+	Email         map[NotificationType]bool
+	EmailAddress  *string
+	EmailLanguage *Language
+	EmailMode     *EmailMode
+}
+
+type Language int
+
+const (
+	LanguageJapanese Language = iota + 1
+	LanguageEnglish
+)
+
+// EmailMode / メールの送り方
+type EmailMode int
+
+const (
+	// EmailModeOff / メールを送らない
+	EmailModeOff EmailMode = iota + 1
+	// EmailModeImmediate / 通知のたびに送る
+	EmailModeImmediate
+	// EmailModeDigest / 1日分の通知をまとめて送る
+	EmailModeDigest
+)
+
+// NewNotificationPreference / すべての種類をアプリ内で通知し、メールは送らない設定を生成する
+func NewNotificationPreference(userID UserIdentifier) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:        userID,
+		InApp:         map[NotificationType]bool{},
+		Email:         map[NotificationType]bool{},
+		EmailLanguage: LanguageJapanese,
+		EmailMode:     EmailModeOff,
+	}
+}
+
+// Update / 指定された項目の設定のみを置き換える
+func (m *NotificationPreference) Update(desc NotificationPreferenceDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+	for notificationType, enabled := range desc.InApp {
+		m.InApp[notificationType] = enabled
+	}
+	for notificationType, enabled := range desc.Email {
+		m.Email[notificationType] = enabled
+	}
+	if desc.EmailAddress != nil {
+		m.EmailAddress = desc.EmailAddress
+		if *desc.EmailAddress == "" {
+			m.EmailAddress = nil
+		}
+	}
+	if desc.EmailLanguage != nil {
+		m.EmailLanguage = *desc.EmailLanguage
+	}
+	if desc.EmailMode != nil {
+		m.EmailMode = *desc.EmailMode
+	}
+	if m.EmailMode != EmailModeOff && m.EmailAddress == nil {
+		return apperr.NewBadRequestError().Wrap(errInvalidEmailNoAddress)
+	}
+	// 配信停止のリンクはメールを受け取るようになった時点で発行する
+	if m.EmailMode != EmailModeOff && m.UnsubscribeToken == "" {
+		token, err := newUnsubscribeToken()
+		if err != nil {
+			return apperr.NewInternalServerError().Wrap(err)
+		}
+		m.UnsubscribeToken = token
+	}
+	return nil
+}
+
+func (d *NotificationPreferenceDescription) validate() error {
+	if d.EmailAddress != nil && *d.EmailAddress != "" {
+		address, err := mail.ParseAddress(*d.EmailAddress)
+		if err != nil || address.Address != *d.EmailAddress {
+			return errInvalidEmailAddress
+		}
+	}
+	if d.EmailLanguage != nil {
+		switch *d.EmailLanguage {
+		case LanguageJapanese, LanguageEnglish:
+		default:
+			return errInvalidEmailLanguage
+		}
+	}
+	if d.EmailMode != nil {
+		switch *d.EmailMode {
+		case EmailModeOff, EmailModeImmediate, EmailModeDigest:
+		default:
+			return errInvalidEmailMode
+		}
+	}
+	return nil
+}
+
+// AllowsInApp / アプリ内で通知するかどうか
+func (m *NotificationPreference) AllowsInApp(notificationType NotificationType) bool {
+	enabled, ok := m.InApp[notificationType]
+	return !ok || enabled
+}
+
+// AllowsEmail / 種類ごとの設定でメールを送るかどうか。送り方は EmailMode に従う。
+func (m *NotificationPreference) AllowsEmail(notificationType NotificationType) bool {
+	if m.EmailMode == EmailModeOff || m.EmailAddress == nil {
+		return false
+	}
+	enabled, ok := m.Email[notificationType]
+	return !ok || enabled
+}
+
+// Unsubscribe / メールの配信を停止する
+func (m *NotificationPreference) Unsubscribe() {
+	m.EmailMode = EmailModeOff
+}
+
+// IsDigestDue / ダイジェストを送る時刻を過ぎ、その回のダイジェストをまだ送っていないかどうか。hour は送る時刻（0〜23時）。
+func (m *NotificationPreference) IsDigestDue(now time.Time, hour int) bool {
+	if m.EmailMode != EmailModeDigest || m.EmailAddress == nil {
+		return false
+	}
+	mark := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if now.Before(mark) {
+		mark = mark.AddDate(0, 0, -1)
+	}
+	return m.LastDigestAt == nil || m.LastDigestAt.Before(mark)
+}
+
+// DigestSince / ダイジェストに含める通知の開始日時。初回は1日前から。
+func (m *NotificationPreference) DigestSince(now time.Time) time.Time {
+	if m.LastDigestAt == nil {
+		return now.AddDate(0, 0, -1)
+	}
+	return *m.LastDigestAt
+}
+
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, unsubscribeTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (e Language) String() string {
+	switch e {
+	case LanguageJapanese:
+		return "ja"
+	case LanguageEnglish:
+		return "en"
+	default:
+		return ""
+	}
+}
+
+func (e EmailMode) String() string {
+	switch e {
+	case EmailModeOff:
+		return "OFF"
+	case EmailModeImmediate:
+		return "IMMEDIATE"
+	case EmailModeDigest:
+		return "DIGEST"
+	default:
+		return ""
+	}
+}
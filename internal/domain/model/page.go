@@ -0,0 +1,30 @@
+package model
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Page / 一覧のページ指定。Number は1始まり。
+type Page struct {
+	Number int
+	Size   int
+}
+
+// NewPage / 範囲外の値を補正してページ指定を生成する
+func NewPage(number, size int) Page {
+	if number < 1 {
+		number = 1
+	}
+	if size < 1 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	return Page{Number: number, Size: size}
+}
+
+func (m Page) Offset() int {
+	return (m.Number - 1) * m.Size
+}
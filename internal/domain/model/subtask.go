@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidTaskParentCompany   = errors.New("Task Parent must belong to the same company as the task")
+	errInvalidTaskParentCycle     = errors.New("Task Parent cannot be the task itself or its descendant")
+	errInvalidTaskDepth           = errors.New("Task hierarchy exceeds the maximum depth")
+	errInvalidTaskOpenSubtasks    = errors.New("Task with open subtasks cannot be closed")
+	errInvalidChecklistLength     = errors.New("Checklist must have 50 or fewer items")
+	errInvalidChecklistItemLength = errors.New("Checklist Item Text must be 1 to 100 characters")
+)
+
+const (
+	minChecklistItemTextLength = 1
+	maxChecklistItemTextLength = 100
+	maxChecklistLength         = 50
+)
+
+// TaskHierarchy / 親タスクの設定の検証に必要な階層の情報
+type TaskHierarchy struct {
+	Parent *Task
+	// AncestorIDs / 親タスクの祖先のID。親に近い順。
+	AncestorIDs []TaskIdentifier
+	// DescendantDepth / 自身の子孫の階層の深さ。子がなければ0。
+	DescendantDepth int
+}
+
+// SubtaskSummary / 子タスクの件数
+type SubtaskSummary struct {
+	Total  int
+	Closed int
+}
+
+// ChecklistItem / タスクに含まれるチェックリストの項目
+type ChecklistItem struct {
+	Text      string
+	Done      bool
+	SortOrder int
+}
+
+type ChecklistItemDescription struct {
+	Text string
+	Done bool
+}
+
+// Open / 未完了の子タスクの件数
+func (m SubtaskSummary) Open() int {
+	return m.Total - m.Closed
+}
+
+// Progress / 完了した子タスクの割合（0〜100）。子タスクがない場合は0。
+func (m SubtaskSummary) Progress() int {
+	if m.Total == 0 {
+		return 0
+	}
+	return m.Closed * 100 / m.Total
+}
+
+// validateHierarchy / 親タスクが同じ企業に属し、循環せず、階層の深さが上限を超えないことを検証する
+func (m *Task) validateHierarchy(hierarchy *TaskHierarchy, workflow *Workflow, setting *CompanySetting) error {
+	if hierarchy == nil || hierarchy.Parent == nil {
+		return nil
+	}
+	parent := hierarchy.Parent
+	if workflow == nil || parent.Creator.Company.ID != workflow.CompanyID {
+		return errInvalidTaskParentCompany
+	}
+	// 新規作成時はIDが未採番のため循環しない
+	if m.ID != 0 {
+		if parent.ID == m.ID {
+			return errInvalidTaskParentCycle
+		}
+		for _, id := range hierarchy.AncestorIDs {
+			if id == m.ID {
+				return errInvalidTaskParentCycle
+			}
+		}
+	}
+	if setting != nil {
+		depth := len(hierarchy.AncestorIDs) + 1 + hierarchy.DescendantDepth
+		if depth > setting.MaxTaskDepth {
+			return errInvalidTaskDepth
+		}
+	}
+	return nil
+}
+
+// validateSubtasksClosed / 設定により、未完了の子タスクがある場合は完了にできないことを検証する
+func (m *Task) validateSubtasksClosed(status *TaskStatus, setting *CompanySetting) error {
+	if setting == nil || !setting.RequireClosedSubtasks {
+		return nil
+	}
+	if status.IsClosed() && !m.Status.IsClosed() && m.Subtasks.Open() > 0 {
+		return errInvalidTaskOpenSubtasks
+	}
+	return nil
+}
+
+func newChecklist(descs []ChecklistItemDescription) []*ChecklistItem {
+	var items []*ChecklistItem
+	for i, desc := range descs {
+		items = append(items, &ChecklistItem{
+			Text:      desc.Text,
+			Done:      desc.Done,
+			SortOrder: i + 1,
+		})
+	}
+	return items
+}
+
+func validateChecklist(descs []ChecklistItemDescription) error {
+	if len(descs) > maxChecklistLength {
+		return errInvalidChecklistLength
+	}
+	for _, desc := range descs {
+		textLength := utf8.RuneCountInString(desc.Text)
+		if textLength < minChecklistItemTextLength || textLength > maxChecklistItemTextLength {
+			return errInvalidChecklistItemLength
+		}
+	}
+	return nil
+}
@@ -11,6 +11,9 @@ var (
 	errInvalidTaskTitleLength  = errors.New("Task Title must be 1 to 50 characters")
 	errInvalidTaskDetailLength = errors.New("Task Detail must be shorter or equal 400 characters")
 	errInvalidTaskAssignment   = errors.New("Task cannot be assigned to an user of other companies")
+	errInvalidTaskWatcher      = errors.New("Task cannot be watched by an user of other companies")
+	errInvalidTaskStartDate    = errors.New("Task StartDate must be before or equal LimitDate")
+	errInvalidTaskDeleteParent = errors.New("Task with subtasks cannot be deleted")
 )
 
 const (
@@ -27,7 +30,36 @@ type Task struct {
 	Status         TaskStatus
 	Visibility     TaskVisibility
 	PersonInCharge *User
+	Priority       *TaskPriority
+	StartDate      *time.Time
 	LimitDate      *time.Time
+	Labels         []*Label
+	ParentID       *TaskIdentifier
+	Checklist      []*ChecklistItem
+	// Assignees / 担当者。主担当者（PersonInCharge）を先頭に含む。
+	Assignees []*User
+	// Watchers / 担当はせずにタスクをフォローするユーザ
+	Watchers []*User
+	// Series / 繰り返しの系列。繰り返さないタスクは nil。
+	Series *TaskSeries
+	// Occurrence / 系列の何回目か。1始まり。
+	Occurrence int
+	// Subtasks / 子タスクの件数。リポジトリで集計され、永続化はされない。
+	Subtasks SubtaskSummary
+	// OpenBlockerIDs / このタスクをブロックしている未完了のタスクのID。リポジトリで集計され、永続化はされない。
+	OpenBlockerIDs []TaskIdentifier
+	// Changes / 保存されていない項目の変更。リポジトリで更新と同じトランザクションで履歴として保存される。
+	Changes []*TaskChange
+	// Events / 保存されていない出来事。リポジトリで更新と同じトランザクションでアウトボックスに記録される。
+	Events []*DomainEvent
+	// Version / 更新のたびに加算される版数
+	Version Version
+	// DeleteAt / 削除日時。削除されたタスクは取得できない。
+	DeleteAt *time.Time
+	// ChangeSeq / 企業内で変更のたびに採番される通し番号。リポジトリで保存時に設定され、差分の同期に使う。
+	ChangeSeq uint64
+	// ExternalRef / 他のツールから取り込んだ課題との対応。リポジトリで作成と同じトランザクションで記録される。
+	ExternalRef *TaskExternalRef
 
 	CreateAt time.Time
 	Creator  User
@@ -41,9 +73,32 @@ type TaskDescription struct {
 	Status         TaskStatus
 	Visibility     TaskVisibility
 	PersonInCharge *User
+	Priority       *TaskPriority
+	StartDate      *time.Time
 	LimitDate      *time.Time
-	Creator        *User
-	Updator        *User
+	Labels         []*Label
+	Checklist      []ChecklistItemDescription
+	// Assignees / 主担当者以外の担当者。PersonInCharge が nil の場合は先頭が主担当者となる。
+	Assignees []*User
+	Watchers  []*User
+	// Hierarchy / 親タスクの情報。nil の場合は最上位のタスクとなる。
+	Hierarchy *TaskHierarchy
+	Creator   *User
+	Updator   *User
+	// Workflow / タスクの所属する企業のワークフロー。ステータスの遷移の検証に利用する。
+	Workflow *Workflow
+	// Setting / タスクの所属する企業の設定。階層の深さと親タスクの完了の検証に利用する。
+	Setting *CompanySetting
+}
+
+// TaskStatusUpdateDescription / ステータスのみの更新に必要な情報
+type TaskStatusUpdateDescription struct {
+	Status   *TaskStatus
+	Workflow *Workflow
+	Setting  *CompanySetting
+	Updator  *User
+	// Force / 未完了のブロッカーがあっても開始・完了できるようにする
+	Force bool
 }
 
 type TaskIdentifier uint64
@@ -55,14 +110,6 @@ const (
 	TaskVisibilityCompany
 )
 
-type TaskStatus int
-
-const (
-	TaskStatusNew TaskStatus = iota + 1
-	TaskStatusProcessing
-	TaskStatusDone
-)
-
 func NewTask(desc TaskDescription) (*Task, apperr.AppErr) {
 	task := new(Task)
 	if err := task.Update(desc); err != nil {
@@ -76,19 +123,45 @@ func (m *Task) Update(desc TaskDescription) apperr.AppErr {
 	if err := desc.validate(); err != nil {
 		return apperr.NewBadRequestError().Wrap(err)
 	}
+	updator := desc.Updator
+	if updator == nil {
+		updator = desc.Creator
+	}
+	if err := m.validateStatus(desc.Workflow, desc.Setting, &desc.Status, updator, false); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+	if err := m.validateHierarchy(desc.Hierarchy, desc.Workflow, desc.Setting); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
 
+	before := m.snapshot()
 	m.Title = desc.Title
 	m.Detail = desc.Detail
 	m.Status = desc.Status
 	m.Visibility = desc.Visibility
-	m.PersonInCharge = desc.PersonInCharge
+	m.Assignees = newAssignees(desc.PersonInCharge, desc.Assignees)
+	m.PersonInCharge = nil
+	if len(m.Assignees) > 0 {
+		m.PersonInCharge = m.Assignees[0]
+	}
+	m.Watchers = uniqueUsers(desc.Watchers)
+	m.Priority = desc.Priority
+	m.StartDate = desc.StartDate
 	m.LimitDate = desc.LimitDate
+	m.Labels = desc.Labels
+	m.Checklist = newChecklist(desc.Checklist)
+	m.ParentID = nil
+	if desc.Hierarchy != nil && desc.Hierarchy.Parent != nil {
+		parentID := desc.Hierarchy.Parent.ID
+		m.ParentID = &parentID
+	}
 	if desc.Creator != nil {
 		m.Creator = *desc.Creator
 	}
 	if desc.Updator != nil {
 		m.Updator = *desc.Updator
 	}
+	m.recordChanges(before)
 	return nil
 }
 
@@ -97,37 +170,191 @@ func (d *TaskDescription) validate() error {
 	if titleLength < minTaskTitleLength || titleLength > maxTaskTitleLength {
 		return errInvalidTaskTitleLength
 	}
-	detailLength := utf8.RuneCountInString(*d.Detail)
-	if detailLength > maxTaskDetailLength {
-		return errInvalidTaskDetailLength
+	if d.Detail != nil {
+		detailLength := utf8.RuneCountInString(*d.Detail)
+		if detailLength > maxTaskDetailLength {
+			return errInvalidTaskDetailLength
+		}
+	}
+	if d.StartDate != nil && d.LimitDate != nil && d.StartDate.After(*d.LimitDate) {
+		return errInvalidTaskStartDate
+	}
+	if d.Priority != nil && d.Workflow != nil && d.Priority.CompanyID != d.Workflow.CompanyID {
+		return errInvalidTaskPriorityCompany
+	}
+	if err := validateChecklist(d.Checklist); err != nil {
+		return err
+	}
+	for _, label := range d.Labels {
+		if d.Workflow != nil && label.CompanyID != d.Workflow.CompanyID {
+			return errInvalidTaskLabel
+		}
 	}
 	if d.PersonInCharge != nil {
-		// CreatorかUpdatorは片方必ず存在する
-		if d.Creator != nil {
-			if d.PersonInCharge.Company.ID != d.Creator.Company.ID {
-				return errInvalidTaskAssignment
-			}
+		if !d.isSameCompany(d.PersonInCharge) {
+			return errInvalidTaskAssignment
 		}
-		if d.Updator != nil {
-			if d.PersonInCharge.Company.ID != d.Updator.Company.ID {
-				return errInvalidTaskAssignment
-			}
+	}
+	for _, assignee := range d.Assignees {
+		if !d.isSameCompany(assignee) {
+			return errInvalidTaskAssignment
+		}
+	}
+	for _, watcher := range d.Watchers {
+		if !d.isSameCompany(watcher) {
+			return errInvalidTaskWatcher
 		}
 	}
 	return nil
 }
 
-func (e TaskStatus) String() string {
-	switch e {
-	case TaskStatusNew:
-		return "NEW"
-	case TaskStatusProcessing:
-		return "PROCESSING"
-	case TaskStatusDone:
-		return "DONE"
-	default:
-		return ""
+// isSameCompany / ユーザが作成者・更新者と同じ企業に所属するかどうか
+func (d *TaskDescription) isSameCompany(user *User) bool {
+	// CreatorかUpdatorは片方必ず存在する
+	if d.Creator != nil && user.Company.ID != d.Creator.Company.ID {
+		return false
+	}
+	if d.Updator != nil && user.Company.ID != d.Updator.Company.ID {
+		return false
+	}
+	return true
+}
+
+// newAssignees / 主担当者を先頭に、重複を除いた担当者を生成する
+func newAssignees(primary *User, assignees []*User) []*User {
+	if primary != nil {
+		assignees = append([]*User{primary}, assignees...)
+	}
+	return uniqueUsers(assignees)
+}
+
+func uniqueUsers(users []*User) []*User {
+	seen := make(map[UserIdentifier]bool, len(users))
+	var unique []*User
+	for _, user := range users {
+		if !seen[user.ID] {
+			seen[user.ID] = true
+			unique = append(unique, user)
+		}
+	}
+	return unique
+}
+
+// IsAssignedTo / ユーザがタスクの担当者のいずれかであるかどうか
+func (m *Task) IsAssignedTo(userID UserIdentifier) bool {
+	for _, assignee := range m.Assignees {
+		if assignee.ID == userID {
+			return true
+		}
 	}
+	return false
+}
+
+// UpdateStatus / ワークフローに従ってステータスのみを更新する
+func (m *Task) UpdateStatus(desc TaskStatusUpdateDescription) apperr.AppErr {
+	if err := m.validateStatus(desc.Workflow, desc.Setting, desc.Status, desc.Updator, desc.Force); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	before := m.snapshot()
+	m.Status = *desc.Status
+	m.Updator = *desc.Updator
+	m.recordChanges(before)
+	return nil
+}
+
+// RemoveLabel / 削除されるラベルをタスクから外し、ラベルの変更として記録する
+func (m *Task) RemoveLabel(id LabelIdentifier, updator *User) {
+	before := m.snapshot()
+	var labels []*Label
+	for _, label := range m.Labels {
+		if label.ID != id {
+			labels = append(labels, label)
+		}
+	}
+	m.Labels = labels
+	m.Updator = *updator
+	m.recordChanges(before)
+}
+
+// AttachSeries / 系列の最初の回とし、繰り返しの開始として記録する
+func (m *Task) AttachSeries(series *TaskSeries, updator *User) {
+	before := m.snapshot()
+	m.Series = series
+	m.Occurrence = 1
+	m.Updator = *updator
+	m.recordChanges(before)
+}
+
+// DetachSeries / 系列から外して繰り返さないタスクとし、繰り返しの終了として記録する
+func (m *Task) DetachSeries(updator *User) {
+	before := m.snapshot()
+	m.Series = nil
+	m.Occurrence = 0
+	m.Updator = *updator
+	m.recordChanges(before)
+}
+
+// Delete / タスクを論理削除する。子タスクがある場合は削除できない。
+func (m *Task) Delete(updator *User, now time.Time) apperr.AppErr {
+	if m.Subtasks.Total > 0 {
+		return apperr.NewBadRequestError().Wrap(errInvalidTaskDeleteParent)
+	}
+
+	m.DeleteAt = &now
+	m.Updator = *updator
+	change := &TaskChange{
+		Action:   TaskChangeActionDelete,
+		Field:    TaskFieldDeleteAt,
+		NewValue: timeValue(&now),
+	}
+	m.Changes = append(m.Changes, change)
+	m.raiseEvents([]*TaskChange{change})
+	return nil
+}
+
+// MarkImported / 保存されていない出来事を他のツールからの取り込みによるものとする
+func (m *Task) MarkImported() {
+	markImported(m.Events)
+}
+
+// IsVisibleTo / ユーザがタスクを閲覧できるかどうか
+func (m *Task) IsVisibleTo(user *User) bool {
+	if user.Company.ID == AdminCompanyID {
+		return true
+	}
+	// 自社のタスクでない場合は閲覧不可
+	if m.Creator.Company.ID != user.Company.ID {
+		return false
+	}
+	// 作成者が自身でなく、会社に公開でない場合は閲覧不可
+	if m.Creator.ID != user.ID && m.Visibility == TaskVisibilityMe {
+		return false
+	}
+	return true
+}
+
+// validateStatus / ステータスがワークフローに存在し、現在のステータスから遷移可能であることを検証する
+func (m *Task) validateStatus(workflow *Workflow, setting *CompanySetting, status *TaskStatus, user *User, force bool) error {
+	if workflow == nil || workflow.FindStatus(status.ID) == nil {
+		return errInvalidTaskStatusCompany
+	}
+	// 新規作成時は遷移の検証を行わない
+	if m.Status.ID == 0 {
+		return nil
+	}
+	if !workflow.CanTransition(m.Status.ID, status.ID, user) {
+		return errInvalidTaskStatusTransition
+	}
+	if err := m.validateSubtasksClosed(status, setting); err != nil {
+		return err
+	}
+	if !force {
+		if err := m.validateBlockers(status); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (e TaskVisibility) String() string {
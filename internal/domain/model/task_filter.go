@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// TaskFilter / タスク一覧の絞り込み条件と並び順
+type TaskFilter struct {
+	StatusIDs     []TaskStatusIdentifier
+	PriorityIDs   []TaskPriorityIdentifier
+	StartDateFrom *time.Time
+	StartDateTo   *time.Time
+	LimitDateFrom *time.Time
+	LimitDateTo   *time.Time
+	LabelIDs      []LabelIdentifier
+	// LabelMatch / LabelIDs の一致方法。未指定の場合はいずれかに一致。
+	LabelMatch LabelMatch
+	// ParentID / 指定した場合はその親タスクの子タスクのみ
+	ParentID *TaskIdentifier
+	// Sorts / 先頭から順に適用する並び順。未指定の場合はID順。
+	Sorts []TaskSort
+}
+
+type TaskSort struct {
+	Key  TaskSortKey
+	Desc bool
+}
+
+type TaskSortKey int
+
+const (
+	TaskSortKeyCreateAt TaskSortKey = iota + 1
+	TaskSortKeyUpdateAt
+	TaskSortKeyStartDate
+	TaskSortKeyLimitDate
+	TaskSortKeyPriority
+)
+
+func (e TaskSortKey) String() string {
+	switch e {
+	case TaskSortKeyCreateAt:
+		return "create_at"
+	case TaskSortKeyUpdateAt:
+		return "update_at"
+	case TaskSortKeyStartDate:
+		return "start_date"
+	case TaskSortKeyLimitDate:
+		return "limit_date"
+	case TaskSortKeyPriority:
+		return "priority"
+	default:
+		return ""
+	}
+}
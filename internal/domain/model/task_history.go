@@ -0,0 +1,207 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskHistory / タスクの項目の変更の記録。追記のみで更新・削除はしない。
+type TaskHistory struct {
+	ID     TaskHistoryIdentifier
+	TaskID TaskIdentifier
+	TaskChange
+
+	CreateAt time.Time
+	Actor    User
+}
+
+// TaskChange / タスクの1項目の変更。値は表示用の文字列で、未設定の場合は nil。
+type TaskChange struct {
+	Action   TaskChangeAction
+	Field    TaskField
+	OldValue *string
+	NewValue *string
+}
+
+type TaskHistoryIdentifier uint64
+
+type TaskChangeAction int
+
+const (
+	TaskChangeActionCreate TaskChangeAction = iota + 1
+	TaskChangeActionUpdate
+	TaskChangeActionStatus
+	TaskChangeActionAssign
+	TaskChangeActionDelete
+)
+
+// TaskField / 変更を記録するタスクの項目
+type TaskField string
+
+const (
+	TaskFieldTitle          TaskField = "title"
+	TaskFieldDetail         TaskField = "detail"
+	TaskFieldStatus         TaskField = "status"
+	TaskFieldVisibility     TaskField = "visibility"
+	TaskFieldPersonInCharge TaskField = "person_in_charge_id"
+	TaskFieldAssignees      TaskField = "assignee_ids"
+	TaskFieldWatchers       TaskField = "watcher_ids"
+	TaskFieldPriority       TaskField = "priority"
+	TaskFieldStartDate      TaskField = "start_date"
+	TaskFieldLimitDate      TaskField = "limit_date"
+	TaskFieldLabels         TaskField = "labels"
+	TaskFieldParent         TaskField = "parent_id"
+	TaskFieldChecklist      TaskField = "checklist"
+	// TaskFieldOccurrence / 繰り返しの系列の何回目か。繰り返さないタスクは未設定。
+	TaskFieldOccurrence TaskField = "occurrence"
+	// TaskFieldDeleteAt / 削除の記録にのみ用いる
+	TaskFieldDeleteAt TaskField = "delete_at"
+)
+
+// taskFields / 変更を記録する順序
+var taskFields = []TaskField{
+	TaskFieldTitle,
+	TaskFieldDetail,
+	TaskFieldStatus,
+	TaskFieldVisibility,
+	TaskFieldPersonInCharge,
+	TaskFieldAssignees,
+	TaskFieldWatchers,
+	TaskFieldPriority,
+	TaskFieldStartDate,
+	TaskFieldLimitDate,
+	TaskFieldLabels,
+	TaskFieldParent,
+	TaskFieldChecklist,
+	TaskFieldOccurrence,
+}
+
+// snapshot / 変更の比較に用いる各項目の表示用の値
+func (m *Task) snapshot() map[TaskField]*string {
+	values := map[TaskField]*string{
+		TaskFieldTitle:      stringValue(m.Title),
+		TaskFieldDetail:     m.Detail,
+		TaskFieldStatus:     stringValue(m.Status.Name),
+		TaskFieldVisibility: stringValue(m.Visibility.String()),
+		TaskFieldStartDate:  timeValue(m.StartDate),
+		TaskFieldLimitDate:  timeValue(m.LimitDate),
+	}
+	if m.PersonInCharge != nil {
+		values[TaskFieldPersonInCharge] = stringValue(strconv.FormatUint(uint64(m.PersonInCharge.ID), 10))
+	}
+	if m.Priority != nil {
+		values[TaskFieldPriority] = stringValue(m.Priority.Name)
+	}
+	if m.ParentID != nil {
+		values[TaskFieldParent] = stringValue(strconv.FormatUint(uint64(*m.ParentID), 10))
+	}
+	values[TaskFieldAssignees] = userIDsValue(m.Assignees)
+	values[TaskFieldWatchers] = userIDsValue(m.Watchers)
+	var labels []string
+	for _, label := range m.Labels {
+		labels = append(labels, label.Name)
+	}
+	sort.Strings(labels)
+	values[TaskFieldLabels] = stringValue(strings.Join(labels, ", "))
+	var checklist []string
+	for _, item := range m.Checklist {
+		mark := "[ ]"
+		if item.Done {
+			mark = "[x]"
+		}
+		checklist = append(checklist, mark+" "+item.Text)
+	}
+	values[TaskFieldChecklist] = stringValue(strings.Join(checklist, "\n"))
+	if m.Series != nil {
+		values[TaskFieldOccurrence] = stringValue(strconv.Itoa(m.Occurrence))
+	}
+	return values
+}
+
+// recordChanges / 変更前の値と現在の値を比較し、変更された項目を Changes に、その出来事を Events に追加する
+func (m *Task) recordChanges(before map[TaskField]*string) {
+	after := m.snapshot()
+	var changes []*TaskChange
+	for _, field := range taskFields {
+		oldValue, newValue := before[field], after[field]
+		if equalValue(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, &TaskChange{
+			Action:   changeAction(m.ID, field),
+			Field:    field,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+	m.Changes = append(m.Changes, changes...)
+	m.raiseEvents(changes)
+}
+
+func changeAction(id TaskIdentifier, field TaskField) TaskChangeAction {
+	switch {
+	case id == 0:
+		return TaskChangeActionCreate
+	case field == TaskFieldStatus:
+		return TaskChangeActionStatus
+	case field == TaskFieldPersonInCharge || field == TaskFieldAssignees:
+		return TaskChangeActionAssign
+	default:
+		return TaskChangeActionUpdate
+	}
+}
+
+// stringValue / 空文字は未設定として扱う
+func stringValue(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// userIDsValue / ユーザIDを昇順にカンマ区切りで並べる
+func userIDsValue(users []*User) *string {
+	var ids []int
+	for _, user := range users {
+		ids = append(ids, int(user.ID))
+	}
+	sort.Ints(ids)
+	var values []string
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return stringValue(strings.Join(values, ", "))
+}
+
+func timeValue(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	return stringValue(t.Format(time.RFC3339))
+}
+
+func equalValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (e TaskChangeAction) String() string {
+	switch e {
+	case TaskChangeActionCreate:
+		return "CREATE"
+	case TaskChangeActionUpdate:
+		return "UPDATE"
+	case TaskChangeActionStatus:
+		return "STATUS"
+	case TaskChangeActionAssign:
+		return "ASSIGN"
+	case TaskChangeActionDelete:
+		return "DELETE"
+	default:
+		return ""
+	}
+}
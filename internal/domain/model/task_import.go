@@ -0,0 +1,233 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// MaxTaskImportRows / 一度に取り込めるタスクの件数の上限
+	MaxTaskImportRows = 10000
+	// maxTaskImportErrors / 記録する行の誤りの件数の上限。超えた分は件数のみを数える。
+	maxTaskImportErrors = 1000
+	// maxTaskImportUnmatchedUsers / 記録する一致しなかったユーザの件数の上限
+	maxTaskImportUnmatchedUsers = 1000
+)
+
+// TaskImportJob / ファイルからのタスクの取り込み。件数が多い場合は非同期に処理し、進捗を記録する。
+type TaskImportJob struct {
+	ID        TaskImportJobIdentifier
+	CompanyID CompanyIdentifier
+	// UserID / 取り込みを依頼したユーザ。作成するタスクの作成者となる。
+	UserID UserIdentifier
+	// Source / 取り込むファイルの形式の元となるツール
+	Source TaskImportSource
+	// DryRun / 検証のみを行い、タスクを作成しない
+	DryRun bool
+	Status TaskImportStatus
+	Rows   []*TaskImportRow
+	// Total / 取り込む行の件数
+	Total int
+	// Processed / 処理を終えた行の件数。中断した場合はこの次の行から再開する。
+	Processed int
+	// Succeeded / 作成した（検証のみの場合は検証を通った）行の件数
+	Succeeded int
+	// Failed / 誤りのあった行の件数
+	Failed int
+	// Skipped / 以前に取り込み済みのため作成しなかった行の件数
+	Skipped int
+	// UnmatchedUsers / 他のツールからの取り込みで企業のユーザと一致せず、割り当てなかったユーザ
+	UnmatchedUsers []string
+	// Errors / 行ごとの誤り。上限を超えた分は記録しない。
+	Errors     []*TaskImportError
+	LastError  *string
+	CreateAt   time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// TaskImportRow / 取り込むファイルの1行を列の対応に従って読んだ値
+type TaskImportRow struct {
+	// Line / ファイルでの行番号。CSV はヘッダを1行目、JSON は配列の要素の1始まりの番号。
+	Line   int
+	Title  string
+	Detail *string
+	// Visibility / 未指定の場合は0で、企業に公開とする
+	Visibility TaskVisibility
+	// PersonInCharge / 主担当者のユーザIDまたは名前
+	PersonInCharge *string
+	// Assignees / 主担当者以外の担当者のユーザIDまたは名前
+	Assignees []string
+	Watchers  []string
+	Priority  *string
+	StartDate *time.Time
+	LimitDate *time.Time
+	// Labels / ラベルの名前
+	Labels   []string
+	ParentID *TaskIdentifier
+	// Status / ステータスの名前。未指定の場合はワークフローの初期ステータス。
+	Status    *string
+	Checklist []ChecklistItemDescription
+	// ExternalID / 他のツールでの課題のID。取り込み済みの課題は再び取り込まない。
+	ExternalID *string
+	// ParentExternalID / 他のツールでの親の課題のID。先に取り込まれたタスクを親とする。
+	ParentExternalID *string
+	Comments         []*TaskImportComment
+	// Errors / ファイルを読んだ時点で見つかった誤り
+	Errors []*TaskImportError
+}
+
+// TaskImportComment / 他のツールから取り込むコメント。取り込んだユーザの投稿として元の投稿者と日時を本文に含める。
+type TaskImportComment struct {
+	Author   string
+	Body     string
+	CreateAt *time.Time
+}
+
+// TaskExternalRef / 他のツールから取り込んだ課題とタスクの対応
+type TaskExternalRef struct {
+	CompanyID  CompanyIdentifier
+	Source     TaskImportSource
+	ExternalID string
+}
+
+// TaskImportError / 取り込む行の誤り
+type TaskImportError struct {
+	Line int
+	// Field / 誤りのある項目。特定できない場合は空。
+	Field   string
+	Message string
+}
+
+type TaskImportJobIdentifier uint64
+
+type TaskImportStatus int
+
+// TaskImportSource / 取り込むファイルの元となるツール
+type TaskImportSource int
+
+const (
+	// TaskImportSourceFile / 項目を列に対応させた CSV または JSON
+	TaskImportSourceFile TaskImportSource = iota + 1
+	// TaskImportSourceTrello / Trello のボードの JSON
+	TaskImportSourceTrello
+	// TaskImportSourceJira / Jira の課題の CSV
+	TaskImportSourceJira
+	// TaskImportSourceGitHub / GitHub の Issue の JSON
+	TaskImportSourceGitHub
+)
+
+const (
+	TaskImportStatusPending TaskImportStatus = iota + 1
+	TaskImportStatusRunning
+	// TaskImportStatusSucceeded / すべての行を処理した。誤りのあった行を含む場合もある。
+	TaskImportStatusSucceeded
+	// TaskImportStatusFailed / 行によらない理由で処理を続けられなかった
+	TaskImportStatusFailed
+)
+
+func NewTaskImportJob(companyID CompanyIdentifier, userID UserIdentifier, source TaskImportSource, rows []*TaskImportRow, dryRun bool, now time.Time) *TaskImportJob {
+	return &TaskImportJob{
+		CompanyID: companyID,
+		UserID:    userID,
+		Source:    source,
+		DryRun:    dryRun,
+		Status:    TaskImportStatusPending,
+		Rows:      rows,
+		Total:     len(rows),
+		CreateAt:  now,
+	}
+}
+
+// Start / 処理を始める。中断していた場合は続きから再開する。
+func (m *TaskImportJob) Start(now time.Time) {
+	m.Status = TaskImportStatusRunning
+	if m.StartedAt == nil {
+		m.StartedAt = &now
+	}
+}
+
+// Remaining / まだ処理していない行
+func (m *TaskImportJob) Remaining() []*TaskImportRow {
+	return m.Rows[min(m.Processed, len(m.Rows)):]
+}
+
+// Succeed / 行の処理が成功したことを記録する
+func (m *TaskImportJob) Succeed() {
+	m.Processed++
+	m.Succeeded++
+}
+
+// Reject / 行の誤りを記録する
+func (m *TaskImportJob) Reject(errs ...*TaskImportError) {
+	m.Processed++
+	m.Failed++
+	for _, err := range errs {
+		if len(m.Errors) >= maxTaskImportErrors {
+			break
+		}
+		m.Errors = append(m.Errors, err)
+	}
+}
+
+// Skip / 取り込み済みのため行を作成しなかったことを記録する
+func (m *TaskImportJob) Skip() {
+	m.Processed++
+	m.Skipped++
+}
+
+// Unmatch / 企業のユーザと一致しなかったユーザを記録する。同じユーザは一度のみ記録する。
+func (m *TaskImportJob) Unmatch(users ...string) {
+	for _, user := range users {
+		if len(m.UnmatchedUsers) >= maxTaskImportUnmatchedUsers {
+			return
+		}
+		if slices.Contains(m.UnmatchedUsers, user) {
+			continue
+		}
+		m.UnmatchedUsers = append(m.UnmatchedUsers, user)
+	}
+}
+
+// Finish / すべての行の処理を終える
+func (m *TaskImportJob) Finish(now time.Time) {
+	m.Status = TaskImportStatusSucceeded
+	m.FinishedAt = &now
+}
+
+// Fail / 処理を続けられなかったことを記録する
+func (m *TaskImportJob) Fail(reason string, now time.Time) {
+	m.Status = TaskImportStatusFailed
+	m.LastError = &reason
+	m.FinishedAt = &now
+}
+
+func (e TaskImportStatus) String() string {
+	switch e {
+	case TaskImportStatusPending:
+		return "PENDING"
+	case TaskImportStatusRunning:
+		return "RUNNING"
+	case TaskImportStatusSucceeded:
+		return "SUCCEEDED"
+	case TaskImportStatusFailed:
+		return "FAILED"
+	default:
+		return ""
+	}
+}
+
+func (e TaskImportSource) String() string {
+	switch e {
+	case TaskImportSourceFile:
+		return "FILE"
+	case TaskImportSourceTrello:
+		return "TRELLO"
+	case TaskImportSourceJira:
+		return "JIRA"
+	case TaskImportSourceGitHub:
+		return "GITHUB"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,233 @@
+package model
+
+import (
+	"errors"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errInvalidTaskLinkType       = errors.New("Task Link Type is invalid")
+	errInvalidTaskLinkSelf       = errors.New("Task Link must connect different tasks")
+	errInvalidTaskLinkCompany    = errors.New("Task Link must connect tasks of the same company")
+	errInvalidTaskLinkDuplicated = errors.New("Task Link already exists")
+	errInvalidTaskLinkCycle      = errors.New("Task Link of BLOCKS cannot make a cycle")
+	errInvalidTaskOpenBlockers   = errors.New("Task with unfinished blockers cannot be started or finished")
+)
+
+// TaskLink / タスク間の関連。BLOCKS の場合は Source が完了するまで Target を開始できない。
+type TaskLink struct {
+	ID        TaskLinkIdentifier
+	CompanyID CompanyIdentifier
+	Type      TaskLinkType
+	SourceID  TaskIdentifier
+	TargetID  TaskIdentifier
+
+	CreateAt time.Time
+	Creator  User
+}
+
+type TaskLinkDescription struct {
+	Type    TaskLinkType
+	Source  *Task
+	Target  *Task
+	Creator *User
+	// Links / 企業に登録済みの関連。重複と循環の検証に利用する。
+	Links TaskLinks
+}
+
+type TaskLinkIdentifier uint64
+
+type TaskLinkType int
+
+const (
+	TaskLinkTypeBlocks TaskLinkType = iota + 1
+	TaskLinkTypeRelatesTo
+	TaskLinkTypeDuplicates
+)
+
+type TaskLinks []*TaskLink
+
+// TaskGraph / タスクの依存関係のグラフ
+type TaskGraph struct {
+	RootID TaskIdentifier
+	Tasks  []*Task
+	Links  TaskLinks
+}
+
+func NewTaskLink(desc TaskLinkDescription) (*TaskLink, apperr.AppErr) {
+	if err := desc.validate(); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+
+	return &TaskLink{
+		CompanyID: desc.Source.Creator.Company.ID,
+		Type:      desc.Type,
+		SourceID:  desc.Source.ID,
+		TargetID:  desc.Target.ID,
+		Creator:   *desc.Creator,
+	}, nil
+}
+
+func (d *TaskLinkDescription) validate() error {
+	if d.Type.String() == "" {
+		return errInvalidTaskLinkType
+	}
+	if d.Source.ID == d.Target.ID {
+		return errInvalidTaskLinkSelf
+	}
+	if d.Source.Creator.Company.ID != d.Target.Creator.Company.ID {
+		return errInvalidTaskLinkCompany
+	}
+	return d.Links.validateNew(d.Type, d.Source.ID, d.Target.ID)
+}
+
+// Validate / 登録済みの関連に対して重複と循環がないことを検証する。
+// 同時に作成された関連で循環しないように、リポジトリで作成と同じトランザクションで再び検証する。
+func (m *TaskLink) Validate(links TaskLinks) apperr.AppErr {
+	if err := links.validateNew(m.Type, m.SourceID, m.TargetID); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+	return nil
+}
+
+// Touches / 関連が指定したタスクに接続しているかどうか
+func (m *TaskLink) Touches(id TaskIdentifier) bool {
+	return m.SourceID == id || m.TargetID == id
+}
+
+// Find / 種類と接続するタスクから関連を取得する
+func (m TaskLinks) Find(linkType TaskLinkType, sourceID, targetID TaskIdentifier) *TaskLink {
+	for _, link := range m {
+		if link.Type == linkType && link.SourceID == sourceID && link.TargetID == targetID {
+			return link
+		}
+	}
+	return nil
+}
+
+// validateNew / 関連を加えても重複と BLOCKS の循環がないかどうか
+func (m TaskLinks) validateNew(linkType TaskLinkType, sourceID, targetID TaskIdentifier) error {
+	if m.Find(linkType, sourceID, targetID) != nil {
+		return errInvalidTaskLinkDuplicated
+	}
+	// RELATES_TO は向きを持たない
+	if linkType == TaskLinkTypeRelatesTo && m.Find(linkType, targetID, sourceID) != nil {
+		return errInvalidTaskLinkDuplicated
+	}
+	// Target から Source へ BLOCKS を辿れる場合は循環する
+	if linkType == TaskLinkTypeBlocks && m.blocks(targetID, sourceID) {
+		return errInvalidTaskLinkCycle
+	}
+	return nil
+}
+
+// blocks / from から BLOCKS を辿って to に到達できるかどうか
+func (m TaskLinks) blocks(from, to TaskIdentifier) bool {
+	visited := map[TaskIdentifier]bool{from: true}
+	queue := []TaskIdentifier{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, link := range m {
+			if link.Type != TaskLinkTypeBlocks || link.SourceID != id || visited[link.TargetID] {
+				continue
+			}
+			if link.TargetID == to {
+				return true
+			}
+			visited[link.TargetID] = true
+			queue = append(queue, link.TargetID)
+		}
+	}
+	return false
+}
+
+// Dependencies / タスクの依存関係のグラフを構成する関連。
+// BLOCKS は上流・下流の両方向に推移的に辿り、それ以外の関連はタスクに直接接続するもののみを含む。
+func (m TaskLinks) Dependencies(id TaskIdentifier) TaskLinks {
+	var links TaskLinks
+	added := map[TaskLinkIdentifier]bool{}
+	visited := map[TaskIdentifier]bool{id: true}
+	queue := []TaskIdentifier{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, link := range m {
+			if link.Type != TaskLinkTypeBlocks || !link.Touches(current) || added[link.ID] {
+				continue
+			}
+			added[link.ID] = true
+			links = append(links, link)
+			for _, next := range []TaskIdentifier{link.SourceID, link.TargetID} {
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+	for _, link := range m {
+		if link.Type != TaskLinkTypeBlocks && link.Touches(id) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// TaskIDs / 関連に含まれるタスクのID
+func (m TaskLinks) TaskIDs() []TaskIdentifier {
+	var ids []TaskIdentifier
+	seen := map[TaskIdentifier]bool{}
+	for _, link := range m {
+		for _, id := range []TaskIdentifier{link.SourceID, link.TargetID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// NewTaskGraph / ユーザが閲覧できるタスクとその間の関連のみでグラフを構成する
+func NewTaskGraph(rootID TaskIdentifier, links TaskLinks, tasks []*Task, user *User) *TaskGraph {
+	graph := &TaskGraph{RootID: rootID}
+	visible := map[TaskIdentifier]bool{}
+	for _, task := range tasks {
+		if task.IsVisibleTo(user) {
+			visible[task.ID] = true
+			graph.Tasks = append(graph.Tasks, task)
+		}
+	}
+	for _, link := range links {
+		if visible[link.SourceID] && visible[link.TargetID] {
+			graph.Links = append(graph.Links, link)
+		}
+	}
+	return graph
+}
+
+// validateBlockers / 未完了のブロッカーがある場合に開始・完了できないことを検証する
+func (m *Task) validateBlockers(status *TaskStatus) error {
+	if len(m.OpenBlockerIDs) == 0 || status.Category == m.Status.Category {
+		return nil
+	}
+	if status.Category == TaskStatusCategoryInProgress || status.Category == TaskStatusCategoryClosed {
+		return errInvalidTaskOpenBlockers
+	}
+	return nil
+}
+
+func (e TaskLinkType) String() string {
+	switch e {
+	case TaskLinkTypeBlocks:
+		return "BLOCKS"
+	case TaskLinkTypeRelatesTo:
+		return "RELATES_TO"
+	case TaskLinkTypeDuplicates:
+		return "DUPLICATES"
+	default:
+		return ""
+	}
+}
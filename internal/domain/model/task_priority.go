@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidTaskPriorityNameLength = errors.New("Task Priority Name must be 1 to 10 characters")
+	errInvalidTaskPriorityLevel      = errors.New("Task Priority Level must be greater than or equal 1")
+	errInvalidTaskPriorityCompany    = errors.New("Task Priority must belong to the same company as the task")
+)
+
+const (
+	minTaskPriorityNameLength = 1
+	maxTaskPriorityNameLength = 10
+	minTaskPriorityLevel      = 1
+)
+
+// TaskPriority / 企業ごとに定義されるタスクの優先度。Level が大きいほど優先度が高い。
+type TaskPriority struct {
+	ID        TaskPriorityIdentifier
+	CompanyID CompanyIdentifier
+	Name      string
+	Level     int
+}
+
+type TaskPriorityDescription struct {
+	Name  string
+	Level int
+}
+
+type TaskPriorityIdentifier uint64
+
+// TaskPriorities / 企業の優先度の一覧
+type TaskPriorities []*TaskPriority
+
+// 企業作成時に設定される優先度
+var defaultTaskPriorities = []TaskPriorityDescription{
+	{Name: "LOW", Level: 1},
+	{Name: "MEDIUM", Level: 2},
+	{Name: "HIGH", Level: 3},
+	{Name: "URGENT", Level: 4},
+}
+
+func NewTaskPriority(companyID CompanyIdentifier, desc TaskPriorityDescription) (*TaskPriority, apperr.AppErr) {
+	priority := &TaskPriority{
+		CompanyID: companyID,
+	}
+	if err := priority.Update(desc); err != nil {
+		return nil, err
+	}
+
+	return priority, nil
+}
+
+func (m *TaskPriority) Update(desc TaskPriorityDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	m.Name = desc.Name
+	m.Level = desc.Level
+	return nil
+}
+
+func (d *TaskPriorityDescription) validate() error {
+	nameLength := utf8.RuneCountInString(d.Name)
+	if nameLength < minTaskPriorityNameLength || nameLength > maxTaskPriorityNameLength {
+		return errInvalidTaskPriorityNameLength
+	}
+	if d.Level < minTaskPriorityLevel {
+		return errInvalidTaskPriorityLevel
+	}
+	return nil
+}
+
+// NewDefaultTaskPriorities / 企業の既定の優先度を生成する
+func NewDefaultTaskPriorities(companyID CompanyIdentifier) TaskPriorities {
+	var priorities TaskPriorities
+	for _, desc := range defaultTaskPriorities {
+		priorities = append(priorities, &TaskPriority{
+			CompanyID: companyID,
+			Name:      desc.Name,
+			Level:     desc.Level,
+		})
+	}
+	return priorities
+}
+
+// Find / IDから優先度を取得する
+func (m TaskPriorities) Find(id TaskPriorityIdentifier) *TaskPriority {
+	for _, priority := range m {
+		if priority.ID == id {
+			return priority
+		}
+	}
+	return nil
+}
+
+// FindByName / 名前から優先度を取得する。大文字小文字は区別しない。
+func (m TaskPriorities) FindByName(name string) *TaskPriority {
+	for _, priority := range m {
+		if strings.EqualFold(priority.Name, name) {
+			return priority
+		}
+	}
+	return nil
+}
+
+// Sorted / 優先度の低い順に並べた一覧
+func (m TaskPriorities) Sorted() TaskPriorities {
+	priorities := make(TaskPriorities, len(m))
+	copy(priorities, m)
+	sort.SliceStable(priorities, func(i, j int) bool {
+		return priorities[i].Level < priorities[j].Level
+	})
+	return priorities
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// TaskReminder / タスクの期限を担当者に知らせるリマインダー。
+// 同じタスク・担当者・期限・通知時機のリマインダーは一度のみ送る。
+type TaskReminder struct {
+	ID     TaskReminderIdentifier
+	TaskID TaskIdentifier
+	UserID UserIdentifier
+	// Offset / 期限のどれだけ前に送るか。0 以下の場合は期限切れの通知となる。
+	Offset time.Duration
+	// LimitDate / 送った時点の期限
+	LimitDate time.Time
+	CreateAt  time.Time
+}
+
+type TaskReminderIdentifier uint64
+
+// NewTaskReminders / 通知時機を迎えた担当者ごとのリマインダーを生成する。
+// 完了・削除済みのタスクと、lookback より前に通知時機を過ぎたもの（停止中に送り損ねたもの）、
+// タスクの作成より前に通知時機を過ぎたものと、タスクを閲覧できない担当者へのものは生成しない。
+func NewTaskReminders(task *Task, offsets []time.Duration, now time.Time, lookback time.Duration) []*TaskReminder {
+	if task.LimitDate == nil || task.Status.IsClosed() || task.DeleteAt != nil {
+		return nil
+	}
+	assignees := task.Assignees
+	if len(assignees) == 0 && task.PersonInCharge != nil {
+		assignees = []*User{task.PersonInCharge}
+	}
+
+	var reminders []*TaskReminder
+	for _, offset := range offsets {
+		remindAt := task.LimitDate.Add(-offset)
+		if remindAt.After(now) || !remindAt.After(now.Add(-lookback)) || remindAt.Before(task.CreateAt) {
+			continue
+		}
+		for _, assignee := range assignees {
+			// 通知と同じく、閲覧できないタスクの題名を送らない
+			if !task.IsVisibleTo(assignee) {
+				continue
+			}
+			reminders = append(reminders, &TaskReminder{
+				TaskID:    task.ID,
+				UserID:    assignee.ID,
+				Offset:    offset,
+				LimitDate: *task.LimitDate,
+				CreateAt:  now,
+			})
+		}
+	}
+	return reminders
+}
+
+// IsOverdue / 期限切れの通知かどうか
+func (m *TaskReminder) IsOverdue() bool {
+	return m.Offset <= 0
+}
@@ -0,0 +1,230 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errInvalidRecurrenceFrequency = errors.New("Recurrence Frequency must be DAILY, WEEKLY or MONTHLY")
+	errInvalidRecurrenceInterval  = errors.New("Recurrence Interval must be 1 to 365")
+	errInvalidRecurrenceWeekdays  = errors.New("Recurrence Weekdays can be specified only for WEEKLY")
+	errInvalidRecurrenceEnd       = errors.New("Recurrence cannot have both Until and Count")
+	errInvalidRecurrenceCount     = errors.New("Recurrence Count must be 1 to 1000")
+	errInvalidRecurrenceLimitDate = errors.New("Task must have LimitDate to recur")
+	errInvalidRecurrenceTemplate  = errors.New("Task is already a part of another series")
+)
+
+const (
+	minRecurrenceInterval = 1
+	maxRecurrenceInterval = 365
+	minRecurrenceCount    = 1
+	maxRecurrenceCount    = 1000
+)
+
+// TaskSeries / 繰り返しのタスクの系列。各回のタスクは期限をずらして生成する。
+type TaskSeries struct {
+	ID         TaskSeriesIdentifier
+	Recurrence Recurrence
+	// Anchor / 最初の回の期限。月ごとの繰り返しの日付の基準とする。
+	Anchor time.Time
+	// TemplateTaskID / 次の回の内容の元とするタスク。「以降のすべて」の編集で切り替わる。
+	TemplateTaskID TaskIdentifier
+}
+
+// Recurrence / RRULE (RFC 5545) の FREQ, INTERVAL, BYDAY, UNTIL, COUNT に相当する繰り返しの規則
+type Recurrence struct {
+	Frequency RecurrenceFrequency
+	// Interval / 繰り返しの間隔。2 の場合は隔日・隔週・隔月となる。
+	Interval int
+	// Weekdays / 週ごとの繰り返しで期限とする曜日。未指定の場合は最初の回と同じ曜日。
+	Weekdays []time.Weekday
+	// Until / この日時より後の回は生成しない
+	Until *time.Time
+	// Count / 最初の回を含めて生成する回数の上限
+	Count *int
+}
+
+// TaskOccurrence / 生成する回の番号と日付
+type TaskOccurrence struct {
+	Number    int
+	StartDate *time.Time
+	LimitDate time.Time
+}
+
+type TaskSeriesIdentifier uint64
+
+type RecurrenceFrequency int
+
+const (
+	RecurrenceFrequencyDaily RecurrenceFrequency = iota + 1
+	RecurrenceFrequencyWeekly
+	RecurrenceFrequencyMonthly
+)
+
+// NewTaskSeries / タスクを最初の回とする系列を生成する
+func NewTaskSeries(task *Task, recurrence Recurrence) (*TaskSeries, apperr.AppErr) {
+	if task.Series != nil {
+		return nil, apperr.NewConflictError().Wrap(errInvalidRecurrenceTemplate)
+	}
+	if task.LimitDate == nil {
+		return nil, apperr.NewBadRequestError().Wrap(errInvalidRecurrenceLimitDate)
+	}
+	series := &TaskSeries{
+		Anchor:         *task.LimitDate,
+		TemplateTaskID: task.ID,
+	}
+	if err := series.Update(recurrence); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// Update / 繰り返しの規則を置き換える。まだ生成していない回から適用される。
+func (m *TaskSeries) Update(recurrence Recurrence) apperr.AppErr {
+	if err := recurrence.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+	slices.Sort(recurrence.Weekdays)
+	recurrence.Weekdays = slices.Compact(recurrence.Weekdays)
+	m.Recurrence = recurrence
+	return nil
+}
+
+func (r *Recurrence) validate() error {
+	switch r.Frequency {
+	case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly, RecurrenceFrequencyMonthly:
+	default:
+		return errInvalidRecurrenceFrequency
+	}
+	if r.Interval < minRecurrenceInterval || r.Interval > maxRecurrenceInterval {
+		return errInvalidRecurrenceInterval
+	}
+	if len(r.Weekdays) > 0 && r.Frequency != RecurrenceFrequencyWeekly {
+		return errInvalidRecurrenceWeekdays
+	}
+	if r.Until != nil && r.Count != nil {
+		return errInvalidRecurrenceEnd
+	}
+	if r.Count != nil && (*r.Count < minRecurrenceCount || *r.Count > maxRecurrenceCount) {
+		return errInvalidRecurrenceCount
+	}
+	return nil
+}
+
+// NextOccurrence / 前の回に続く回の番号と日付を求める。系列が終了している場合は false を返す。
+// 開始日は前の回の開始日から期限までの長さを保つ。
+func (m *TaskSeries) NextOccurrence(previous *Task) (*TaskOccurrence, bool) {
+	if previous.LimitDate == nil {
+		return nil, false
+	}
+	number := previous.Occurrence + 1
+	if m.Recurrence.Count != nil && number > *m.Recurrence.Count {
+		return nil, false
+	}
+	limitDate := m.Recurrence.next(*previous.LimitDate, m.Anchor)
+	if m.Recurrence.Until != nil && limitDate.After(*m.Recurrence.Until) {
+		return nil, false
+	}
+	occurrence := &TaskOccurrence{
+		Number:    number,
+		LimitDate: limitDate,
+	}
+	if previous.StartDate != nil {
+		startDate := limitDate.Add(previous.StartDate.Sub(*previous.LimitDate))
+		occurrence.StartDate = &startDate
+	}
+	return occurrence, true
+}
+
+// next / 日時 t の次の回の日時。時刻は t のものを保つ。
+func (r *Recurrence) next(t, anchor time.Time) time.Time {
+	switch r.Frequency {
+	case RecurrenceFrequencyWeekly:
+		if len(r.Weekdays) == 0 {
+			return t.AddDate(0, 0, 7*r.Interval)
+		}
+		// 同じ週（月曜始まり）の残りの曜日を探し、なければ間隔を空けた週の最初の曜日とする
+		for d := t.AddDate(0, 0, 1); weekdayIndex(d.Weekday()) > weekdayIndex(t.Weekday()); d = d.AddDate(0, 0, 1) {
+			if slices.Contains(r.Weekdays, d.Weekday()) {
+				return d
+			}
+		}
+		monday := t.AddDate(0, 0, -weekdayIndex(t.Weekday())+7*r.Interval)
+		for i := 0; i < 7; i++ {
+			if d := monday.AddDate(0, 0, i); slices.Contains(r.Weekdays, d.Weekday()) {
+				return d
+			}
+		}
+		return monday
+	case RecurrenceFrequencyMonthly:
+		// 月末をまたいで日付がずれないよう、基準日の日付をその月の日数に収める
+		first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		first = first.AddDate(0, r.Interval, 0)
+		day := min(anchor.Day(), first.AddDate(0, 1, -1).Day())
+		return first.AddDate(0, 0, day-1)
+	default:
+		return t.AddDate(0, 0, r.Interval)
+	}
+}
+
+// weekdayIndex / 月曜を0とする曜日の順序
+func weekdayIndex(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// String / RRULE 形式の文字列
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Frequency.String(), "INTERVAL=" + strconv.Itoa(r.Interval)}
+	if len(r.Weekdays) > 0 {
+		var days []string
+		for _, d := range r.Weekdays {
+			days = append(days, weekdayName(d))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// weekdayName / RRULE の BYDAY で用いる曜日の2文字の名前
+func weekdayName(d time.Weekday) string {
+	return weekdayNames[d]
+}
+
+func (e RecurrenceFrequency) String() string {
+	switch e {
+	case RecurrenceFrequencyDaily:
+		return "DAILY"
+	case RecurrenceFrequencyWeekly:
+		return "WEEKLY"
+	case RecurrenceFrequencyMonthly:
+		return "MONTHLY"
+	default:
+		return ""
+	}
+}
+
+// NewTaskOccurrence / 系列の次の回のタスクを生成する。日付は回の日付で置き換える。
+func NewTaskOccurrence(series *TaskSeries, occurrence *TaskOccurrence, desc TaskDescription) (*Task, apperr.AppErr) {
+	desc.StartDate = occurrence.StartDate
+	desc.LimitDate = &occurrence.LimitDate
+	task, err := NewTask(desc)
+	if err != nil {
+		return nil, err
+	}
+	task.Series = series
+	task.Occurrence = occurrence.Number
+	return task, nil
+}
@@ -0,0 +1,89 @@
+package model
+
+import "time"
+
+// TaskStreamEvent / 接続中のクライアントにリアルタイムに送るタスクの変更。
+// 閲覧できるかどうかを判定するため、変更を配信した時点のタスクの作成者と公開範囲を持つ。
+type TaskStreamEvent struct {
+	// ID / ブローカーが採番する通し番号。再接続時の Last-Event-ID に使う。
+	ID         TaskStreamEventIdentifier
+	Type       DomainEventType
+	CompanyID  CompanyIdentifier
+	TaskID     TaskIdentifier
+	CreatorID  UserIdentifier
+	Visibility TaskVisibility
+	ActorID    *UserIdentifier
+	Changes    []*DomainEventChange
+	OccurredAt time.Time
+	// Removed / 公開範囲の変更で閲覧できなくなったユーザに、タスクを端末から削除させるための変更かどうか
+	Removed bool
+}
+
+type TaskStreamEventIdentifier uint64
+
+// NewTaskStreamEvent / タスクの出来事から送る変更を作成する
+func NewTaskStreamEvent(event *DomainEvent, task *Task) *TaskStreamEvent {
+	return &TaskStreamEvent{
+		Type:       event.Type,
+		CompanyID:  task.Creator.Company.ID,
+		TaskID:     task.ID,
+		CreatorID:  task.Creator.ID,
+		Visibility: task.Visibility,
+		ActorID:    event.ActorID,
+		Changes:    event.Changes,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// IsVisibleTo / ユーザが変更を受け取れるかどうか。タスクの閲覧の可否と同じ規則で判定する。
+func (m *TaskStreamEvent) IsVisibleTo(user *User) bool {
+	task := Task{
+		Creator:    User{ID: m.CreatorID, Company: Company{ID: m.CompanyID}},
+		Visibility: m.Visibility,
+	}
+	return task.IsVisibleTo(user)
+}
+
+// WasVisibleTo / 公開範囲を変更する前に、ユーザがタスクを閲覧できたかどうか。公開範囲を変更していない場合は false とする。
+func (m *TaskStreamEvent) WasVisibleTo(user *User) bool {
+	for _, change := range m.Changes {
+		if change.Field != string(TaskFieldVisibility) || change.OldValue == nil {
+			continue
+		}
+		task := Task{
+			Creator: User{ID: m.CreatorID, Company: Company{ID: m.CompanyID}},
+		}
+		switch *change.OldValue {
+		case TaskVisibilityMe.String():
+			task.Visibility = TaskVisibilityMe
+		case TaskVisibilityCompany.String():
+			task.Visibility = TaskVisibilityCompany
+		default:
+			return false
+		}
+		return task.IsVisibleTo(user)
+	}
+	return false
+}
+
+// Removal / 閲覧できなくなったことを知らせる変更を作成する。変更後の値は閲覧できないため含めない。
+func (m *TaskStreamEvent) Removal() *TaskStreamEvent {
+	return &TaskStreamEvent{
+		ID:         m.ID,
+		Type:       m.Type,
+		CompanyID:  m.CompanyID,
+		TaskID:     m.TaskID,
+		CreatorID:  m.CreatorID,
+		Visibility: m.Visibility,
+		OccurredAt: m.OccurredAt,
+		Removed:    true,
+	}
+}
+
+// TypeName / クライアントに送る変更の種類。閲覧できなくなったことを知らせる変更は task.removed とする。
+func (m *TaskStreamEvent) TypeName() string {
+	if m.Removed {
+		return "task.removed"
+	}
+	return m.Type.String()
+}
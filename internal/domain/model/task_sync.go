@@ -0,0 +1,24 @@
+package model
+
+// TaskChangeSet / 差分の同期で返す、カーソルより後に変更されたタスク
+type TaskChangeSet struct {
+	// Tasks / 作成または更新され、ユーザが閲覧できるタスク
+	Tasks []*Task
+	// Tombstones / 削除された、またはユーザが閲覧できなくなったタスクのID
+	Tombstones []TaskIdentifier
+	// Cursor / 次の同期で指定するカーソル
+	Cursor uint64
+	// HasMore / 続きがあるかどうか。ある場合は Cursor を指定してすぐに取得し直す。
+	HasMore bool
+}
+
+// Add / 変更されたタスクを加える。削除されたか閲覧できないタスクは、tombstone が true の場合のみ ID を加える。
+func (m *TaskChangeSet) Add(task *Task, user *User, tombstone bool) {
+	if task.DeleteAt == nil && task.IsVisibleTo(user) {
+		m.Tasks = append(m.Tasks, task)
+		return
+	}
+	if tombstone {
+		m.Tombstones = append(m.Tombstones, task.ID)
+	}
+}
@@ -21,6 +21,8 @@ type User struct {
 	Role     UserRole
 	UserType UserType
 	Company  Company
+	// Version / 更新のたびに加算される版数
+	Version Version
 }
 
 type UserDescription struct {
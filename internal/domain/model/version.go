@@ -0,0 +1,21 @@
+package model
+
+import (
+	"errors"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errVersionMismatch = errors.New("resource has been modified by another request")
+)
+
+// Version / 更新のたびに加算される版数。楽観的排他制御に利用する。
+type Version uint64
+
+// Validate / 期待する版数と一致することを検証する。期待する版数が指定されていない場合は検証しない。
+func (v Version) Validate(expected *Version) apperr.AppErr {
+	if expected == nil || *expected == v {
+		return nil
+	}
+	return apperr.NewPreconditionFailedError().Wrap(errVersionMismatch)
+}
@@ -0,0 +1,400 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo_api/internal/lib/apperr"
+)
+
+var (
+	errInvalidWebhookURL        = errors.New("Webhook URL must be an absolute http or https URL of up to 255 characters")
+	errInvalidWebhookEventTypes = errors.New("Webhook must subscribe to at least one event type")
+	errInvalidWebhookHost       = errors.New("Webhook URL must not point to a loopback, private or link-local address")
+)
+
+// nonPublicWebhookPrefixes / IsPrivate などで判定できない、外部に公開されていないアドレスの範囲
+var nonPublicWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+const (
+	maxWebhookURLLength = 255
+	// maxWebhookFailures / 連続して配信に失敗した場合に Webhook を無効にする回数
+	maxWebhookFailures = 20
+	// maxWebhookAttempts / 一つの配信を試みる回数の上限。超えた場合は失敗とする。
+	maxWebhookAttempts = 8
+	// webhookRetryBase / 再送までの間隔の初期値。失敗のたびに倍にする。
+	webhookRetryBase = time.Minute
+	webhookRetryMax  = 6 * time.Hour
+)
+
+// Webhook / 企業の出来事を通知する外部のエンドポイント
+type Webhook struct {
+	ID        WebhookIdentifier
+	CompanyID CompanyIdentifier
+	URL       string
+	// Secret / ペイロードの署名の鍵
+	Secret     string
+	EventTypes []WebhookEventType
+	Active     bool
+	// Failures / 連続して配信に失敗した回数。成功すると 0 に戻る。
+	Failures int
+	// DisabledAt / 失敗が続いたために無効にした日時
+	DisabledAt *time.Time
+
+	CreateAt time.Time
+	UpdateAt time.Time
+}
+
+type WebhookDescription struct {
+	URL        string
+	EventTypes []WebhookEventType
+	Active     bool
+}
+
+type WebhookIdentifier uint64
+
+type WebhookEventType int
+
+const (
+	WebhookEventTypeTaskCreated WebhookEventType = iota + 1
+	WebhookEventTypeTaskUpdated
+	WebhookEventTypeTaskStatusChanged
+	WebhookEventTypeTaskDeleted
+	WebhookEventTypeCommentCreated
+	WebhookEventTypeUserCreated
+)
+
+// WebhookEvent / Webhook で通知する出来事。種類に応じて Task、Comment、User のいずれかを持つ。
+type WebhookEvent struct {
+	// ID / 出来事の識別子。再送しても変わらない。
+	ID         string
+	Type       WebhookEventType
+	CompanyID  CompanyIdentifier
+	OccurredAt time.Time
+	Actor      User
+	Task       *Task
+	// Changes / タスクの更新で変更された項目
+	Changes []*TaskChange
+	Comment *Comment
+	User    *User
+}
+
+// WebhookDelivery / Webhook への1件の配信。送信はアウトボックスを介して非同期に行い、結果を記録する。
+type WebhookDelivery struct {
+	ID        WebhookDeliveryIdentifier
+	WebhookID WebhookIdentifier
+	EventID   string
+	EventType WebhookEventType
+	// Payload / 送信する JSON。再送でも同じ内容を送る。
+	Payload string
+	Status  WebhookDeliveryStatus
+	// Attempts / 送信を試みた回数
+	Attempts      int
+	NextAttemptAt time.Time
+	// ResponseCode / 最後の送信の応答のステータスコード。応答がなかった場合は nil。
+	ResponseCode *int
+	LastError    *string
+	// RedeliveryOf / 手動で再送した元の配信
+	RedeliveryOf *WebhookDeliveryIdentifier
+	CreateAt     time.Time
+	DeliveredAt  *time.Time
+}
+
+type WebhookDeliveryIdentifier uint64
+
+type WebhookDeliveryStatus int
+
+const (
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = iota + 1
+	WebhookDeliveryStatusSucceeded
+	WebhookDeliveryStatusFailed
+)
+
+// WebhookEventTypes / 購読できる出来事の種類
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventTypeTaskCreated,
+	WebhookEventTypeTaskUpdated,
+	WebhookEventTypeTaskStatusChanged,
+	WebhookEventTypeTaskDeleted,
+	WebhookEventTypeCommentCreated,
+	WebhookEventTypeUserCreated,
+}
+
+// NewWebhook / 署名の鍵を生成して Webhook を作成する
+func NewWebhook(companyID CompanyIdentifier, desc WebhookDescription) (*Webhook, apperr.AppErr) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook := &Webhook{
+		CompanyID: companyID,
+		Secret:    secret,
+	}
+	if err := webhook.Update(desc); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Update / 設定を更新する。無効になった Webhook を有効に戻した場合は失敗の回数を戻す。
+func (m *Webhook) Update(desc WebhookDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	var eventTypes []WebhookEventType
+	for _, eventType := range WebhookEventTypes {
+		if slices.Contains(desc.EventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	if desc.Active && !m.Active {
+		m.Failures = 0
+		m.DisabledAt = nil
+	}
+	m.URL = desc.URL
+	m.EventTypes = eventTypes
+	m.Active = desc.Active
+	return nil
+}
+
+func (d *WebhookDescription) validate() error {
+	if len(d.URL) > maxWebhookURLLength {
+		return errInvalidWebhookURL
+	}
+	u, err := url.Parse(d.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidWebhookURL
+	}
+	// 内部のサーバに送らせないよう、ループバックやプライベートのアドレスを拒否する。
+	// 名前から解決されるアドレスは送信時に検証する。
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errInvalidWebhookHost
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicWebhookAddr(addr) {
+		return errInvalidWebhookHost
+	}
+	if len(d.EventTypes) == 0 {
+		return errInvalidWebhookEventTypes
+	}
+	return nil
+}
+
+// IsPublicWebhookAddr / Webhook の送信先として許可する、外部に公開されたアドレスかどうか
+func IsPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicWebhookPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscribes / 出来事を配信するかどうか
+func (m *Webhook) Subscribes(eventType WebhookEventType) bool {
+	return m.Active && slices.Contains(m.EventTypes, eventType)
+}
+
+// Sign / 送信日時とペイロードの署名。HMAC-SHA256 で "{送信日時のUNIX秒}.{ペイロード}" を署名し、16進数で返す。
+func (m *Webhook) Sign(timestamp time.Time, payload string) string {
+	mac := hmac.New(sha256.New, []byte(m.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10) + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Succeed / 配信の成功を記録し、失敗の回数を戻す
+func (m *Webhook) Succeed() {
+	m.Failures = 0
+}
+
+// Fail / 配信の失敗を記録する。上限の回数まで続いた場合は無効にする。
+func (m *Webhook) Fail(now time.Time) {
+	m.Failures++
+	if m.Active && m.Failures >= maxWebhookFailures {
+		m.Active = false
+		m.DisabledAt = &now
+	}
+}
+
+func newWebhookSecret() (string, apperr.AppErr) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperr.NewInternalServerError().Wrap(err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newWebhookEvent / アウトボックスの出来事から生成する。識別子は出来事から決めるため、出来事が再び配信されても変わらない。
+func newWebhookEvent(eventType WebhookEventType, source *DomainEvent, actor User) *WebhookEvent {
+	return &WebhookEvent{
+		ID:         fmt.Sprintf("%032x", uint64(source.ID)),
+		Type:       eventType,
+		CompanyID:  source.CompanyID,
+		OccurredAt: source.OccurredAt,
+		Actor:      actor,
+	}
+}
+
+// NewTaskWebhookEvent / タスクの出来事から生成する。作成者のみに公開のタスクは nil を返す。
+// task は配信する時点のタスクで、変更された項目は出来事に記録されたものを送る。
+func NewTaskWebhookEvent(source *DomainEvent, task *Task, actor User) *WebhookEvent {
+	if task.Visibility != TaskVisibilityCompany {
+		return nil
+	}
+
+	var eventType WebhookEventType
+	switch source.Type {
+	case DomainEventTypeTaskCreated:
+		eventType = WebhookEventTypeTaskCreated
+	case DomainEventTypeTaskUpdated:
+		eventType = WebhookEventTypeTaskUpdated
+	case DomainEventTypeTaskStatusChanged:
+		eventType = WebhookEventTypeTaskStatusChanged
+	case DomainEventTypeTaskDeleted:
+		eventType = WebhookEventTypeTaskDeleted
+	default:
+		return nil
+	}
+
+	event := newWebhookEvent(eventType, source, actor)
+	event.Task = task
+	if eventType != WebhookEventTypeTaskCreated {
+		event.Changes = source.TaskChanges()
+	}
+	return event
+}
+
+// NewCommentWebhookEvent / コメントの投稿の出来事から生成する。作成者のみに公開のタスクへのコメントは nil を返す。
+func NewCommentWebhookEvent(source *DomainEvent, task *Task, comment *Comment) *WebhookEvent {
+	if source.Type != DomainEventTypeCommentCreated || task.Visibility != TaskVisibilityCompany {
+		return nil
+	}
+	event := newWebhookEvent(WebhookEventTypeCommentCreated, source, comment.Author)
+	event.Task = task
+	event.Comment = comment
+	return event
+}
+
+// NewUserWebhookEvent / ユーザの作成の出来事から生成する
+func NewUserWebhookEvent(source *DomainEvent, user *User, creator User) *WebhookEvent {
+	if source.Type != DomainEventTypeUserCreated {
+		return nil
+	}
+	event := newWebhookEvent(WebhookEventTypeUserCreated, source, creator)
+	event.User = user
+	return event
+}
+
+// NewWebhookDelivery / 出来事のペイロードをすぐに送る配信を生成する
+func NewWebhookDelivery(webhook *Webhook, event *WebhookEvent, payload string, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreateAt:      now,
+	}
+}
+
+// Redeliver / 同じ出来事とペイロードをすぐに送る新しい配信を生成する
+func (m *WebhookDelivery) Redeliver(now time.Time) *WebhookDelivery {
+	id := m.ID
+	return &WebhookDelivery{
+		WebhookID:     m.WebhookID,
+		EventID:       m.EventID,
+		EventType:     m.EventType,
+		Payload:       m.Payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		RedeliveryOf:  &id,
+		CreateAt:      now,
+	}
+}
+
+// Succeed / 配信の成功を記録する
+func (m *WebhookDelivery) Succeed(responseCode int, now time.Time) {
+	m.Attempts++
+	m.Status = WebhookDeliveryStatusSucceeded
+	m.ResponseCode = &responseCode
+	m.LastError = nil
+	m.DeliveredAt = &now
+}
+
+// Fail / 配信の失敗を記録し、指数的に間隔を空けて再送する。上限の回数に達した場合は失敗とする。
+func (m *WebhookDelivery) Fail(responseCode *int, reason string, now time.Time) {
+	m.Attempts++
+	m.ResponseCode = responseCode
+	m.LastError = &reason
+	if m.Attempts >= maxWebhookAttempts {
+		m.Status = WebhookDeliveryStatusFailed
+		return
+	}
+	delay := webhookRetryBase << (m.Attempts - 1)
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	m.NextAttemptAt = now.Add(delay)
+}
+
+// Abandon / Webhook が無効になったため送らずに失敗とする
+func (m *WebhookDelivery) Abandon(reason string) {
+	m.Status = WebhookDeliveryStatusFailed
+	m.LastError = &reason
+}
+
+func (e WebhookEventType) String() string {
+	switch e {
+	case WebhookEventTypeTaskCreated:
+		return "task.created"
+	case WebhookEventTypeTaskUpdated:
+		return "task.updated"
+	case WebhookEventTypeTaskStatusChanged:
+		return "task.status_changed"
+	case WebhookEventTypeTaskDeleted:
+		return "task.deleted"
+	case WebhookEventTypeCommentCreated:
+		return "comment.created"
+	case WebhookEventTypeUserCreated:
+		return "user.created"
+	default:
+		return ""
+	}
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	switch e {
+	case WebhookDeliveryStatusPending:
+		return "PENDING"
+	case WebhookDeliveryStatusSucceeded:
+		return "SUCCEEDED"
+	case WebhookDeliveryStatusFailed:
+		return "FAILED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,287 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"todo_api/internal/lib/apperr"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidTaskStatusNameLength   = errors.New("Task Status Name must be 1 to 10 characters")
+	errInvalidTaskStatusCategory     = errors.New("Task Status Category is invalid")
+	errInvalidTaskStatusCompany      = errors.New("Task Status must belong to the same company as the task")
+	errInvalidTaskStatusTransition   = errors.New("Task Status cannot be changed to the requested status")
+	errInvalidTransitionSameStatus   = errors.New("Task Status Transition must connect different statuses")
+	errInvalidTransitionOtherCompany = errors.New("Task Status Transition must connect statuses of the same company")
+)
+
+const (
+	minTaskStatusNameLength = 1
+	maxTaskStatusNameLength = 10
+)
+
+// TaskStatus / 企業ごとに定義されるタスクのステータス
+type TaskStatus struct {
+	ID        TaskStatusIdentifier
+	CompanyID CompanyIdentifier
+	Name      string
+	Category  TaskStatusCategory
+	SortOrder int
+}
+
+type TaskStatusDescription struct {
+	Name      string
+	Category  TaskStatusCategory
+	SortOrder int
+}
+
+type TaskStatusIdentifier uint64
+
+// TaskStatusCategory / ステータスの分類。企業ごとのステータスはいずれかの分類に属する。
+type TaskStatusCategory int
+
+const (
+	TaskStatusCategoryOpen TaskStatusCategory = iota + 1
+	TaskStatusCategoryInProgress
+	TaskStatusCategoryClosed
+)
+
+// TaskStatusTransition / ステータス間で許可された遷移。Role, UserType が設定されている場合はそのユーザのみ遷移可能。
+type TaskStatusTransition struct {
+	ID           TaskStatusTransitionIdentifier
+	CompanyID    CompanyIdentifier
+	FromStatusID TaskStatusIdentifier
+	ToStatusID   TaskStatusIdentifier
+	Role         *UserRole
+	UserType     *UserType
+}
+
+type TaskStatusTransitionDescription struct {
+	From     *TaskStatus
+	To       *TaskStatus
+	Role     *UserRole
+	UserType *UserType
+}
+
+type TaskStatusTransitionIdentifier uint64
+
+// Workflow / 企業のステータスとその遷移の定義
+type Workflow struct {
+	CompanyID   CompanyIdentifier
+	Statuses    []*TaskStatus
+	Transitions []*TaskStatusTransition
+}
+
+// 企業作成時に設定されるステータスと遷移
+var (
+	defaultTaskStatuses = []TaskStatusDescription{
+		{Name: "NEW", Category: TaskStatusCategoryOpen, SortOrder: 1},
+		{Name: "PROCESSING", Category: TaskStatusCategoryInProgress, SortOrder: 2},
+		{Name: "DONE", Category: TaskStatusCategoryClosed, SortOrder: 3},
+	}
+	defaultTaskStatusTransitions = [][2]string{
+		{"NEW", "PROCESSING"},
+		{"NEW", "DONE"},
+		{"PROCESSING", "NEW"},
+		{"PROCESSING", "DONE"},
+		{"DONE", "PROCESSING"},
+	}
+)
+
+func NewTaskStatus(companyID CompanyIdentifier, desc TaskStatusDescription) (*TaskStatus, apperr.AppErr) {
+	status := &TaskStatus{
+		CompanyID: companyID,
+	}
+	if err := status.Update(desc); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (m *TaskStatus) Update(desc TaskStatusDescription) apperr.AppErr {
+	if err := desc.validate(); err != nil {
+		return apperr.NewBadRequestError().Wrap(err)
+	}
+
+	m.Name = desc.Name
+	m.Category = desc.Category
+	m.SortOrder = desc.SortOrder
+	return nil
+}
+
+func (d *TaskStatusDescription) validate() error {
+	nameLength := utf8.RuneCountInString(d.Name)
+	if nameLength < minTaskStatusNameLength || nameLength > maxTaskStatusNameLength {
+		return errInvalidTaskStatusNameLength
+	}
+	if d.Category.String() == "" {
+		return errInvalidTaskStatusCategory
+	}
+	return nil
+}
+
+// IsClosed / 完了扱いのステータスかどうか
+func (m *TaskStatus) IsClosed() bool {
+	return m.Category == TaskStatusCategoryClosed
+}
+
+func NewTaskStatusTransition(desc TaskStatusTransitionDescription) (*TaskStatusTransition, apperr.AppErr) {
+	if err := desc.validate(); err != nil {
+		return nil, apperr.NewBadRequestError().Wrap(err)
+	}
+
+	return &TaskStatusTransition{
+		CompanyID:    desc.From.CompanyID,
+		FromStatusID: desc.From.ID,
+		ToStatusID:   desc.To.ID,
+		Role:         desc.Role,
+		UserType:     desc.UserType,
+	}, nil
+}
+
+func (d *TaskStatusTransitionDescription) validate() error {
+	if d.From.ID == d.To.ID {
+		return errInvalidTransitionSameStatus
+	}
+	if d.From.CompanyID != d.To.CompanyID {
+		return errInvalidTransitionOtherCompany
+	}
+	return nil
+}
+
+// allows / 対象のユーザがこの遷移を行えるかどうか
+func (m *TaskStatusTransition) allows(user *User) bool {
+	if m.Role != nil && (user == nil || user.Role != *m.Role) {
+		return false
+	}
+	if m.UserType != nil && (user == nil || user.UserType != *m.UserType) {
+		return false
+	}
+	return true
+}
+
+// NewDefaultTaskStatuses / 企業の既定のステータスを生成する
+func NewDefaultTaskStatuses(companyID CompanyIdentifier) []*TaskStatus {
+	var statuses []*TaskStatus
+	for _, desc := range defaultTaskStatuses {
+		statuses = append(statuses, &TaskStatus{
+			CompanyID: companyID,
+			Name:      desc.Name,
+			Category:  desc.Category,
+			SortOrder: desc.SortOrder,
+		})
+	}
+	return statuses
+}
+
+// NewDefaultTaskStatusTransitions / 登録済みの既定のステータスから既定の遷移を生成する
+func NewDefaultTaskStatusTransitions(statuses []*TaskStatus) []*TaskStatusTransition {
+	w := &Workflow{Statuses: statuses}
+	var transitions []*TaskStatusTransition
+	for _, names := range defaultTaskStatusTransitions {
+		from := w.FindStatusByName(names[0])
+		to := w.FindStatusByName(names[1])
+		if from == nil || to == nil {
+			continue
+		}
+		transitions = append(transitions, &TaskStatusTransition{
+			CompanyID:    from.CompanyID,
+			FromStatusID: from.ID,
+			ToStatusID:   to.ID,
+		})
+	}
+	return transitions
+}
+
+// FindStatus / IDからステータスを取得する
+func (m *Workflow) FindStatus(id TaskStatusIdentifier) *TaskStatus {
+	for _, status := range m.Statuses {
+		if status.ID == id {
+			return status
+		}
+	}
+	return nil
+}
+
+// FindStatusByName / 名前からステータスを取得する。大文字小文字は区別しない。
+func (m *Workflow) FindStatusByName(name string) *TaskStatus {
+	for _, status := range m.Statuses {
+		if strings.EqualFold(status.Name, name) {
+			return status
+		}
+	}
+	return nil
+}
+
+// FindTransition / IDから遷移を取得する
+func (m *Workflow) FindTransition(id TaskStatusTransitionIdentifier) *TaskStatusTransition {
+	for _, transition := range m.Transitions {
+		if transition.ID == id {
+			return transition
+		}
+	}
+	return nil
+}
+
+// InitialStatus / 新規タスクに設定されるステータス。未着手のうち並び順が最も小さいもの。
+func (m *Workflow) InitialStatus() *TaskStatus {
+	var initial *TaskStatus
+	for _, status := range m.SortedStatuses() {
+		if status.Category == TaskStatusCategoryOpen {
+			initial = status
+			break
+		}
+	}
+	return initial
+}
+
+// SortedStatuses / 並び順に従ったステータスの一覧
+func (m *Workflow) SortedStatuses() []*TaskStatus {
+	statuses := make([]*TaskStatus, len(m.Statuses))
+	copy(statuses, m.Statuses)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].SortOrder < statuses[j].SortOrder
+	})
+	return statuses
+}
+
+// CanRemoveStatus / ステータスを削除しても未着手のステータスが残るかどうか
+func (m *Workflow) CanRemoveStatus(id TaskStatusIdentifier) bool {
+	for _, status := range m.Statuses {
+		if status.ID != id && status.Category == TaskStatusCategoryOpen {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition / 対象のユーザがステータスを from から to へ変更できるかどうか
+func (m *Workflow) CanTransition(from, to TaskStatusIdentifier, user *User) bool {
+	if from == to {
+		return true
+	}
+	for _, transition := range m.Transitions {
+		if transition.FromStatusID == from &&
+			transition.ToStatusID == to &&
+			transition.allows(user) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e TaskStatusCategory) String() string {
+	switch e {
+	case TaskStatusCategoryOpen:
+		return "OPEN"
+	case TaskStatusCategoryInProgress:
+		return "IN_PROGRESS"
+	case TaskStatusCategoryClosed:
+		return "CLOSED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type ActivityRepository interface {
+	// ListByCompanyID / 企業の出来事を新しい順に取得する。閲覧者が閲覧できないタスクの出来事は含まない。
+	ListByCompanyID(viewer *model.User, companyID model.CompanyIdentifier, filter model.ActivityFilter, cursor model.ActivityCursor) ([]*model.Activity, apperr.AppErr)
+	// Create / 同じ出来事による同じ種類の出来事が記録済みの場合は、重複して記録しない
+	Create(activities ...*model.Activity) apperr.AppErr
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type AttachmentRepository interface {
+	Get(id model.AttachmentIdentifier) (*model.Attachment, apperr.AppErr)
+	ListByTaskID(taskID model.TaskIdentifier) ([]*model.Attachment, apperr.AppErr)
+
+	// SumSizeByCompanyID / 企業の添付ファイルの合計容量を取得する
+	SumSizeByCompanyID(companyID model.CompanyIdentifier) (int64, apperr.AppErr)
+
+	// Create / 企業の登録を直列にし、合計容量が quota を超えない場合のみ登録して容量を確保する。超える場合は BadRequest を返す。
+	Create(attachment *model.Attachment, quota int64) (*model.AttachmentIdentifier, apperr.AppErr)
+	Delete(id model.AttachmentIdentifier) apperr.AppErr
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"io"
+	"todo_api/internal/lib/apperr"
+)
+
+// BlobStore / 添付ファイルの実体を保存するストレージ
+type BlobStore interface {
+	Put(key string, body io.Reader, size int64, contentType string) apperr.AppErr
+	// Get / 実体を取得する。存在しない場合は NotFound を返す。呼び出し側で Close すること。
+	Get(key string) (io.ReadCloser, apperr.AppErr)
+	// Delete / 実体を削除する。存在しない場合も成功とする。
+	Delete(key string) apperr.AppErr
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type CalendarFeedRepository interface {
+	// Get / ユーザのカレンダーの購読を取得する。存在しない場合は NotFound を返す。
+	Get(userID model.UserIdentifier) (*model.CalendarFeed, apperr.AppErr)
+	// GetByToken / トークンからカレンダーの購読を取得する。存在しない場合は NotFound を返す。
+	GetByToken(token string) (*model.CalendarFeed, apperr.AppErr)
+	// Save / ユーザのカレンダーの購読を作成し、既にある場合はトークンを置き換える
+	Save(feed *model.CalendarFeed) apperr.AppErr
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type CommentRepository interface {
+	Get(id model.CommentIdentifier) (*model.Comment, apperr.AppErr)
+	// ListByTaskID / タスクの削除されていないコメントを投稿順に取得する。全件数も返す。
+	ListByTaskID(taskID model.TaskIdentifier, page model.Page) ([]*model.Comment, int, apperr.AppErr)
+
+	Create(comment *model.Comment) (*model.CommentIdentifier, apperr.AppErr)
+	// Update / コメントの本文、メンション、編集日時と削除日時を更新する。
+	Update(comment *model.Comment) apperr.AppErr
+}
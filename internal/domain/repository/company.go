@@ -8,6 +8,7 @@ import (
 type CompanyRepository interface {
 	Get(id model.CompanyIdentifier) (*model.Company, apperr.AppErr)
 
+	// Create / 企業と、既定のワークフロー、優先度、設定を同じトランザクションで登録する
 	Create(company *model.Company) (*model.CompanyIdentifier, apperr.AppErr)
 	Update(company *model.Company) apperr.AppErr
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type CompanySettingRepository interface {
+	// Get / 企業の設定を取得する。未設定の企業には既定の設定を返す。
+	Get(companyID model.CompanyIdentifier) (*model.CompanySetting, apperr.AppErr)
+
+	Save(setting *model.CompanySetting) apperr.AppErr
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// DomainEventRepository / 出来事のアウトボックス。出来事は集約のリポジトリが保存と同じトランザクションで記録する。
+type DomainEventRepository interface {
+	// ListPending / now の時点で配信できる出来事を記録順に limit 件取得する。
+	// 再送を待つ出来事と、同じ集約でそれより前に再送を待つ出来事がある出来事は含まない。
+	ListPending(now time.Time, limit int) ([]*model.DomainEvent, apperr.AppErr)
+	// Update / 配信の状態のみを更新する
+	Update(event *model.DomainEvent) apperr.AppErr
+}
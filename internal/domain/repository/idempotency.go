@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// IdempotencyRepository / 冪等キーの記録の保存先
+type IdempotencyRepository interface {
+	// Get / 有効期限内の記録を取得する。存在しない場合は NotFound を返す。
+	Get(userID model.UserIdentifier, key string) (*model.IdempotencyRecord, apperr.AppErr)
+	// Reserve / 有効な記録がない場合のみ処理中の記録を保存する。既に存在する場合は false を返す。
+	Reserve(record *model.IdempotencyRecord) (bool, apperr.AppErr)
+	// Complete / 処理中の記録にレスポンスを保存する
+	Complete(record *model.IdempotencyRecord) apperr.AppErr
+}
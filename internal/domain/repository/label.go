@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type LabelRepository interface {
+	Get(id model.LabelIdentifier) (*model.Label, apperr.AppErr)
+	// ListByCompanyID / 企業のラベルの一覧を取得する。
+	ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Label, apperr.AppErr)
+	// ListByIDs / IDを元にラベルを取得する。存在しないIDは無視する。
+	ListByIDs(ids []model.LabelIdentifier) ([]*model.Label, apperr.AppErr)
+
+	Create(label *model.Label) (*model.LabelIdentifier, apperr.AppErr)
+	Update(label *model.Label) apperr.AppErr
+	// Delete / ラベルを外したタスクをラベルの削除と同じトランザクションで保存する。
+	// tasks はラベルが付与されたすべてのタスクとし、同時に付与されたタスクがある場合は Conflict を返す。
+	Delete(id model.LabelIdentifier, tasks []*model.Task) apperr.AppErr
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// MailRepository / 送信待ちのメールのアウトボックス
+type MailRepository interface {
+	Create(messages ...*model.MailMessage) apperr.AppErr
+	// ListDue / 送信待ちで再送の時刻を迎えたメールを古い順に limit 件取得する
+	ListDue(now time.Time, limit int) ([]*model.MailMessage, apperr.AppErr)
+	Update(message *model.MailMessage) apperr.AppErr
+}
+
+// MailSender / メールを送る
+type MailSender interface {
+	Send(message *model.MailMessage) apperr.AppErr
+}
+
+// MailRenderer / 通知からメールの件名と本文を生成する。本文には配信停止のURLを含める。
+type MailRenderer interface {
+	// RenderNotification / 一件の通知のメール
+	RenderNotification(language model.Language, notification *model.Notification, task *model.Task, unsubscribeURL string) (subject, body string, err apperr.AppErr)
+	// RenderDigest / 複数の通知をまとめたメール。tasks は通知のタスクID ごとのタスク。
+	RenderDigest(language model.Language, notifications []*model.Notification, tasks map[model.TaskIdentifier]*model.Task, unsubscribeURL string) (subject, body string, err apperr.AppErr)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type NotificationRepository interface {
+	// ListByUserID / ユーザの通知を未読を先に新しい順で取得する。全件数も返す。
+	ListByUserID(userID model.UserIdentifier, page model.Page) ([]*model.Notification, int, apperr.AppErr)
+	// ListSince / ユーザの指定日時より後の通知を古い順に取得する
+	ListSince(userID model.UserIdentifier, since time.Time) ([]*model.Notification, apperr.AppErr)
+	// CountUnread / ユーザの未読の通知の件数
+	CountUnread(userID model.UserIdentifier) (int, apperr.AppErr)
+	Get(id model.NotificationIdentifier) (*model.Notification, apperr.AppErr)
+	// Create / 通知と、通知をすぐに送るメールを同じトランザクションで保存する。
+	// 同じ出来事による同じユーザへの同じ種類の通知とメールが保存済みの場合は、重複して保存しない。
+	Create(notifications []*model.Notification, messages []*model.MailMessage) apperr.AppErr
+	Update(notification *model.Notification) apperr.AppErr
+	// ReadAll / ユーザの未読の通知をすべて既読にする
+	ReadAll(userID model.UserIdentifier, now time.Time) apperr.AppErr
+}
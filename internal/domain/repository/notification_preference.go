@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type NotificationPreferenceRepository interface {
+	// Get / ユーザの通知の設定を取得する。設定がない場合はアプリ内ですべて通知し、メールは送らない設定を返す。
+	Get(userID model.UserIdentifier) (*model.NotificationPreference, apperr.AppErr)
+	// GetByUnsubscribeToken / 配信停止のトークンからユーザの設定を取得する。存在しない場合は NotFound を返す。
+	GetByUnsubscribeToken(token string) (*model.NotificationPreference, apperr.AppErr)
+	// ListDigest / メールの送り方がダイジェストの設定を取得する
+	ListDigest() ([]*model.NotificationPreference, apperr.AppErr)
+	Save(preference *model.NotificationPreference) apperr.AppErr
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"time"
 	"todo_api/internal/domain/model"
 	"todo_api/internal/lib/apperr"
 )
@@ -8,14 +9,43 @@ import (
 type TaskRepository interface {
 	// Get / タスクIDを元にタスクを取得する。
 	Get(id model.TaskIdentifier) (*model.Task, apperr.AppErr)
+	// GetIncludingDeleted / 削除済みのタスクも含めてタスクIDを元にタスクを取得する。
+	GetIncludingDeleted(id model.TaskIdentifier) (*model.Task, apperr.AppErr)
 	// Find / 取得者のIDで表示可能なタスクを取得する
 	Find(userID model.UserIdentifier, id model.TaskIdentifier) (*model.Task, apperr.AppErr)
-	// ListByUserID / 担当者IDを元にタスクを取得する。
-	ListByAssignedUserID(userID, assignedUserID model.UserIdentifier) ([]*model.Task, apperr.AppErr)
-	// ListByCompanyID / 組織IDを元にタスクを取得する。
-	ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier) ([]*model.Task, apperr.AppErr)
+	// ListByUserID / 担当者IDを元に、取得者が Task.IsVisibleTo と同じ条件で閲覧できるタスクを取得する。
+	ListByAssignedUserID(userID, assignedUserID model.UserIdentifier, filter model.TaskFilter) ([]*model.Task, apperr.AppErr)
+	// ListByIDs / タスクIDを元にタスクを取得する。閲覧可能かどうかは検証しない。
+	ListByIDs(ids []model.TaskIdentifier) ([]*model.Task, apperr.AppErr)
+	// ListByLabelID / ラベルが付与されたタスクを取得する。閲覧可能かどうかは検証しない。
+	ListByLabelID(labelID model.LabelIdentifier) ([]*model.Task, apperr.AppErr)
+	// ListByCompanyID / 組織IDを元に、取得者が Task.IsVisibleTo と同じ条件で閲覧できるタスクを取得する。
+	ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier, filter model.TaskFilter) ([]*model.Task, apperr.AppErr)
 
 	Create(task *model.Task) (*model.TaskIdentifier, apperr.AppErr)
 	Update(task *model.Task) apperr.AppErr
-	UpdateStatus(id model.TaskIdentifier, status model.TaskStatus) apperr.AppErr
+	// UpdateAll / 複数のタスクを一つのトランザクションで更新する。いずれかが失敗した場合はすべて保存しない。
+	UpdateAll(tasks ...*model.Task) apperr.AppErr
+	// UpdateStatus / タスクのステータスと更新者のみを更新する。
+	UpdateStatus(task *model.Task) apperr.AppErr
+	// CreateOccurrence / 繰り返しのタスクの回を作成する。同じ回が作成済みの場合は作成せずに false を返す。
+	CreateOccurrence(task *model.Task) (bool, apperr.AppErr)
+	// ListBySeriesID / 系列に属するタスクを回の順に取得する
+	ListBySeriesID(seriesID model.TaskSeriesIdentifier) ([]*model.Task, apperr.AppErr)
+	// ListDueOccurrences / 系列の最新の回のうち、期限が指定日時を過ぎたタスクを取得する
+	ListDueOccurrences(now time.Time) ([]*model.Task, apperr.AppErr)
+	// ListOpenByLimitDate / 期限が指定の期間（from より後、to 以前）にある未完了のタスクを取得する
+	ListOpenByLimitDate(from, to time.Time) ([]*model.Task, apperr.AppErr)
+
+	// GetChangeSeq / 企業の確定した変更の最新の通し番号を取得する。変更がない場合は0。
+	GetChangeSeq(companyID model.CompanyIdentifier) (uint64, apperr.AppErr)
+	// ListChanged / 企業のタスクのうち、通し番号が since より後で until 以下のものを削除済みも含めて通し番号の順に limit 件取得する。
+	ListChanged(companyID model.CompanyIdentifier, since, until uint64, limit int) ([]*model.Task, apperr.AppErr)
+	// FindByExternalRef / 他のツールから取り込んだ課題のタスクIDを削除済みも含めて取得する。取り込んでいない場合は nil。
+	FindByExternalRef(ref model.TaskExternalRef) (*model.TaskIdentifier, apperr.AppErr)
+
+	// ListAncestorIDs / 親タスクを辿り、祖先のタスクIDを親に近い順に取得する。
+	ListAncestorIDs(id model.TaskIdentifier) ([]model.TaskIdentifier, apperr.AppErr)
+	// GetDescendantDepth / 子孫のタスクの階層の深さを取得する。子がなければ0。
+	GetDescendantDepth(id model.TaskIdentifier) (int, apperr.AppErr)
 }
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// TaskHistoryRepository / タスクの変更履歴の参照。履歴の追記は TaskRepository の更新と同じトランザクションで行う。
+type TaskHistoryRepository interface {
+	// ListByTaskID / タスクの変更履歴を古い順に取得する。全件数も返す。
+	ListByTaskID(taskID model.TaskIdentifier, page model.Page) ([]*model.TaskHistory, int, apperr.AppErr)
+}
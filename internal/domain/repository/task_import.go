@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskImportJobRepository interface {
+	// Get / 取り込みの状態と進捗を取得する。取り込む行は含まない。
+	Get(id model.TaskImportJobIdentifier) (*model.TaskImportJob, apperr.AppErr)
+	// GetNext / 処理待ちまたは処理中に中断した取り込みのうち最も古いものを行とともに取得する。ない場合は nil を返す。
+	GetNext() (*model.TaskImportJob, apperr.AppErr)
+
+	Create(job *model.TaskImportJob) (*model.TaskImportJobIdentifier, apperr.AppErr)
+	// UpdateProgress / 状態と進捗、行の誤りのみを更新する
+	UpdateProgress(job *model.TaskImportJob) apperr.AppErr
+	// CreateTask / 行のタスクとそのコメントを作成し、同じトランザクションで取り込みの進捗を更新する。
+	// 中断した取り込みを再開しても同じ行を重複して作成しないためである。取り込みが保存されていない場合は進捗を更新しない。
+	CreateTask(job *model.TaskImportJob, task *model.Task, comments []*model.Comment) apperr.AppErr
+}
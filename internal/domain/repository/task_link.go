@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskLinkRepository interface {
+	Get(id model.TaskLinkIdentifier) (*model.TaskLink, apperr.AppErr)
+	// ListByCompanyID / 企業のタスク間の関連をすべて取得する。
+	ListByCompanyID(companyID model.CompanyIdentifier) (model.TaskLinks, apperr.AppErr)
+
+	// Create / 企業の関連の作成を直列にし、重複と循環がないことを検証し直して作成する。違反する場合は BadRequest を返す。
+	Create(link *model.TaskLink) (*model.TaskLinkIdentifier, apperr.AppErr)
+	Delete(id model.TaskLinkIdentifier) apperr.AppErr
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskPriorityRepository interface {
+	// ListByCompanyID / 企業の優先度の一覧を取得する。
+	ListByCompanyID(companyID model.CompanyIdentifier) (model.TaskPriorities, apperr.AppErr)
+
+	Create(priority *model.TaskPriority) (*model.TaskPriorityIdentifier, apperr.AppErr)
+	Update(priority *model.TaskPriority) apperr.AppErr
+	// Delete / 優先度を削除する。タスクで利用中の場合は削除できない。
+	Delete(id model.TaskPriorityIdentifier) apperr.AppErr
+}
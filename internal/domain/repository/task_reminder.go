@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// TaskReminderRepository / 送信済みのリマインダーの記録
+type TaskReminderRepository interface {
+	// Create / リマインダーを送信済みとして記録する。同じリマインダーが記録済みの場合は false を返す。
+	Create(reminder *model.TaskReminder) (bool, apperr.AppErr)
+	// Delete / 記録を取り消す。存在しない場合も成功とする。
+	Delete(id model.TaskReminderIdentifier) apperr.AppErr
+}
+
+// LeaderLock / 複数のプロセスのうち一つのみが処理を行うためのロック。一つのゴルーチンから利用する。
+type LeaderLock interface {
+	// TryAcquire / ロックを取得する。既に保持している場合も true、他のプロセスが保持している場合は false を返す。
+	TryAcquire() (bool, apperr.AppErr)
+	// Release / 保持しているロックを解放する
+	Release() apperr.AppErr
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskSeriesRepository interface {
+	Get(id model.TaskSeriesIdentifier) (*model.TaskSeries, apperr.AppErr)
+	// Create / 系列と、AttachSeries で最初の回とした元のタスクを同じトランザクションで保存する。
+	// 元のタスクが同時に更新された場合は 412 を返す。
+	Create(series *model.TaskSeries, task *model.Task) (*model.TaskSeriesIdentifier, apperr.AppErr)
+	// Update / 系列と、系列の元とした回などのタスクを同じトランザクションで保存する
+	Update(series *model.TaskSeries, tasks ...*model.Task) apperr.AppErr
+	// Delete / DetachSeries で系列から外したタスクを保存し、系列を削除する。生成済みのタスクは繰り返さないタスクとして残る。
+	Delete(series *model.TaskSeries, tasks []*model.Task) apperr.AppErr
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// TaskStreamBroker / タスクの変更を接続中のクライアントのいるすべてのサーバに届ける。
+// 再接続したクライアントに再送するため、直近の変更を一定の件数だけ保持する。
+type TaskStreamBroker interface {
+	// Publish / 変更に通し番号を採番して保持し、購読者に届ける
+	Publish(event *model.TaskStreamEvent) apperr.AppErr
+	// Subscribe / 企業の変更の購読を始める。購読者が受け取りきれない場合はチャネルを閉じる。
+	// 返した関数で購読をやめる。
+	Subscribe(companyID model.CompanyIdentifier) (<-chan *model.TaskStreamEvent, func(), apperr.AppErr)
+	// ListAfter / 保持している企業の変更のうち、afterID より後のものを古い順に取得する。
+	// afterID の直後の変更をすでに保持していない場合は false を返す。
+	ListAfter(companyID model.CompanyIdentifier, afterID model.TaskStreamEventIdentifier) ([]*model.TaskStreamEvent, bool, apperr.AppErr)
+}
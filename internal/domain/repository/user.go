@@ -7,6 +7,12 @@ import (
 
 type UserRepository interface {
 	Get(model.UserIdentifier) (*model.User, apperr.AppErr)
+	// ListByNames / 企業に所属するユーザを名前から取得する。
+	ListByNames(companyID model.CompanyIdentifier, names []string) ([]*model.User, apperr.AppErr)
+	// ListByEmails / 企業に所属するユーザを通知のメールアドレスから取得する。
+	ListByEmails(companyID model.CompanyIdentifier, emails []string) ([]*model.User, apperr.AppErr)
+	// ListByIDs / ユーザIDを元にユーザを取得する。存在しないIDは無視する。
+	ListByIDs(ids []model.UserIdentifier) ([]*model.User, apperr.AppErr)
 
 	Update(*model.User) apperr.AppErr
 }
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type WebhookRepository interface {
+	Get(id model.WebhookIdentifier) (*model.Webhook, apperr.AppErr)
+	// ListByCompanyID / 企業の Webhook の一覧を取得する
+	ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Webhook, apperr.AppErr)
+	// ListActive / 企業の有効な Webhook の一覧を取得する
+	ListActive(companyID model.CompanyIdentifier) ([]*model.Webhook, apperr.AppErr)
+
+	Create(webhook *model.Webhook) (*model.WebhookIdentifier, apperr.AppErr)
+	Update(webhook *model.Webhook) apperr.AppErr
+	// UpdateFailures / 配信の結果による失敗の回数と有効かどうかのみを更新する
+	UpdateFailures(webhook *model.Webhook) apperr.AppErr
+	// Delete / Webhook を配信の記録とともに削除する
+	Delete(id model.WebhookIdentifier) apperr.AppErr
+}
+
+// WebhookDeliveryRepository / Webhook の配信のアウトボックス。配信の記録を兼ねる。
+type WebhookDeliveryRepository interface {
+	Get(id model.WebhookDeliveryIdentifier) (*model.WebhookDelivery, apperr.AppErr)
+	// ListByWebhookID / Webhook の配信を新しい順に取得し、全件数とともに返す
+	ListByWebhookID(webhookID model.WebhookIdentifier, page model.Page) ([]*model.WebhookDelivery, int, apperr.AppErr)
+	// ListDue / 送信待ちで再送の時刻を迎えた配信を古い順に limit 件取得する
+	ListDue(now time.Time, limit int) ([]*model.WebhookDelivery, apperr.AppErr)
+
+	Create(deliveries ...*model.WebhookDelivery) apperr.AppErr
+	Update(delivery *model.WebhookDelivery) apperr.AppErr
+}
+
+// WebhookSender / 署名を付けて配信を送り、応答のステータスコードを返す。応答がなかった場合は 0 を返す。
+// 2xx 以外の応答はエラーとする。
+type WebhookSender interface {
+	Send(webhook *model.Webhook, delivery *model.WebhookDelivery) (int, apperr.AppErr)
+}
+
+// WebhookEncoder / 出来事から配信する JSON のペイロードを生成する
+type WebhookEncoder interface {
+	Encode(event *model.WebhookEvent) (string, apperr.AppErr)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+type WorkflowRepository interface {
+	// Get / 企業のステータスと遷移の定義を取得する。
+	Get(companyID model.CompanyIdentifier) (*model.Workflow, apperr.AppErr)
+
+	CreateStatus(status *model.TaskStatus) (*model.TaskStatusIdentifier, apperr.AppErr)
+	UpdateStatus(status *model.TaskStatus) apperr.AppErr
+	// DeleteStatus / ステータスを削除する。タスクで利用中の場合は削除できない。
+	DeleteStatus(id model.TaskStatusIdentifier) apperr.AppErr
+
+	CreateTransition(transition *model.TaskStatusTransition) (*model.TaskStatusTransitionIdentifier, apperr.AppErr)
+	DeleteTransition(id model.TaskStatusTransitionIdentifier) apperr.AppErr
+}
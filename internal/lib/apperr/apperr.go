@@ -1,6 +1,7 @@
 package apperr
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -15,9 +16,14 @@ const (
 	ErrorCodeForbidden
 	ErrorCodeNotFound
 	ErrorCodeInternalServerError
+	ErrorCodePreconditionFailed
+	ErrorCodeConflict
+	ErrorCodeUnprocessableEntity
 )
 
+// AppErr / error として返せるため、トランザクション内の失敗はそのまま返し FromError で戻す
 type AppErr interface {
+	error
 	Code() ErrorCode
 	Message() string
 	HTTPError() *echo.HTTPError
@@ -54,6 +60,11 @@ func (e *appErr) Message() string {
 	return e.message
 }
 
+// Error / トランザクション内など error として扱う必要がある箇所のために error を実装する
+func (e *appErr) Error() string {
+	return e.message
+}
+
 func (e *appErr) HTTPError() *echo.HTTPError {
 	var code int
 	switch e.Code() {
@@ -67,6 +78,12 @@ func (e *appErr) HTTPError() *echo.HTTPError {
 		code = http.StatusNotFound
 	case ErrorCodeInternalServerError:
 		code = http.StatusInternalServerError
+	case ErrorCodePreconditionFailed:
+		code = http.StatusPreconditionFailed
+	case ErrorCodeConflict:
+		code = http.StatusConflict
+	case ErrorCodeUnprocessableEntity:
+		code = http.StatusUnprocessableEntity
 	default:
 		code = http.StatusInternalServerError
 	}
@@ -100,3 +117,30 @@ func NewNotFoundError() *appErr {
 func NewInternalServerError() *appErr {
 	return new(ErrorCodeInternalServerError, "internal server error")
 }
+
+// NewPreconditionFailedError / 更新の前提となる版数が一致しない場合のエラー
+func NewPreconditionFailedError() *appErr {
+	return new(ErrorCodePreconditionFailed, "precondition failed")
+}
+
+// NewConflictError / 処理中のリクエストなど、現在の状態と競合する場合のエラー
+func NewConflictError() *appErr {
+	return new(ErrorCodeConflict, "conflict")
+}
+
+// NewUnprocessableEntityError / 形式は正しいが処理できないリクエストのエラー
+func NewUnprocessableEntityError() *appErr {
+	return new(ErrorCodeUnprocessableEntity, "unprocessable entity")
+}
+
+// FromError / error を AppErr に変換する。AppErr でない場合は内部エラーとして扱う。
+func FromError(err error) AppErr {
+	if err == nil {
+		return nil
+	}
+	var aerr *appErr
+	if errors.As(err, &aerr) {
+		return aerr
+	}
+	return NewInternalServerError().Wrap(err)
+}
@@ -1,8 +1,19 @@
 package main
 
 import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 	"todo_api/internal/adapter/inbound/http/handler"
+	"todo_api/internal/adapter/inbound/scheduler"
+	"todo_api/internal/adapter/outbound/blob"
+	"todo_api/internal/adapter/outbound/mail"
+	"todo_api/internal/adapter/outbound/memory"
 	"todo_api/internal/adapter/outbound/mysql/repository"
+	"todo_api/internal/adapter/outbound/webhook"
+	domainRepository "todo_api/internal/domain/repository"
 	"todo_api/internal/usecase"
 
 	_ "todo_api/docs" // docs is generated by Swag CLI, you have to import it.
@@ -32,21 +43,163 @@ func main() {
 
 	// repository
 	authRepository := repository.NewAuthRepository(db)
+	activityRepository := repository.NewActivityRepository(db)
 	companyRepository := repository.NewCompanyRepostiroy(db)
 	userRepository := repository.NewUserRepository(db)
 	taskRepository := repository.NewTaskRepository(db)
+	workflowRepository := repository.NewWorkflowRepository(db)
+	taskPriorityRepository := repository.NewTaskPriorityRepository(db)
+	labelRepository := repository.NewLabelRepository(db)
+	companySettingRepository := repository.NewCompanySettingRepository(db)
+	taskLinkRepository := repository.NewTaskLinkRepository(db)
+	commentRepository := repository.NewCommentRepository(db)
+	attachmentRepository := repository.NewAttachmentRepository(db)
+	taskHistoryRepository := repository.NewTaskHistoryRepository(db)
+	taskSeriesRepository := repository.NewTaskSeriesRepository(db)
+	taskReminderRepository := repository.NewTaskReminderRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	notificationPreferenceRepository := repository.NewNotificationPreferenceRepository(db)
+	mailRepository := repository.NewMailRepository(db)
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(db)
+	taskImportJobRepository := repository.NewTaskImportJobRepository(db)
+	calendarFeedRepository := repository.NewCalendarFeedRepository(db)
+	idempotencyRepository := newIdempotencyRepository(db)
+	blobStore := newBlobStore()
+	mailSender := newMailSender(e.Logger)
+	mailRenderer := mail.NewTemplateRenderer()
+
+	// 通知の保存とメールの送信待ちへの追加
+	publicURL := getenv("PUBLIC_URL", "http://localhost:8080")
+	notifier := usecase.NewNotifier(notificationRepository, notificationPreferenceRepository, taskRepository, mailRenderer, publicURL)
+
+	// Webhook の配信の送信待ちへの追加
+	webhookPublisher := usecase.NewWebhookPublisher(webhookRepository, webhookDeliveryRepository, webhook.NewJSONEncoder())
 
 	// usecase
 	authUsecase := usecase.NewAuthUsecase(authRepository, companyRepository)
 	companyUsecase := usecase.NewCompanyUsecase(companyRepository)
 	userUsecase := usecase.NewUserUsecase(userRepository, companyRepository)
-	taskUsecase := usecase.NewTaskUsecase(userRepository, taskRepository)
+	taskUsecase := usecase.NewTaskUsecase(userRepository, taskRepository, workflowRepository, taskPriorityRepository, labelRepository, companySettingRepository, taskSeriesRepository)
+	workflowUsecase := usecase.NewWorkflowUsecase(workflowRepository)
+	taskPriorityUsecase := usecase.NewTaskPriorityUsecase(taskPriorityRepository)
+	labelUsecase := usecase.NewLabelUsecase(userRepository, taskRepository, labelRepository)
+	companySettingUsecase := usecase.NewCompanySettingUsecase(companySettingRepository)
+	taskLinkUsecase := usecase.NewTaskLinkUsecase(userRepository, taskRepository, taskLinkRepository)
+	commentUsecase := usecase.NewCommentUsecase(userRepository, taskRepository, commentRepository)
+	taskHistoryUsecase := usecase.NewTaskHistoryUsecase(taskRepository, taskHistoryRepository)
+	activityUsecase := usecase.NewActivityUsecase(userRepository, activityRepository)
+	attachmentUsecase := usecase.NewAttachmentUsecase(userRepository, taskRepository, companySettingRepository, attachmentRepository, blobStore)
+	idempotencyTTL, err := time.ParseDuration(getenv("IDEMPOTENCY_TTL", "24h"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyRepository, idempotencyTTL)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepository, notificationPreferenceRepository)
+	mailDigestHour, err := strconv.Atoi(getenv("MAIL_DIGEST_HOUR", "8"))
+	if err != nil || mailDigestHour < 0 || mailDigestHour > 23 {
+		e.Logger.Fatal("MAIL_DIGEST_HOUR must be 0 to 23")
+	}
+	mailUsecase := usecase.NewMailUsecase(mailRepository, mailSender, mailRenderer, notificationRepository, notificationPreferenceRepository, taskRepository, publicURL, mailDigestHour)
+	webhookTimeout, err := time.ParseDuration(getenv("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository, webhookDeliveryRepository, webhook.NewHTTPSender(webhookTimeout))
+	taskStreamBroker := newTaskStreamBroker(db, e.Logger)
+	taskStreamUsecase := usecase.NewTaskStreamUsecase(userRepository, taskStreamBroker)
+	taskSyncUsecase := usecase.NewTaskSyncUsecase(userRepository, taskRepository)
+	taskImportUsecase := usecase.NewTaskImportUsecase(taskImportJobRepository, userRepository, labelRepository, taskRepository, taskUsecase)
+	calendarFeedUsecase := usecase.NewCalendarFeedUsecase(userRepository, calendarFeedRepository, taskUsecase, publicURL)
 
 	// handler
 	authHandler := handler.NewAuthHandler(authUsecase)
 	companyHandler := handler.NewCompanyHandler(authUsecase, companyUsecase)
 	userHandler := handler.NewUserHandler(authUsecase, userUsecase)
 	taskHandler := handler.NewTaskHandler(authUsecase, taskUsecase)
+	workflowHandler := handler.NewWorkflowHandler(authUsecase, workflowUsecase)
+	taskPriorityHandler := handler.NewTaskPriorityHandler(authUsecase, taskPriorityUsecase)
+	labelHandler := handler.NewLabelHandler(authUsecase, labelUsecase)
+	companySettingHandler := handler.NewCompanySettingHandler(authUsecase, companySettingUsecase)
+	taskLinkHandler := handler.NewTaskLinkHandler(authUsecase, taskLinkUsecase)
+	commentHandler := handler.NewCommentHandler(authUsecase, commentUsecase)
+	attachmentHandler := handler.NewAttachmentHandler(authUsecase, attachmentUsecase)
+	taskHistoryHandler := handler.NewTaskHistoryHandler(authUsecase, taskHistoryUsecase)
+	activityHandler := handler.NewActivityHandler(authUsecase, activityUsecase)
+	notificationHandler := handler.NewNotificationHandler(authUsecase, notificationUsecase, mailUsecase)
+	webhookHandler := handler.NewWebhookHandler(authUsecase, webhookUsecase)
+	taskStreamHeartbeat, err := time.ParseDuration(getenv("TASK_STREAM_HEARTBEAT", "15s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	taskStreamHandler := handler.NewTaskStreamHandler(authUsecase, taskStreamUsecase, taskStreamHeartbeat)
+	taskSyncHandler := handler.NewTaskSyncHandler(authUsecase, taskSyncUsecase)
+	taskTransferHandler := handler.NewTaskTransferHandler(authUsecase, taskUsecase, taskImportUsecase)
+	calendarHandler := handler.NewCalendarHandler(authUsecase, calendarFeedUsecase)
+
+	// 更新時に If-Match を必須とするかどうか
+	handler.RequireIfMatch = os.Getenv("REQUIRE_IF_MATCH") == "true"
+
+	// 作成のリクエストの再送で重複して作成しないように Idempotency-Key を扱う
+	idempotent := handler.Idempotency(idempotencyUsecase)
+
+	// 繰り返しのタスクの次の回の生成
+	recurrenceInterval, err := time.ParseDuration(getenv("RECURRENCE_INTERVAL", "1h"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	go scheduler.NewRecurrenceScheduler(taskUsecase, recurrenceInterval, e.Logger).Run(context.Background())
+
+	// 期限のリマインダーの送信。複数のサーバのうちロックを取得した一つのみが送る。
+	reminderOffsets, err := parseDurations(getenv("REMINDER_OFFSETS", "24h,0s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	reminderInterval, err := time.ParseDuration(getenv("REMINDER_INTERVAL", "1m"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	taskReminderUsecase := usecase.NewTaskReminderUsecase(taskRepository, taskReminderRepository, notifier, reminderOffsets)
+	reminderLock := repository.NewLeaderLock(db, "todo_api.reminder")
+	go scheduler.NewReminderScheduler(taskReminderUsecase, reminderLock, reminderInterval, e.Logger).Run(context.Background())
+
+	// 送信待ちのメールの送信。リマインダーと同じく一つのサーバのみが送る。
+	mailInterval, err := time.ParseDuration(getenv("MAIL_INTERVAL", "30s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	mailLock := repository.NewLeaderLock(db, "todo_api.mail")
+	go scheduler.NewMailScheduler(mailUsecase, mailLock, mailInterval, e.Logger).Run(context.Background())
+
+	// 送信待ちの Webhook の配信の送信
+	webhookInterval, err := time.ParseDuration(getenv("WEBHOOK_INTERVAL", "10s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	webhookLock := repository.NewLeaderLock(db, "todo_api.webhook")
+	go scheduler.NewWebhookScheduler(webhookUsecase, webhookLock, webhookInterval, e.Logger).Run(context.Background())
+
+	// アウトボックスの出来事の購読者への配信。購読者は配信を始める前に Subscribe で登録する。
+	// タイムライン、通知、Webhook は保存と同じトランザクションで記録した出来事から生成する。
+	domainEventDispatcher := usecase.NewDomainEventDispatcher(repository.NewDomainEventRepository(db))
+	domainEventDispatcher.Subscribe(usecase.NewActivitySubscriber(userRepository, taskRepository, commentRepository, activityRepository))
+	domainEventDispatcher.Subscribe(usecase.NewNotificationSubscriber(userRepository, taskRepository, commentRepository, notifier))
+	domainEventDispatcher.Subscribe(usecase.NewWebhookSubscriber(userRepository, taskRepository, commentRepository, webhookPublisher))
+	domainEventDispatcher.Subscribe(usecase.NewTaskStreamSubscriber(taskRepository, taskStreamBroker))
+	domainEventInterval, err := time.ParseDuration(getenv("DOMAIN_EVENT_INTERVAL", "1s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	domainEventLock := repository.NewLeaderLock(db, "todo_api.domain_event")
+	go scheduler.NewDomainEventScheduler(domainEventDispatcher, domainEventLock, domainEventInterval, e.Logger).Run(context.Background())
+
+	// 件数の多いタスクの取り込みの処理。中断した取り込みは続きから再開する。
+	taskImportInterval, err := time.ParseDuration(getenv("TASK_IMPORT_INTERVAL", "5s"))
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	taskImportLock := repository.NewLeaderLock(db, "todo_api.task_import")
+	go scheduler.NewTaskImportScheduler(taskImportUsecase, taskImportLock, taskImportInterval, e.Logger).Run(context.Background())
 
 	// Middleware
 	e.Use(middleware.Logger())
@@ -65,43 +218,162 @@ func main() {
 		authRoute.POST("/login", authHandler.Login)
 	}
 
+	// メールの配信停止は認証せずにトークンで行う
+	apiRoute.GET("/notification/unsubscribe", notificationHandler.Unsubscribe)
+	apiRoute.POST("/notification/unsubscribe", notificationHandler.Unsubscribe)
+
+	// カレンダーのアプリからの購読は認証せずにトークンで行う
+	apiRoute.GET("/calendar/:token", calendarHandler.Feed)
+
 	// 以下は認証が必要
 	companyRoute := apiRoute.Group("/company")
 	companyRoute.Use(echojwt.WithConfig(handler.Config))
 	{
 		// company
-		companyRoute.POST("/create", companyHandler.Create)
+		companyRoute.POST("/create", companyHandler.Create, idempotent)
 
 		companyIDRoute := companyRoute.Group("/:company_id")
 		{
 			companyIDRoute.GET("", companyHandler.Get)
-			companyRoute.PUT("/update", companyHandler.Update)
+			companyIDRoute.PUT("/update", companyHandler.Update)
+			companyIDRoute.PATCH("", companyHandler.Patch)
+		}
+
+		// setting
+		settingRoute := companyIDRoute.Group("/setting")
+		{
+			settingRoute.GET("", companySettingHandler.Get)
+			settingRoute.PUT("/update", companySettingHandler.Update)
 		}
 
 		// user
 		userRoute := companyIDRoute.Group("/user")
 		{
-			userRoute.POST("/create", authHandler.Create)
+			userRoute.POST("/create", authHandler.Create, idempotent)
 
 			userIDRoute := userRoute.Group("/:user_id")
 			{
 				userIDRoute.GET("", userHandler.Get)
 				userIDRoute.PUT("/update", authHandler.Update)
+				userIDRoute.PATCH("", authHandler.Patch)
 			}
 		}
 
+		// workflow
+		workflowRoute := companyIDRoute.Group("/workflow")
+		{
+			workflowRoute.GET("", workflowHandler.Get)
+
+			statusRoute := workflowRoute.Group("/status")
+			{
+				statusRoute.POST("/create", workflowHandler.CreateStatus, idempotent)
+				statusRoute.PUT("/:status_id/update", workflowHandler.UpdateStatus)
+				statusRoute.DELETE("/:status_id/delete", workflowHandler.DeleteStatus)
+			}
+
+			transitionRoute := workflowRoute.Group("/transition")
+			{
+				transitionRoute.POST("/create", workflowHandler.CreateTransition, idempotent)
+				transitionRoute.DELETE("/:transition_id/delete", workflowHandler.DeleteTransition)
+			}
+		}
+
+		// priority
+		priorityRoute := companyIDRoute.Group("/priority")
+		{
+			priorityRoute.GET("/list", taskPriorityHandler.List)
+			priorityRoute.POST("/create", taskPriorityHandler.Create, idempotent)
+			priorityRoute.PUT("/:priority_id/update", taskPriorityHandler.Update)
+			priorityRoute.DELETE("/:priority_id/delete", taskPriorityHandler.Delete)
+		}
+
+		// label
+		labelRoute := companyIDRoute.Group("/label")
+		{
+			labelRoute.GET("/list", labelHandler.List)
+			labelRoute.POST("/create", labelHandler.Create, idempotent)
+			labelRoute.PUT("/:label_id/update", labelHandler.Update)
+			labelRoute.DELETE("/:label_id/delete", labelHandler.Delete)
+		}
+
+		// webhook
+		webhookRoute := companyIDRoute.Group("/webhook")
+		{
+			webhookRoute.GET("/list", webhookHandler.List)
+			webhookRoute.POST("/create", webhookHandler.Create, idempotent)
+			webhookRoute.GET("/:webhook_id", webhookHandler.Get)
+			webhookRoute.PUT("/:webhook_id/update", webhookHandler.Update)
+			webhookRoute.DELETE("/:webhook_id/delete", webhookHandler.Delete)
+			webhookRoute.GET("/:webhook_id/deliveries", webhookHandler.ListDeliveries)
+			webhookRoute.POST("/:webhook_id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+		}
+
+		// activity
+		companyIDRoute.GET("/activity", activityHandler.List)
+
+		// notification
+		notificationRoute := companyIDRoute.Group("/notification")
+		{
+			notificationRoute.GET("", notificationHandler.List)
+			notificationRoute.GET("/unread_count", notificationHandler.CountUnread)
+			notificationRoute.PUT("/read_all", notificationHandler.ReadAll)
+			notificationRoute.PUT("/:notification_id/read", notificationHandler.Read)
+			notificationRoute.GET("/preference", notificationHandler.GetPreference)
+			notificationRoute.PUT("/preference/update", notificationHandler.UpdatePreference)
+		}
+
+		// calendar
+		calendarRoute := companyIDRoute.Group("/calendar")
+		{
+			calendarRoute.GET("", calendarHandler.GetFeed)
+			calendarRoute.POST("/regenerate", calendarHandler.RegenerateFeed)
+		}
+
 		// task
 		taskRoute := companyIDRoute.Group("/task")
 		{
-			taskRoute.POST("/create", taskHandler.Create)
+			taskRoute.POST("/create", taskHandler.Create, idempotent)
 			taskRoute.GET("/list", taskHandler.ListByCompanyID)
+			taskRoute.GET("/stream", taskStreamHandler.Stream)
+			taskRoute.GET("/changes", taskSyncHandler.ListChanges)
+			taskRoute.GET("/export", taskTransferHandler.Export)
+			// 取り込むファイルは10000件を想定して上限を設ける
+			taskRoute.POST("/import", taskTransferHandler.Import, middleware.BodyLimit("10M"), idempotent)
+			taskRoute.GET("/import/:job_id", taskTransferHandler.GetImportJob)
+			taskRoute.POST("/bulk", taskHandler.Bulk)
 			taskRoute.GET("/list_by_assigned_user_id/:assigned_user_id", taskHandler.ListByAssignedUserID)
 
 			taskIDRoute := taskRoute.Group("/:task_id")
 			{
 				taskIDRoute.GET("", taskHandler.Find)
 				taskIDRoute.PUT("/update", taskHandler.Update)
+				taskIDRoute.PATCH("", taskHandler.Patch)
 				taskIDRoute.PUT("/status/:status", taskHandler.UpdateStatus)
+				taskIDRoute.PUT("/recurrence/update", taskHandler.SetRecurrence)
+				taskIDRoute.DELETE("/recurrence/delete", taskHandler.DeleteRecurrence)
+				taskIDRoute.GET("/history", taskHistoryHandler.List)
+				taskIDRoute.GET("/dependencies", taskLinkHandler.GetDependencies)
+				taskIDRoute.POST("/link/create", taskLinkHandler.Create, idempotent)
+				taskIDRoute.DELETE("/link/:link_id/delete", taskLinkHandler.Delete)
+
+				// comment
+				commentRoute := taskIDRoute.Group("/comments")
+				{
+					commentRoute.GET("", commentHandler.List)
+					commentRoute.POST("/create", commentHandler.Create, idempotent)
+					commentRoute.PUT("/:comment_id/update", commentHandler.Update)
+					commentRoute.DELETE("/:comment_id/delete", commentHandler.Delete)
+				}
+
+				// attachment
+				attachmentRoute := taskIDRoute.Group("/attachments")
+				{
+					attachmentRoute.GET("", attachmentHandler.List)
+					// 企業の設定で指定できる容量の上限（100MiB）に multipart の分を加えた大きさで打ち切る
+					attachmentRoute.POST("/upload", attachmentHandler.Upload, middleware.BodyLimit("101M"), idempotent)
+					attachmentRoute.GET("/:attachment_id/download", attachmentHandler.Download)
+					attachmentRoute.DELETE("/:attachment_id/delete", attachmentHandler.Delete)
+				}
 			}
 		}
 	}
@@ -109,6 +381,83 @@ func main() {
 	e.Logger.Fatal(e.Start(":8080"))
 }
 
+// newBlobStore / 環境変数 BLOB_STORE に応じて添付ファイルのストレージを生成する。既定はローカルのファイルシステム。
+func newBlobStore() domainRepository.BlobStore {
+	if os.Getenv("BLOB_STORE") == "s3" {
+		return blob.NewS3BlobStore(blob.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getenv("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		})
+	}
+	return blob.NewLocalBlobStore(getenv("BLOB_LOCAL_DIR", "./tmp/blob"))
+}
+
+// newMailSender / 環境変数 MAIL_SENDER に応じてメールの送信先を生成する。既定はログへの出力。
+func newMailSender(logger echo.Logger) domainRepository.MailSender {
+	if os.Getenv("MAIL_SENDER") == "smtp" {
+		port, err := strconv.Atoi(getenv("SMTP_PORT", "587"))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		return mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     getenv("SMTP_HOST", "localhost"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getenv("MAIL_FROM", "noreply@example.com"),
+		})
+	}
+	return mail.NewLogSender(logger)
+}
+
+// newIdempotencyRepository / 環境変数 IDEMPOTENCY_STORE に応じて冪等キーの保存先を生成する。既定はプロセス内のメモリ。
+func newIdempotencyRepository(db *gorm.DB) domainRepository.IdempotencyRepository {
+	if os.Getenv("IDEMPOTENCY_STORE") == "mysql" {
+		return repository.NewIdempotencyRepository(db)
+	}
+	return memory.NewIdempotencyRepository()
+}
+
+// newTaskStreamBroker / 環境変数 TASK_STREAM_BROKER に応じてタスクの変更のブローカーを生成する。既定はプロセス内のメモリ。
+// 複数のサーバで動かす場合は mysql を指定する。
+func newTaskStreamBroker(db *gorm.DB, logger echo.Logger) domainRepository.TaskStreamBroker {
+	size, err := strconv.Atoi(getenv("TASK_STREAM_LOG_SIZE", "1000"))
+	if err != nil || size <= 0 {
+		logger.Fatal("TASK_STREAM_LOG_SIZE must be a positive number")
+	}
+	if os.Getenv("TASK_STREAM_BROKER") == "mysql" {
+		interval, err := time.ParseDuration(getenv("TASK_STREAM_POLL_INTERVAL", "1s"))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		return repository.NewTaskStreamBroker(db, size, interval)
+	}
+	return memory.NewTaskStreamBroker(size)
+}
+
+// parseDurations / カンマ区切りの時間の一覧を解釈する
+func parseDurations(s string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
 // HealthCheck
 //
 //	@Summary		ヘルスチェック
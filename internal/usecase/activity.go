@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// activitySubscriberName / 出来事の配信済みの記録に使う購読者の名前
+const activitySubscriberName = "activity"
+
+type ActivityUsecase interface {
+	// ListByCompanyID / 企業の出来事を新しい順に取得する。閲覧できないタスクの出来事は含まない。
+	ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier, filter model.ActivityFilter, cursor model.ActivityCursor) ([]*model.Activity, apperr.AppErr)
+}
+
+type activityUsecase struct {
+	userRepository     repository.UserRepository
+	activityRepository repository.ActivityRepository
+}
+
+func NewActivityUsecase(
+	userRepository repository.UserRepository,
+	activityRepository repository.ActivityRepository,
+) ActivityUsecase {
+	return &activityUsecase{
+		userRepository,
+		activityRepository,
+	}
+}
+
+func (u *activityUsecase) ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier, filter model.ActivityFilter, cursor model.ActivityCursor) ([]*model.Activity, apperr.AppErr) {
+	viewer, err := u.userRepository.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := u.activityRepository.ListByCompanyID(viewer, companyID, filter, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// activitySubscriber / 出来事を購読し、企業のタイムラインに記録する
+type activitySubscriber struct {
+	domainEventLoader
+	activityRepository repository.ActivityRepository
+}
+
+func NewActivitySubscriber(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	commentRepository repository.CommentRepository,
+	activityRepository repository.ActivityRepository,
+) DomainEventSubscriber {
+	return &activitySubscriber{
+		domainEventLoader{userRepository, taskRepository, commentRepository},
+		activityRepository,
+	}
+}
+
+func (s *activitySubscriber) Name() string {
+	return activitySubscriberName
+}
+
+func (s *activitySubscriber) Handle(event *model.DomainEvent) apperr.AppErr {
+	switch event.Type {
+	case model.DomainEventTypeTaskCreated, model.DomainEventTypeTaskUpdated:
+		task, err := s.task(model.TaskIdentifier(event.AggregateID))
+		if err != nil || task == nil {
+			return err
+		}
+		actor, err := s.actor(event)
+		if err != nil || actor == nil {
+			return err
+		}
+		return s.activityRepository.Create(model.NewTaskActivities(event, task, *actor)...)
+	case model.DomainEventTypeCommentCreated:
+		comment, task, err := s.comment(event)
+		if err != nil || comment == nil {
+			return err
+		}
+		return s.activityRepository.Create(model.NewCommentActivity(event, task, comment))
+	case model.DomainEventTypeUserCreated:
+		user, err := s.user(model.UserIdentifier(event.AggregateID))
+		if err != nil || user == nil {
+			return err
+		}
+		actor, err := s.actor(event)
+		if err != nil || actor == nil {
+			return err
+		}
+		return s.activityRepository.Create(model.NewUserActivity(event, user, *actor))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"io"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type AttachmentUsecase interface {
+	ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier) ([]*model.Attachment, apperr.AppErr)
+
+	Upload(taskID model.TaskIdentifier, params AttachmentUploadParams) (*model.AttachmentIdentifier, apperr.AppErr)
+	// Download / 添付ファイルとその実体を取得する。実体は呼び出し側で Close すること。
+	Download(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.AttachmentIdentifier) (*model.Attachment, io.ReadCloser, apperr.AppErr)
+	Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.AttachmentIdentifier) apperr.AppErr
+}
+
+// AttachmentUploadParams / 添付ファイルの登録に必要な情報。ContentType は内容から判定したものを渡す。
+type AttachmentUploadParams struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+	CreatorID   model.UserIdentifier
+}
+
+type attachmentUsecase struct {
+	userRepository           repository.UserRepository
+	taskRepository           repository.TaskRepository
+	companySettingRepository repository.CompanySettingRepository
+	attachmentRepository     repository.AttachmentRepository
+	blobStore                repository.BlobStore
+}
+
+func NewAttachmentUsecase(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	companySettingRepository repository.CompanySettingRepository,
+	attachmentRepository repository.AttachmentRepository,
+	blobStore repository.BlobStore,
+) AttachmentUsecase {
+	return &attachmentUsecase{
+		userRepository,
+		taskRepository,
+		companySettingRepository,
+		attachmentRepository,
+		blobStore,
+	}
+}
+
+func (u *attachmentUsecase) ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier) ([]*model.Attachment, apperr.AppErr) {
+	// 添付ファイルはタスクの公開範囲に従う
+	if _, err := u.taskRepository.Find(userID, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := u.attachmentRepository.ListByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (u *attachmentUsecase) Upload(taskID model.TaskIdentifier, params AttachmentUploadParams) (*model.AttachmentIdentifier, apperr.AppErr) {
+	creator, err := u.userRepository.Get(params.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := u.taskRepository.Find(creator.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := u.companySettingRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+	usedBytes, err := u.attachmentRepository.SumSizeByCompanyID(task.Creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := model.AttachmentDescription{
+		FileName:    params.FileName,
+		ContentType: params.ContentType,
+		Size:        params.Size,
+		Setting:     setting,
+		UsedBytes:   usedBytes,
+	}
+	attachment, err := model.NewAttachment(task, creator, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先に登録して容量を確保してから実体を保存する。保存に失敗した場合は登録を取り消す。
+	id, err := u.attachmentRepository.Create(attachment, setting.StorageQuota)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.blobStore.Put(attachment.StorageKey, params.Body, attachment.Size, attachment.ContentType); err != nil {
+		_ = u.attachmentRepository.Delete(*id)
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *attachmentUsecase) Download(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.AttachmentIdentifier) (*model.Attachment, io.ReadCloser, apperr.AppErr) {
+	task, err := u.taskRepository.Find(userID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachment, err := u.getAttachment(task.ID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := u.blobStore.Get(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+func (u *attachmentUsecase) Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.AttachmentIdentifier) apperr.AppErr {
+	task, err := u.taskRepository.Find(userID, taskID)
+	if err != nil {
+		return err
+	}
+	attachment, err := u.getAttachment(task.ID, id)
+	if err != nil {
+		return err
+	}
+
+	// 登録を先に削除する。実体の削除に失敗しても容量の集計には含まれない。
+	if err = u.attachmentRepository.Delete(attachment.ID); err != nil {
+		return err
+	}
+	if err = u.blobStore.Delete(attachment.StorageKey); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getAttachment / タスクの添付ファイルを取得する。他のタスクの添付ファイルは存在しないものとして扱う。
+func (u *attachmentUsecase) getAttachment(taskID model.TaskIdentifier, id model.AttachmentIdentifier) (*model.Attachment, apperr.AppErr) {
+	attachment, err := u.attachmentRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, apperr.NewNotFoundError()
+	}
+	return attachment, nil
+}
@@ -9,6 +9,8 @@ import (
 type AuthUsecase interface {
 	Create(params AuthCreateParams) (*model.UserIdentifier, apperr.AppErr)
 	Update(id model.UserIdentifier, params AuthUpdateParams) apperr.AppErr
+	// Patch / 指定された項目のみを現在の値に適用し、Update と同じ検証を経て更新する
+	Patch(id model.UserIdentifier, params AuthPatchParams) apperr.AppErr
 	Login(params AuthLoginParams) (*model.Auth, apperr.AppErr)
 	Get(id model.UserIdentifier) (*model.Auth, apperr.AppErr)
 }
@@ -19,12 +21,24 @@ type AuthCreateParams struct {
 	Role      model.UserRole
 	UserType  model.UserType
 	CompanyID model.CompanyIdentifier
+	CreatorID model.UserIdentifier
 }
 
 type AuthUpdateParams struct {
 	Name     string
 	Role     model.UserRole
 	UserType model.UserType
+	// Version / 更新の前提とする版数。nil の場合は検証しない。
+	Version *model.Version
+}
+
+// AuthPatchParams / ユーザの部分更新に必要な情報
+type AuthPatchParams struct {
+	Name     Patch[string]
+	Role     Patch[model.UserRole]
+	UserType Patch[model.UserType]
+	// Version / 更新の前提とする版数。nil の場合は検証しない。
+	Version *model.Version
 }
 
 type AuthLoginParams struct {
@@ -50,6 +64,11 @@ func (u *authUsecase) Create(
 	if err != nil {
 		return nil, err
 	}
+	creator, err := u.authRepository.Get(params.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	updator := creator.User()
 
 	authDescription := model.AuthDescription{
 		Name:     params.Name,
@@ -57,17 +76,13 @@ func (u *authUsecase) Create(
 		Role:     params.Role,
 		UserType: params.UserType,
 		Company:  company,
+		Updator:  &updator,
 	}
 	auth, err := model.NewAuth(authDescription)
 	if err != nil {
 		return nil, err
 	}
-	userID, err := u.authRepository.Create(auth)
-	if err != nil {
-		return nil, err
-	}
-
-	return userID, nil
+	return u.authRepository.Create(auth)
 }
 
 func (u *authUsecase) Update(
@@ -78,16 +93,44 @@ func (u *authUsecase) Update(
 	if err != nil {
 		return err
 	}
+	if err = auth.Version.Validate(params.Version); err != nil {
+		return err
+	}
+
+	return u.update(auth, params)
+}
+
+func (u *authUsecase) Patch(
+	id model.UserIdentifier,
+	params AuthPatchParams,
+) apperr.AppErr {
+	auth, err := u.authRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = auth.Version.Validate(params.Version); err != nil {
+		return err
+	}
+
+	update := AuthUpdateParams{
+		Name:     params.Name.ApplyValue(auth.Name),
+		Role:     params.Role.ApplyValue(auth.Role),
+		UserType: params.UserType.ApplyValue(auth.UserType),
+	}
+	return u.update(auth, update)
+}
 
+// update / 更新内容を検証してユーザを更新する
+func (u *authUsecase) update(auth *model.Auth, params AuthUpdateParams) apperr.AppErr {
 	desc := model.AuthDescription{
 		Name:     params.Name,
 		Role:     params.Role,
 		UserType: params.UserType,
 	}
-	if err = auth.Update(desc); err != nil {
+	if err := auth.Update(desc); err != nil {
 		return err
 	}
-	if err = u.authRepository.Update(auth); err != nil {
+	if err := u.authRepository.Update(auth); err != nil {
 		return err
 	}
 
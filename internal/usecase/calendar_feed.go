@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"net/url"
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type CalendarFeedUsecase interface {
+	// Get / 自身のカレンダーの購読の URL を取得する。初めての場合はトークンを発行する。
+	Get(userID model.UserIdentifier) (*CalendarFeedLink, apperr.AppErr)
+	// Regenerate / トークンを発行し直す。以前の URL では取得できなくなる。
+	Regenerate(userID model.UserIdentifier) (*CalendarFeedLink, apperr.AppErr)
+	// ListTasks / トークンのユーザが閲覧できるタスクのうち、範囲と絞り込みに一致するものを取得する
+	ListTasks(token string, params CalendarFeedParams) ([]*model.Task, apperr.AppErr)
+}
+
+// CalendarFeedLink / カレンダーの購読の URL
+type CalendarFeedLink struct {
+	URL      string
+	CreateAt time.Time
+}
+
+// CalendarFeedParams / カレンダーに載せるタスクの指定。絞り込みはタスク一覧と同じ。
+type CalendarFeedParams struct {
+	Scope model.CalendarFeedScope
+	Tasks TaskListParams
+}
+
+type calendarFeedUsecase struct {
+	userRepository         repository.UserRepository
+	calendarFeedRepository repository.CalendarFeedRepository
+	taskUsecase            TaskUsecase
+	publicURL              string
+}
+
+func NewCalendarFeedUsecase(
+	userRepository repository.UserRepository,
+	calendarFeedRepository repository.CalendarFeedRepository,
+	taskUsecase TaskUsecase,
+	publicURL string,
+) CalendarFeedUsecase {
+	return &calendarFeedUsecase{
+		userRepository,
+		calendarFeedRepository,
+		taskUsecase,
+		publicURL,
+	}
+}
+
+func (u *calendarFeedUsecase) Get(userID model.UserIdentifier) (*CalendarFeedLink, apperr.AppErr) {
+	feed, err := u.calendarFeedRepository.Get(userID)
+	if err != nil {
+		if err.Code() != apperr.ErrorCodeNotFound {
+			return nil, err
+		}
+		feed, err = model.NewCalendarFeed(userID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := u.calendarFeedRepository.Save(feed); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.link(feed), nil
+}
+
+func (u *calendarFeedUsecase) Regenerate(userID model.UserIdentifier) (*CalendarFeedLink, apperr.AppErr) {
+	feed, err := model.NewCalendarFeed(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.calendarFeedRepository.Save(feed); err != nil {
+		return nil, err
+	}
+
+	return u.link(feed), nil
+}
+
+func (u *calendarFeedUsecase) ListTasks(token string, params CalendarFeedParams) ([]*model.Task, apperr.AppErr) {
+	feed, err := u.calendarFeedRepository.GetByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userRepository.Get(feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 閲覧の制限はトークンのユーザとして一覧を取得することで守る
+	var tasks []*model.Task
+	switch params.Scope {
+	case model.CalendarFeedScopeCompany:
+		tasks, err = u.taskUsecase.ListByCompanyID(user.ID, user.Company.ID, params.Tasks)
+	default:
+		tasks, err = u.taskUsecase.ListByAssignedUserID(user.ID, user.ID, params.Tasks)
+	}
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return []*model.Task{}, nil
+		}
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (u *calendarFeedUsecase) link(feed *model.CalendarFeed) *CalendarFeedLink {
+	return &CalendarFeedLink{
+		URL:      newCalendarFeedURL(u.publicURL, feed.Token),
+		CreateAt: feed.CreateAt,
+	}
+}
+
+func newCalendarFeedURL(publicURL, token string) string {
+	return publicURL + "/api/v1/calendar/" + url.PathEscape(token) + ".ics"
+}
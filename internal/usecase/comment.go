@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type CommentUsecase interface {
+	// ListByTaskID / 閲覧可能なタスクのコメントを取得する。全件数も返す。
+	ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier, page model.Page) ([]*model.Comment, int, apperr.AppErr)
+
+	Create(taskID model.TaskIdentifier, params CommentParams) (*model.CommentIdentifier, apperr.AppErr)
+	Update(taskID model.TaskIdentifier, id model.CommentIdentifier, params CommentParams) apperr.AppErr
+	Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.CommentIdentifier) apperr.AppErr
+}
+
+// CommentParams / コメントの投稿・編集に必要な情報。UserID は投稿者または編集者。
+type CommentParams struct {
+	Body   string
+	UserID model.UserIdentifier
+}
+
+type commentUsecase struct {
+	userRepository    repository.UserRepository
+	taskRepository    repository.TaskRepository
+	commentRepository repository.CommentRepository
+}
+
+func NewCommentUsecase(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	commentRepository repository.CommentRepository,
+) CommentUsecase {
+	return &commentUsecase{
+		userRepository,
+		taskRepository,
+		commentRepository,
+	}
+}
+
+func (u *commentUsecase) ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier, page model.Page) ([]*model.Comment, int, apperr.AppErr) {
+	// コメントはタスクの公開範囲に従う
+	if _, err := u.taskRepository.Find(userID, taskID); err != nil {
+		return nil, 0, err
+	}
+
+	comments, total, err := u.commentRepository.ListByTaskID(taskID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (u *commentUsecase) Create(taskID model.TaskIdentifier, params CommentParams) (*model.CommentIdentifier, apperr.AppErr) {
+	author, err := u.userRepository.Get(params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := u.taskRepository.Find(author.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := u.resolveMentions(task, params.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := model.CommentDescription{
+		Body:     params.Body,
+		Mentions: mentions,
+	}
+	comment, err := model.NewComment(task, author, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.commentRepository.Create(comment)
+}
+
+func (u *commentUsecase) Update(taskID model.TaskIdentifier, id model.CommentIdentifier, params CommentParams) apperr.AppErr {
+	editor, err := u.userRepository.Get(params.UserID)
+	if err != nil {
+		return err
+	}
+	task, err := u.taskRepository.Find(editor.ID, taskID)
+	if err != nil {
+		return err
+	}
+	comment, err := u.getComment(task.ID, id)
+	if err != nil {
+		return err
+	}
+
+	mentions, err := u.resolveMentions(task, params.Body)
+	if err != nil {
+		return err
+	}
+
+	desc := model.CommentDescription{
+		Body:     params.Body,
+		Mentions: mentions,
+	}
+	if err = comment.Update(editor, desc); err != nil {
+		return err
+	}
+
+	return u.commentRepository.Update(comment)
+}
+
+func (u *commentUsecase) Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.CommentIdentifier) apperr.AppErr {
+	user, err := u.userRepository.Get(userID)
+	if err != nil {
+		return err
+	}
+	task, err := u.taskRepository.Find(user.ID, taskID)
+	if err != nil {
+		return err
+	}
+	comment, err := u.getComment(task.ID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = comment.Delete(user); err != nil {
+		return err
+	}
+
+	if err = u.commentRepository.Update(comment); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getComment / タスクのコメントを取得する。他のタスクのコメントと削除済みのコメントは存在しないものとして扱う。
+func (u *commentUsecase) getComment(taskID model.TaskIdentifier, id model.CommentIdentifier) (*model.Comment, apperr.AppErr) {
+	comment, err := u.commentRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskID != taskID || comment.IsDeleted() {
+		return nil, apperr.NewNotFoundError()
+	}
+	return comment, nil
+}
+
+// resolveMentions / 本文中の @ユーザ名 をタスクを閲覧できる企業のユーザに解決する。該当しない名前は無視する。
+func (u *commentUsecase) resolveMentions(task *model.Task, body string) ([]*model.User, apperr.AppErr) {
+	names := model.ParseMentions(body)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	users, err := u.userRepository.ListByNames(task.Creator.Company.ID, names)
+	if err != nil {
+		return nil, err
+	}
+	var mentions []*model.User
+	for _, user := range users {
+		if task.IsVisibleTo(user) {
+			mentions = append(mentions, user)
+		}
+	}
+	return mentions, nil
+}
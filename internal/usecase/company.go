@@ -9,14 +9,19 @@ import (
 type CompanyUsecase interface {
 	Get(id model.CompanyIdentifier) (*model.Company, apperr.AppErr)
 	Create(name string) (*model.CompanyIdentifier, apperr.AppErr)
-	Update(id model.CompanyIdentifier, name string) apperr.AppErr
+	// Update / 企業名を更新する。version が nil の場合は版数を検証しない。
+	Update(id model.CompanyIdentifier, name string, version *model.Version) apperr.AppErr
+	// Patch / 企業名が指定されている場合のみ更新する。検証は Update と同じ。
+	Patch(id model.CompanyIdentifier, name Patch[string], version *model.Version) apperr.AppErr
 }
 
 type companyUsecase struct {
 	companyRepository repository.CompanyRepository
 }
 
-func NewCompanyUsecase(companyRepository repository.CompanyRepository) CompanyUsecase {
+func NewCompanyUsecase(
+	companyRepository repository.CompanyRepository,
+) CompanyUsecase {
 	return &companyUsecase{
 		companyRepository,
 	}
@@ -43,6 +48,7 @@ func (u *companyUsecase) Create(
 	if err != nil {
 		return nil, err
 	}
+	// 新規企業には既定のワークフローと優先度、設定を同じトランザクションで登録する
 	companyID, err := u.companyRepository.Create(company)
 	if err != nil {
 		return nil, err
@@ -54,16 +60,41 @@ func (u *companyUsecase) Create(
 func (u *companyUsecase) Update(
 	id model.CompanyIdentifier,
 	name string,
+	version *model.Version,
 ) apperr.AppErr {
 	company, err := u.companyRepository.Get(id)
 	if err != nil {
 		return err
 	}
+	if err = company.Version.Validate(version); err != nil {
+		return err
+	}
+
+	return u.update(company, name)
+}
+
+func (u *companyUsecase) Patch(
+	id model.CompanyIdentifier,
+	name Patch[string],
+	version *model.Version,
+) apperr.AppErr {
+	company, err := u.companyRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = company.Version.Validate(version); err != nil {
+		return err
+	}
+
+	return u.update(company, name.ApplyValue(company.Name))
+}
 
+// update / 更新内容を検証して企業を更新する
+func (u *companyUsecase) update(company *model.Company, name string) apperr.AppErr {
 	desc := model.CompanyDescipriton{
 		Name: name,
 	}
-	if err = company.Update(desc); err != nil {
+	if err := company.Update(desc); err != nil {
 		return err
 	}
 
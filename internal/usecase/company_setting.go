@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type CompanySettingUsecase interface {
+	Get(companyID model.CompanyIdentifier) (*model.CompanySetting, apperr.AppErr)
+	Update(companyID model.CompanyIdentifier, params CompanySettingParams) apperr.AppErr
+}
+
+type CompanySettingParams struct {
+	MaxTaskDepth          int
+	RequireClosedSubtasks bool
+	MaxAttachmentSize     int64
+	AllowedMimeTypes      []string
+	StorageQuota          int64
+}
+
+type companySettingUsecase struct {
+	companySettingRepository repository.CompanySettingRepository
+}
+
+func NewCompanySettingUsecase(companySettingRepository repository.CompanySettingRepository) CompanySettingUsecase {
+	return &companySettingUsecase{
+		companySettingRepository,
+	}
+}
+
+func (u *companySettingUsecase) Get(companyID model.CompanyIdentifier) (*model.CompanySetting, apperr.AppErr) {
+	setting, err := u.companySettingRepository.Get(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *companySettingUsecase) Update(companyID model.CompanyIdentifier, params CompanySettingParams) apperr.AppErr {
+	setting, err := u.companySettingRepository.Get(companyID)
+	if err != nil {
+		return err
+	}
+
+	desc := model.CompanySettingDescription{
+		MaxTaskDepth:          params.MaxTaskDepth,
+		RequireClosedSubtasks: params.RequireClosedSubtasks,
+		MaxAttachmentSize:     params.MaxAttachmentSize,
+		AllowedMimeTypes:      params.AllowedMimeTypes,
+		StorageQuota:          params.StorageQuota,
+	}
+	if err = setting.Update(desc); err != nil {
+		return err
+	}
+
+	if err = u.companySettingRepository.Save(setting); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// domainEventBatchSize / 一度の実行で配信する出来事の件数の上限
+const domainEventBatchSize = 100
+
+// DomainEventSubscriber / 出来事の購読者。同じ出来事が複数回配信されることがあるため、冪等に処理する。
+type DomainEventSubscriber interface {
+	// Name / 配信済みの記録に使う購読者の名前。購読者ごとに一意で、変更しない。
+	Name() string
+	Handle(event *model.DomainEvent) apperr.AppErr
+}
+
+// DomainEventDispatcher / アウトボックスの出来事を登録された購読者に少なくとも一度配信する。
+// 同じ集約の出来事は記録順に配信し、前の出来事の配信が終わるまで後の出来事は配信しない。
+type DomainEventDispatcher interface {
+	// Subscribe / 購読者を登録する。配信を始める前に登録する。
+	Subscribe(subscriber DomainEventSubscriber)
+	// Dispatch / 配信待ちの出来事を配信し、配信を終えた件数を返す。失敗した出来事は間隔を空けて再送する。
+	Dispatch(now time.Time) (int, apperr.AppErr)
+}
+
+type domainEventDispatcher struct {
+	domainEventRepository repository.DomainEventRepository
+	subscribers           []DomainEventSubscriber
+}
+
+func NewDomainEventDispatcher(domainEventRepository repository.DomainEventRepository) DomainEventDispatcher {
+	return &domainEventDispatcher{
+		domainEventRepository: domainEventRepository,
+	}
+}
+
+func (d *domainEventDispatcher) Subscribe(subscriber DomainEventSubscriber) {
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+func (d *domainEventDispatcher) Dispatch(now time.Time) (int, apperr.AppErr) {
+	events, err := d.domainEventRepository.ListPending(now, domainEventBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// 配信に失敗して再送を待つことになった集約は、順序を保つため後の出来事も配信しない
+	blocked := make(map[string]bool)
+	dispatched := 0
+	for _, event := range events {
+		key := event.AggregateKey()
+		if blocked[key] {
+			continue
+		}
+
+		var herr apperr.AppErr
+		for _, subscriber := range d.subscribers {
+			if event.IsDeliveredTo(subscriber.Name()) {
+				continue
+			}
+			if herr = subscriber.Handle(event); herr != nil {
+				break
+			}
+			event.Deliver(subscriber.Name())
+		}
+		if herr != nil {
+			event.Fail(herr.Message(), time.Now())
+			// 上限に達して失敗とした場合は後の出来事の配信を続ける
+			if event.Status == model.DomainEventStatusPending {
+				blocked[key] = true
+			}
+		} else {
+			event.Dispatched(time.Now())
+			dispatched++
+		}
+		if err := d.domainEventRepository.Update(event); err != nil {
+			return dispatched, err
+		}
+	}
+
+	return dispatched, nil
+}
+
+// domainEventLoader / 購読者が出来事の集約と変更したユーザを配信する時点の状態で取得する。
+// 出来事の後に削除された集約やユーザは nil を返し、購読者は配信を終えたものとして扱う。
+type domainEventLoader struct {
+	userRepository    repository.UserRepository
+	taskRepository    repository.TaskRepository
+	commentRepository repository.CommentRepository
+}
+
+// actor / 出来事を起こしたユーザ。記録されていない場合は nil を返す。
+func (l *domainEventLoader) actor(event *model.DomainEvent) (*model.User, apperr.AppErr) {
+	if event.ActorID == nil {
+		return nil, nil
+	}
+	return l.user(*event.ActorID)
+}
+
+func (l *domainEventLoader) user(id model.UserIdentifier) (*model.User, apperr.AppErr) {
+	user, err := l.userRepository.Get(id)
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// task / 削除されたタスクを含めて取得する
+func (l *domainEventLoader) task(id model.TaskIdentifier) (*model.Task, apperr.AppErr) {
+	task, err := l.taskRepository.GetIncludingDeleted(id)
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// comment / コメントの出来事のコメントとそのタスク
+func (l *domainEventLoader) comment(event *model.DomainEvent) (*model.Comment, *model.Task, apperr.AppErr) {
+	comment, err := l.commentRepository.Get(model.CommentIdentifier(event.AggregateID))
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	task, err := l.task(comment.TaskID)
+	if err != nil || task == nil {
+		return nil, nil, err
+	}
+	return comment, task, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type IdempotencyUsecase interface {
+	// Begin / 冪等キーの処理を開始する。記録済みのレスポンスがある場合はその記録を、ない場合は処理中として保存した記録を返す。
+	Begin(userID model.UserIdentifier, key, requestHash string) (*model.IdempotencyRecord, apperr.AppErr)
+	// Complete / 処理中の記録にレスポンスを保存する
+	Complete(record *model.IdempotencyRecord, statusCode int, contentType string, body []byte) apperr.AppErr
+}
+
+type idempotencyUsecase struct {
+	idempotencyRepository repository.IdempotencyRepository
+	ttl                   time.Duration
+}
+
+func NewIdempotencyUsecase(
+	idempotencyRepository repository.IdempotencyRepository,
+	ttl time.Duration,
+) IdempotencyUsecase {
+	return &idempotencyUsecase{
+		idempotencyRepository,
+		ttl,
+	}
+}
+
+func (u *idempotencyUsecase) Begin(userID model.UserIdentifier, key, requestHash string) (*model.IdempotencyRecord, apperr.AppErr) {
+	record, err := model.NewIdempotencyRecord(userID, key, requestHash, time.Now(), u.ttl)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := u.idempotencyRepository.Reserve(record)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return record, nil
+	}
+
+	existing, err := u.idempotencyRepository.Get(userID, key)
+	if err != nil {
+		// 保存に失敗した直後に期限切れとなった場合など。再試行を促す。
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil, apperr.NewConflictError()
+		}
+		return nil, err
+	}
+	if err = existing.ValidateReplay(requestHash); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (u *idempotencyUsecase) Complete(record *model.IdempotencyRecord, statusCode int, contentType string, body []byte) apperr.AppErr {
+	record.Complete(statusCode, contentType, body)
+	return u.idempotencyRepository.Complete(record)
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type LabelUsecase interface {
+	ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Label, apperr.AppErr)
+
+	Create(companyID model.CompanyIdentifier, params LabelParams) (*model.LabelIdentifier, apperr.AppErr)
+	Update(companyID model.CompanyIdentifier, id model.LabelIdentifier, params LabelParams) apperr.AppErr
+	// Delete / ラベルを削除する。付与されているタスクからは外し、タスクの変更として記録する。
+	Delete(userID model.UserIdentifier, companyID model.CompanyIdentifier, id model.LabelIdentifier) apperr.AppErr
+}
+
+type LabelParams struct {
+	Name  string
+	Color string
+}
+
+type labelUsecase struct {
+	userRepository  repository.UserRepository
+	taskRepository  repository.TaskRepository
+	labelRepository repository.LabelRepository
+}
+
+func NewLabelUsecase(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	labelRepository repository.LabelRepository,
+) LabelUsecase {
+	return &labelUsecase{
+		userRepository,
+		taskRepository,
+		labelRepository,
+	}
+}
+
+func (u *labelUsecase) ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Label, apperr.AppErr) {
+	labels, err := u.labelRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (u *labelUsecase) Create(companyID model.CompanyIdentifier, params LabelParams) (*model.LabelIdentifier, apperr.AppErr) {
+	desc := model.LabelDescription{
+		Name:  params.Name,
+		Color: params.Color,
+	}
+	label, err := model.NewLabel(companyID, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.labelRepository.Create(label)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *labelUsecase) Update(companyID model.CompanyIdentifier, id model.LabelIdentifier, params LabelParams) apperr.AppErr {
+	label, err := u.labelRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	// 他社のラベルは存在しないものとして扱う
+	if label.CompanyID != companyID {
+		return apperr.NewNotFoundError()
+	}
+
+	desc := model.LabelDescription{
+		Name:  params.Name,
+		Color: params.Color,
+	}
+	if err = label.Update(desc); err != nil {
+		return err
+	}
+
+	if err = u.labelRepository.Update(label); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *labelUsecase) Delete(userID model.UserIdentifier, companyID model.CompanyIdentifier, id model.LabelIdentifier) apperr.AppErr {
+	label, err := u.labelRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	if label.CompanyID != companyID {
+		return apperr.NewNotFoundError()
+	}
+	updator, err := u.userRepository.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := u.taskRepository.ListByLabelID(id)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.RemoveLabel(id, updator)
+	}
+
+	if err = u.labelRepository.Delete(id, tasks); err != nil {
+		return err
+	}
+
+	return nil
+}
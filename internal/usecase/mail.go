@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// mailBatchSize / 一度の実行で送るメールの件数の上限
+const mailBatchSize = 100
+
+type MailUsecase interface {
+	// DeliverPending / 送信待ちのメールを送り、送った件数を返す。失敗したメールは間隔を空けて再送する。
+	DeliverPending(now time.Time) (int, apperr.AppErr)
+	// QueueDigests / ダイジェストの時刻を迎えたユーザに前回以降の通知をまとめたメールを送信待ちにし、その件数を返す
+	QueueDigests(now time.Time) (int, apperr.AppErr)
+	// Unsubscribe / 配信停止のトークンのユーザへのメールを停止する
+	Unsubscribe(token string) apperr.AppErr
+}
+
+type mailUsecase struct {
+	mailRepository                   repository.MailRepository
+	mailSender                       repository.MailSender
+	mailRenderer                     repository.MailRenderer
+	notificationRepository           repository.NotificationRepository
+	notificationPreferenceRepository repository.NotificationPreferenceRepository
+	taskRepository                   repository.TaskRepository
+	publicURL                        string
+	// digestHour / ダイジェストを送る時刻（0〜23時）
+	digestHour int
+}
+
+func NewMailUsecase(
+	mailRepository repository.MailRepository,
+	mailSender repository.MailSender,
+	mailRenderer repository.MailRenderer,
+	notificationRepository repository.NotificationRepository,
+	notificationPreferenceRepository repository.NotificationPreferenceRepository,
+	taskRepository repository.TaskRepository,
+	publicURL string,
+	digestHour int,
+) MailUsecase {
+	return &mailUsecase{
+		mailRepository,
+		mailSender,
+		mailRenderer,
+		notificationRepository,
+		notificationPreferenceRepository,
+		taskRepository,
+		publicURL,
+		digestHour,
+	}
+}
+
+func (u *mailUsecase) DeliverPending(now time.Time) (int, apperr.AppErr) {
+	messages, err := u.mailRepository.ListDue(now, mailBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, message := range messages {
+		if err := u.mailSender.Send(message); err != nil {
+			message.Fail(err.Message(), time.Now())
+		} else {
+			message.Sent(time.Now())
+			sent++
+		}
+		if err := u.mailRepository.Update(message); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+func (u *mailUsecase) QueueDigests(now time.Time) (int, apperr.AppErr) {
+	preferences, err := u.notificationPreferenceRepository.ListDigest()
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, preference := range preferences {
+		if !preference.IsDigestDue(now, u.digestHour) {
+			continue
+		}
+		message, err := u.newDigest(preference, now)
+		if err != nil {
+			return queued, err
+		}
+		if message != nil {
+			if err = u.mailRepository.Create(message); err != nil {
+				return queued, err
+			}
+			queued++
+		}
+		// 通知がなかった場合も次のダイジェストの起点とする
+		preference.LastDigestAt = &now
+		if err = u.notificationPreferenceRepository.Save(preference); err != nil {
+			return queued, err
+		}
+	}
+
+	return queued, nil
+}
+
+// newDigest / 前回以降のメールで送る種類の通知をまとめたメールを生成する。通知がない場合は nil を返す。
+func (u *mailUsecase) newDigest(preference *model.NotificationPreference, now time.Time) (*model.MailMessage, apperr.AppErr) {
+	notifications, err := u.notificationRepository.ListSince(preference.UserID, preference.DigestSince(now))
+	if err != nil {
+		return nil, err
+	}
+	var items []*model.Notification
+	var ids []model.TaskIdentifier
+	for _, notification := range notifications {
+		if preference.AllowsEmail(notification.Type) {
+			items = append(items, notification)
+			ids = append(ids, notification.TaskID)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := u.taskRepository.ListByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	taskMap := make(map[model.TaskIdentifier]*model.Task)
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	unsubscribeURL := newUnsubscribeURL(u.publicURL, preference.UnsubscribeToken)
+	subject, body, err := u.mailRenderer.RenderDigest(preference.EmailLanguage, items, taskMap, unsubscribeURL)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewMailMessage(preference.UserID, *preference.EmailAddress, subject, body, unsubscribeURL, now), nil
+}
+
+func (u *mailUsecase) Unsubscribe(token string) apperr.AppErr {
+	if token == "" {
+		return apperr.NewNotFoundError()
+	}
+	preference, err := u.notificationPreferenceRepository.GetByUnsubscribeToken(token)
+	if err != nil {
+		return err
+	}
+
+	preference.Unsubscribe()
+
+	return u.notificationPreferenceRepository.Save(preference)
+}
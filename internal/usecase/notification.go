@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type NotificationUsecase interface {
+	// List / 自身の通知を未読を先に新しい順で取得する。全件数と未読の件数も返す。
+	List(userID model.UserIdentifier, page model.Page) ([]*model.Notification, int, int, apperr.AppErr)
+	CountUnread(userID model.UserIdentifier) (int, apperr.AppErr)
+	Read(userID model.UserIdentifier, id model.NotificationIdentifier) apperr.AppErr
+	ReadAll(userID model.UserIdentifier) apperr.AppErr
+
+	GetPreference(userID model.UserIdentifier) (*model.NotificationPreference, apperr.AppErr)
+	UpdatePreference(params NotificationPreferenceParams) apperr.AppErr
+}
+
+// NotificationPreferenceParams / 通知の設定の更新に必要な情報。指定のない項目は変更しない。
+type NotificationPreferenceParams struct {
+	InApp         map[model.NotificationType]bool
+	Email         map[model.NotificationType]bool
+	EmailAddress  *string
+	EmailLanguage *model.Language
+	EmailMode     *model.EmailMode
+	UserID        model.UserIdentifier
+}
+
+type notificationUsecase struct {
+	notificationRepository           repository.NotificationRepository
+	notificationPreferenceRepository repository.NotificationPreferenceRepository
+}
+
+func NewNotificationUsecase(
+	notificationRepository repository.NotificationRepository,
+	notificationPreferenceRepository repository.NotificationPreferenceRepository,
+) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepository,
+		notificationPreferenceRepository,
+	}
+}
+
+func (u *notificationUsecase) List(userID model.UserIdentifier, page model.Page) ([]*model.Notification, int, int, apperr.AppErr) {
+	notifications, total, err := u.notificationRepository.ListByUserID(userID, page)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	unread, err := u.notificationRepository.CountUnread(userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return notifications, total, unread, nil
+}
+
+func (u *notificationUsecase) CountUnread(userID model.UserIdentifier) (int, apperr.AppErr) {
+	return u.notificationRepository.CountUnread(userID)
+}
+
+func (u *notificationUsecase) Read(userID model.UserIdentifier, id model.NotificationIdentifier) apperr.AppErr {
+	notification, err := u.notificationRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	// 他のユーザの通知は存在しないものとして扱う
+	if notification.UserID != userID {
+		return apperr.NewNotFoundError()
+	}
+
+	notification.Read(time.Now())
+
+	return u.notificationRepository.Update(notification)
+}
+
+func (u *notificationUsecase) ReadAll(userID model.UserIdentifier) apperr.AppErr {
+	return u.notificationRepository.ReadAll(userID, time.Now())
+}
+
+func (u *notificationUsecase) GetPreference(userID model.UserIdentifier) (*model.NotificationPreference, apperr.AppErr) {
+	return u.notificationPreferenceRepository.Get(userID)
+}
+
+func (u *notificationUsecase) UpdatePreference(params NotificationPreferenceParams) apperr.AppErr {
+	preference, err := u.notificationPreferenceRepository.Get(params.UserID)
+	if err != nil {
+		return err
+	}
+
+	desc := model.NotificationPreferenceDescription{
+		InApp:         params.InApp,
+		Email:         params.Email,
+		EmailAddress:  params.EmailAddress,
+		EmailLanguage: params.EmailLanguage,
+		EmailMode:     params.EmailMode,
+	}
+	if err = preference.Update(desc); err != nil {
+		return err
+	}
+
+	return u.notificationPreferenceRepository.Save(preference)
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"net/url"
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// notificationSubscriberName / 出来事の配信済みの記録に使う購読者の名前
+const notificationSubscriberName = "notification"
+
+// Notifier / 受け取るユーザの設定に従って通知を保存し、メールを送信待ちにする。通知を生成する各ユースケースで共有する。
+// 通知とメールは同じトランザクションで保存し、同じ出来事による通知は再び配信されても重複しない。
+type Notifier interface {
+	Notify(notifications ...*model.Notification) apperr.AppErr
+}
+
+type notifier struct {
+	notificationRepository           repository.NotificationRepository
+	notificationPreferenceRepository repository.NotificationPreferenceRepository
+	taskRepository                   repository.TaskRepository
+	mailRenderer                     repository.MailRenderer
+	// publicURL / メール中の配信停止のリンクの基準となるURL
+	publicURL string
+}
+
+func NewNotifier(
+	notificationRepository repository.NotificationRepository,
+	notificationPreferenceRepository repository.NotificationPreferenceRepository,
+	taskRepository repository.TaskRepository,
+	mailRenderer repository.MailRenderer,
+	publicURL string,
+) Notifier {
+	return &notifier{
+		notificationRepository,
+		notificationPreferenceRepository,
+		taskRepository,
+		mailRenderer,
+		publicURL,
+	}
+}
+
+func (n *notifier) Notify(notifications ...*model.Notification) apperr.AppErr {
+	preferences := make(map[model.UserIdentifier]*model.NotificationPreference)
+	var inApp, email []*model.Notification
+	for _, notification := range notifications {
+		preference, ok := preferences[notification.UserID]
+		if !ok {
+			var err apperr.AppErr
+			if preference, err = n.notificationPreferenceRepository.Get(notification.UserID); err != nil {
+				return err
+			}
+			preferences[notification.UserID] = preference
+		}
+		if preference.AllowsInApp(notification.Type) {
+			inApp = append(inApp, notification)
+		}
+		if preference.EmailMode == model.EmailModeImmediate && preference.AllowsEmail(notification.Type) {
+			email = append(email, notification)
+		}
+	}
+
+	// ダイジェストのメールは保存した通知から後でまとめて送る
+	var messages []*model.MailMessage
+	for _, notification := range email {
+		preference := preferences[notification.UserID]
+		task, err := n.findTask(notification.TaskID)
+		if err != nil {
+			return err
+		}
+		unsubscribeURL := newUnsubscribeURL(n.publicURL, preference.UnsubscribeToken)
+		subject, body, err := n.mailRenderer.RenderNotification(preference.EmailLanguage, notification, task, unsubscribeURL)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, model.NewNotificationMailMessage(notification, *preference.EmailAddress, subject, body, unsubscribeURL, time.Now()))
+	}
+	return n.notificationRepository.Create(inApp, messages)
+}
+
+// findTask / 通知のタスクを取得する。削除されている場合は nil を返す。
+func (n *notifier) findTask(id model.TaskIdentifier) (*model.Task, apperr.AppErr) {
+	task, err := n.taskRepository.Get(id)
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// newUnsubscribeURL / メールの配信停止のURL
+func newUnsubscribeURL(publicURL, token string) string {
+	return publicURL + "/api/v1/notification/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// notificationSubscriber / 出来事を購読し、タスクの担当者やウォッチャー、メンションされたユーザに通知する
+type notificationSubscriber struct {
+	domainEventLoader
+	notifier Notifier
+}
+
+func NewNotificationSubscriber(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	commentRepository repository.CommentRepository,
+	notifier Notifier,
+) DomainEventSubscriber {
+	return &notificationSubscriber{
+		domainEventLoader{userRepository, taskRepository, commentRepository},
+		notifier,
+	}
+}
+
+func (s *notificationSubscriber) Name() string {
+	return notificationSubscriberName
+}
+
+// Handle / 取り込みによる出来事と、配信する時点で削除されているタスクやコメントについては通知しない
+func (s *notificationSubscriber) Handle(event *model.DomainEvent) apperr.AppErr {
+	if event.Imported {
+		return nil
+	}
+	switch event.Type {
+	case model.DomainEventTypeTaskCreated, model.DomainEventTypeTaskUpdated:
+		task, err := s.task(model.TaskIdentifier(event.AggregateID))
+		if err != nil || task == nil || task.DeleteAt != nil {
+			return err
+		}
+		actor, err := s.actor(event)
+		if err != nil || actor == nil {
+			return err
+		}
+		return s.notifier.Notify(model.NewTaskNotifications(event, task, *actor)...)
+	case model.DomainEventTypeCommentCreated, model.DomainEventTypeCommentUpdated:
+		comment, task, err := s.comment(event)
+		if err != nil || comment == nil || comment.IsDeleted() || task.DeleteAt != nil {
+			return err
+		}
+		return s.notifier.Notify(model.NewMentionNotifications(event, task, comment)...)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+// Patch / 部分更新の項目。Set が false の場合は変更せず、Set が true で Value が nil の場合は値を消去する。
+type Patch[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// NewPatch / 値を置き換える項目を生成する
+func NewPatch[T any](value T) Patch[T] {
+	return Patch[T]{Set: true, Value: &value}
+}
+
+// Apply / 項目が指定されている場合は置き換えた値を返す
+func (p Patch[T]) Apply(current *T) *T {
+	if !p.Set {
+		return current
+	}
+	return p.Value
+}
+
+// ApplyValue / 消去できない項目に適用する。値のない指定は無視する。
+func (p Patch[T]) ApplyValue(current T) T {
+	if !p.Set || p.Value == nil {
+		return current
+	}
+	return *p.Value
+}
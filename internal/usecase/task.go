@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"slices"
 	"time"
 	"todo_api/internal/domain/model"
 	"todo_api/internal/domain/repository"
@@ -9,12 +10,27 @@ import (
 
 type TaskUsecase interface {
 	Find(userID model.UserIdentifier, id model.TaskIdentifier) (*model.Task, apperr.AppErr)
-	ListByAssignedUserID(userID, assignedUserID model.UserIdentifier) ([]*model.Task, apperr.AppErr)
-	ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier) ([]*model.Task, apperr.AppErr)
+	ListByAssignedUserID(userID, assignedUserID model.UserIdentifier, params TaskListParams) ([]*model.Task, apperr.AppErr)
+	ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier, params TaskListParams) ([]*model.Task, apperr.AppErr)
 
 	Create(params TaskCreateParams) (*model.TaskIdentifier, apperr.AppErr)
+	// NewTask / 作成の情報から関連する値を解決し、作成と同じ検証を行ったタスクを生成する。タスクは保存しない。
+	NewTask(params TaskCreateParams) (*model.Task, apperr.AppErr)
+	// Update / 更新者が閲覧できる、指定した企業のタスクを更新する
 	Update(id model.TaskIdentifier, params TaskUpdateParams) apperr.AppErr
-	UpdateStatus(id model.TaskIdentifier, status model.TaskStatus) apperr.AppErr
+	// Patch / 指定された項目のみを現在の値に適用し、Update と同じ検証を経て更新する
+	Patch(id model.TaskIdentifier, params TaskPatchParams) apperr.AppErr
+	// UpdateStatus / 更新者が閲覧できる、指定した企業のタスクのステータスを更新する
+	UpdateStatus(id model.TaskIdentifier, params TaskUpdateStatusParams) apperr.AppErr
+	// Bulk / 複数のタスクに操作を適用する。項目ごとに閲覧と企業の所属を検証する。
+	Bulk(params TaskBulkParams) (*TaskBulkResult, apperr.AppErr)
+
+	// SetRecurrence / タスクを繰り返すようにする。系列に属する場合は以降の回の規則を置き換える。
+	SetRecurrence(id model.TaskIdentifier, params TaskRecurrenceParams) apperr.AppErr
+	// DeleteRecurrence / 繰り返しを終了する。生成済みの回は残る。
+	DeleteRecurrence(userID model.UserIdentifier, id model.TaskIdentifier) apperr.AppErr
+	// GenerateDueOccurrences / 期限を過ぎた回の次の回を生成し、生成した件数を返す。同じ回は二度生成しない。
+	GenerateDueOccurrences(now time.Time) (int, apperr.AppErr)
 }
 
 type TaskCreateParams struct {
@@ -22,32 +38,122 @@ type TaskCreateParams struct {
 	Detail           *string
 	Visibility       model.TaskVisibility
 	PersonInChargeID *model.UserIdentifier
+	Priority         *string
+	StartDate        *time.Time
 	LimitDate        *time.Time
-	CreatorID        model.UserIdentifier
+	LabelIDs         []model.LabelIdentifier
+	ParentID         *model.TaskIdentifier
+	Checklist        []model.ChecklistItemDescription
+	// AssigneeIDs / 主担当者以外の担当者
+	AssigneeIDs []model.UserIdentifier
+	WatcherIDs  []model.UserIdentifier
+	CreatorID   model.UserIdentifier
+	// Status / ステータスの名前。未指定の場合はワークフローの初期ステータス。
+	Status *string
+	// ExternalRef / 他のツールから取り込む場合の課題との対応
+	ExternalRef *model.TaskExternalRef
 }
 
 type TaskUpdateParams struct {
 	Title            string
 	Detail           *string
 	Visibility       model.TaskVisibility
-	Status           model.TaskStatus
+	Status           string
 	PersonInChargeID *model.UserIdentifier
+	Priority         *string
+	StartDate        *time.Time
 	LimitDate        *time.Time
+	LabelIDs         []model.LabelIdentifier
+	ParentID         *model.TaskIdentifier
+	Checklist        []model.ChecklistItemDescription
+	// AssigneeIDs / 主担当者以外の担当者
+	AssigneeIDs []model.UserIdentifier
+	WatcherIDs  []model.UserIdentifier
+	UpdatorID   model.UserIdentifier
+	// CompanyID / 操作する企業。タスクがこの企業のものでない場合は更新しない。
+	CompanyID model.CompanyIdentifier
+	// Version / 更新の前提とする版数。nil の場合は検証しない。
+	Version *model.Version
+	// Scope / 繰り返しのタスクの編集の範囲
+	Scope TaskEditScope
+}
+
+// TaskPatchParams / タスクの部分更新に必要な情報
+type TaskPatchParams struct {
+	Title            Patch[string]
+	Detail           Patch[string]
+	Visibility       Patch[model.TaskVisibility]
+	Status           Patch[string]
+	PersonInChargeID Patch[model.UserIdentifier]
+	Priority         Patch[string]
+	StartDate        Patch[time.Time]
+	LimitDate        Patch[time.Time]
+	LabelIDs         Patch[[]model.LabelIdentifier]
+	ParentID         Patch[model.TaskIdentifier]
+	Checklist        Patch[[]model.ChecklistItemDescription]
+	AssigneeIDs      Patch[[]model.UserIdentifier]
+	WatcherIDs       Patch[[]model.UserIdentifier]
 	UpdatorID        model.UserIdentifier
+	// CompanyID / 操作する企業。タスクがこの企業のものでない場合は更新しない。
+	CompanyID model.CompanyIdentifier
+	// Version / 更新の前提とする版数。nil の場合は検証しない。
+	Version *model.Version
+	// Scope / 繰り返しのタスクの編集の範囲
+	Scope TaskEditScope
+}
+
+type TaskUpdateStatusParams struct {
+	Status    string
+	UpdatorID model.UserIdentifier
+	// CompanyID / 操作する企業。タスクがこの企業のものでない場合は更新しない。
+	CompanyID model.CompanyIdentifier
+	// Force / 未完了のブロッカーがあっても開始・完了する
+	Force bool
+	// Version / 更新の前提とする版数。nil の場合は検証しない。
+	Version *model.Version
+}
+
+// TaskListParams / タスク一覧の絞り込み条件。ステータスと優先度は名前で指定する。
+type TaskListParams struct {
+	Statuses      []string
+	Priorities    []string
+	StartDateFrom *time.Time
+	StartDateTo   *time.Time
+	LimitDateFrom *time.Time
+	LimitDateTo   *time.Time
+	LabelIDs      []model.LabelIdentifier
+	LabelMatch    model.LabelMatch
+	ParentID      *model.TaskIdentifier
+	Sorts         []model.TaskSort
 }
 
 type taskUsecase struct {
-	userRepository repository.UserRepository
-	taskRepository repository.TaskRepository
+	userRepository           repository.UserRepository
+	taskRepository           repository.TaskRepository
+	workflowRepository       repository.WorkflowRepository
+	taskPriorityRepository   repository.TaskPriorityRepository
+	labelRepository          repository.LabelRepository
+	companySettingRepository repository.CompanySettingRepository
+	taskSeriesRepository     repository.TaskSeriesRepository
 }
 
 func NewTaskUsecase(
 	userRepository repository.UserRepository,
 	taskRepository repository.TaskRepository,
+	workflowRepository repository.WorkflowRepository,
+	taskPriorityRepository repository.TaskPriorityRepository,
+	labelRepository repository.LabelRepository,
+	companySettingRepository repository.CompanySettingRepository,
+	taskSeriesRepository repository.TaskSeriesRepository,
 ) TaskUsecase {
 	return &taskUsecase{
 		userRepository,
 		taskRepository,
+		workflowRepository,
+		taskPriorityRepository,
+		labelRepository,
+		companySettingRepository,
+		taskSeriesRepository,
 	}
 }
 
@@ -60,8 +166,18 @@ func (u *taskUsecase) Find(userID model.UserIdentifier, id model.TaskIdentifier)
 	return task, nil
 }
 
-func (u *taskUsecase) ListByAssignedUserID(userID, assignedUserID model.UserIdentifier) ([]*model.Task, apperr.AppErr) {
-	tasks, err := u.taskRepository.ListByAssignedUserID(userID, assignedUserID)
+func (u *taskUsecase) ListByAssignedUserID(userID, assignedUserID model.UserIdentifier, params TaskListParams) ([]*model.Task, apperr.AppErr) {
+	assignedUser, err := u.userRepository.Get(assignedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := u.buildTaskFilter(assignedUser.Company.ID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskRepository.ListByAssignedUserID(userID, assignedUserID, *filter)
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +185,13 @@ func (u *taskUsecase) ListByAssignedUserID(userID, assignedUserID model.UserIden
 	return tasks, nil
 }
 
-func (u *taskUsecase) ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier) ([]*model.Task, apperr.AppErr) {
-	tasks, err := u.taskRepository.ListByCompanyID(userID, companyID)
+func (u *taskUsecase) ListByCompanyID(userID model.UserIdentifier, companyID model.CompanyIdentifier, params TaskListParams) ([]*model.Task, apperr.AppErr) {
+	filter, err := u.buildTaskFilter(companyID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskRepository.ListByCompanyID(userID, companyID, *filter)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +199,142 @@ func (u *taskUsecase) ListByCompanyID(userID model.UserIdentifier, companyID mod
 	return tasks, nil
 }
 
+// buildTaskFilter / 名前で指定されたステータスと優先度を企業の定義から解決する
+func (u *taskUsecase) buildTaskFilter(companyID model.CompanyIdentifier, params TaskListParams) (*model.TaskFilter, apperr.AppErr) {
+	filter := &model.TaskFilter{
+		StartDateFrom: params.StartDateFrom,
+		StartDateTo:   params.StartDateTo,
+		LimitDateFrom: params.LimitDateFrom,
+		LimitDateTo:   params.LimitDateTo,
+		LabelIDs:      uniqueLabelIDs(params.LabelIDs),
+		LabelMatch:    params.LabelMatch,
+		ParentID:      params.ParentID,
+		Sorts:         params.Sorts,
+	}
+
+	if len(params.Statuses) > 0 {
+		workflow, err := u.workflowRepository.Get(companyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range params.Statuses {
+			status := workflow.FindStatusByName(name)
+			if status == nil {
+				return nil, apperr.NewBadRequestError().SetMessage("task status is not found")
+			}
+			filter.StatusIDs = append(filter.StatusIDs, status.ID)
+		}
+	}
+
+	if len(params.Priorities) > 0 {
+		priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range params.Priorities {
+			priority := priorities.FindByName(name)
+			if priority == nil {
+				return nil, apperr.NewBadRequestError().SetMessage("task priority is not found")
+			}
+			filter.PriorityIDs = append(filter.PriorityIDs, priority.ID)
+		}
+	}
+
+	return filter, nil
+}
+
+// findLabels / IDで指定されたラベルを取得する。他社のラベルはドメインモデルの検証でエラーとなる。
+func (u *taskUsecase) findLabels(ids []model.LabelIdentifier) ([]*model.Label, apperr.AppErr) {
+	ids = uniqueLabelIDs(ids)
+	labels, err := u.labelRepository.ListByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) != len(ids) {
+		return nil, apperr.NewBadRequestError().SetMessage("label is not found")
+	}
+	return labels, nil
+}
+
+// findUsers / IDで指定された担当者・ウォッチャーを取得する。他社のユーザはドメインモデルの検証でエラーとなる。
+func (u *taskUsecase) findUsers(ids []model.UserIdentifier) ([]*model.User, apperr.AppErr) {
+	users, err := u.userRepository.ListByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(users, func(user *model.User) bool { return user.ID == id }) {
+			return nil, apperr.NewBadRequestError().SetMessage("user is not found")
+		}
+	}
+	return users, nil
+}
+
+func uniqueLabelIDs(ids []model.LabelIdentifier) []model.LabelIdentifier {
+	seen := make(map[model.LabelIdentifier]bool, len(ids))
+	var unique []model.LabelIdentifier
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// buildTaskHierarchy / 親タスクの設定の検証に必要な階層の情報を取得する。親タスクが未指定の場合は nil を返す。
+func (u *taskUsecase) buildTaskHierarchy(userID model.UserIdentifier, id model.TaskIdentifier, parentID *model.TaskIdentifier) (*model.TaskHierarchy, apperr.AppErr) {
+	if parentID == nil {
+		return nil, nil
+	}
+	// 閲覧できないタスクは親にできない
+	parent, err := u.taskRepository.Find(userID, *parentID)
+	if err != nil {
+		return nil, err
+	}
+	ancestorIDs, err := u.taskRepository.ListAncestorIDs(*parentID)
+	if err != nil {
+		return nil, err
+	}
+	hierarchy := &model.TaskHierarchy{
+		Parent:      parent,
+		AncestorIDs: ancestorIDs,
+	}
+	// 新規作成時は子孫が存在しない
+	if id != 0 {
+		if hierarchy.DescendantDepth, err = u.taskRepository.GetDescendantDepth(id); err != nil {
+			return nil, err
+		}
+	}
+	return hierarchy, nil
+}
+
+// findPriority / 名前で指定された優先度を企業の定義から解決する。未指定の場合は nil を返す。
+func (u *taskUsecase) findPriority(companyID model.CompanyIdentifier, name *string) (*model.TaskPriority, apperr.AppErr) {
+	if name == nil {
+		return nil, nil
+	}
+	priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	priority := priorities.FindByName(*name)
+	if priority == nil {
+		return nil, apperr.NewBadRequestError().SetMessage("task priority is not found")
+	}
+	return priority, nil
+}
+
 func (u *taskUsecase) Create(params TaskCreateParams) (*model.TaskIdentifier, apperr.AppErr) {
+	task, err := u.NewTask(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.taskRepository.Create(task)
+}
+
+func (u *taskUsecase) NewTask(params TaskCreateParams) (*model.Task, apperr.AppErr) {
 	var personInCharge, creator *model.User
 	var err apperr.AppErr
 
@@ -94,35 +350,184 @@ func (u *taskUsecase) Create(params TaskCreateParams) (*model.TaskIdentifier, ap
 		return nil, err
 	}
 
+	workflow, err := u.workflowRepository.Get(creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 新規タスクは指定がなければワークフローの初期ステータス
+	status := workflow.InitialStatus()
+	if params.Status != nil {
+		status = workflow.FindStatusByName(*params.Status)
+		if status == nil {
+			return nil, apperr.NewBadRequestError().SetMessage("task status is not found: " + *params.Status)
+		}
+	}
+	if status == nil {
+		return nil, apperr.NewInternalServerError().SetMessage("workflow has no OPEN status")
+	}
+
+	priority, err := u.findPriority(creator.Company.ID, params.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := u.findLabels(params.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := u.buildTaskHierarchy(creator.ID, 0, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := u.findUsers(params.AssigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+	watchers, err := u.findUsers(params.WatcherIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := u.companySettingRepository.Get(creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	desc := model.TaskDescription{
 		Title:          params.Title,
 		Detail:         params.Detail,
-		Status:         model.TaskStatusNew, // 新規タスクはNEW
+		Status:         *status,
 		Visibility:     params.Visibility,
 		PersonInCharge: personInCharge,
+		Priority:       priority,
+		StartDate:      params.StartDate,
 		LimitDate:      params.LimitDate,
+		Labels:         labels,
+		Checklist:      params.Checklist,
+		Assignees:      assignees,
+		Watchers:       watchers,
+		Hierarchy:      hierarchy,
 		Creator:        creator,
 		Updator:        creator,
+		Workflow:       workflow,
+		Setting:        setting,
 	}
 	task, err := model.NewTask(desc)
 	if err != nil {
 		return nil, err
 	}
+	task.ExternalRef = params.ExternalRef
+	return task, nil
+}
 
-	taskID, err := u.taskRepository.Create(task)
+func (u *taskUsecase) Update(id model.TaskIdentifier, params TaskUpdateParams) apperr.AppErr {
+	task, err := u.findEditable(params.UpdatorID, params.CompanyID, id)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err = task.Version.Validate(params.Version); err != nil {
+		return err
 	}
 
-	return taskID, err
+	if params.Scope == TaskEditScopeFuture && task.Series != nil {
+		return u.updateFutureOccurrences(task, params)
+	}
+	return u.update(task, params)
 }
 
-func (u *taskUsecase) Update(id model.TaskIdentifier, params TaskUpdateParams) apperr.AppErr {
-	task, err := u.taskRepository.Get(id)
+func (u *taskUsecase) Patch(id model.TaskIdentifier, params TaskPatchParams) apperr.AppErr {
+	task, err := u.findEditable(params.UpdatorID, params.CompanyID, id)
 	if err != nil {
 		return err
 	}
+	if err = task.Version.Validate(params.Version); err != nil {
+		return err
+	}
+
+	update := newTaskUpdateParams(task)
+	update.Title = params.Title.ApplyValue(update.Title)
+	update.Detail = params.Detail.Apply(update.Detail)
+	update.Visibility = params.Visibility.ApplyValue(update.Visibility)
+	update.Status = params.Status.ApplyValue(update.Status)
+	update.PersonInChargeID = params.PersonInChargeID.Apply(update.PersonInChargeID)
+	update.Priority = params.Priority.Apply(update.Priority)
+	update.StartDate = params.StartDate.Apply(update.StartDate)
+	update.LimitDate = params.LimitDate.Apply(update.LimitDate)
+	update.LabelIDs = params.LabelIDs.ApplyValue(update.LabelIDs)
+	update.ParentID = params.ParentID.Apply(update.ParentID)
+	update.Checklist = params.Checklist.ApplyValue(update.Checklist)
+	update.AssigneeIDs = params.AssigneeIDs.ApplyValue(update.AssigneeIDs)
+	update.WatcherIDs = params.WatcherIDs.ApplyValue(update.WatcherIDs)
+	update.UpdatorID = params.UpdatorID
 
+	if params.Scope == TaskEditScopeFuture && task.Series != nil {
+		return u.updateFutureOccurrences(task, update)
+	}
+	return u.update(task, update)
+}
+
+// findEditable / 更新者が閲覧できる、指定した企業のタスクを取得する。他社のタスクの場合は 403 とする。
+func (u *taskUsecase) findEditable(updatorID model.UserIdentifier, companyID model.CompanyIdentifier, id model.TaskIdentifier) (*model.Task, apperr.AppErr) {
+	task, err := u.taskRepository.Find(updatorID, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Creator.Company.ID != companyID {
+		return nil, apperr.NewForbiddenError()
+	}
+	return task, nil
+}
+
+// newTaskUpdateParams / タスクの現在の値から更新に必要な情報を生成する
+func newTaskUpdateParams(task *model.Task) TaskUpdateParams {
+	params := TaskUpdateParams{
+		Title:      task.Title,
+		Detail:     task.Detail,
+		Visibility: task.Visibility,
+		Status:     task.Status.Name,
+		StartDate:  task.StartDate,
+		LimitDate:  task.LimitDate,
+		ParentID:   task.ParentID,
+	}
+	if task.PersonInCharge != nil {
+		params.PersonInChargeID = &task.PersonInCharge.ID
+	}
+	if task.Priority != nil {
+		params.Priority = &task.Priority.Name
+	}
+	for _, label := range task.Labels {
+		params.LabelIDs = append(params.LabelIDs, label.ID)
+	}
+	for _, item := range task.Checklist {
+		params.Checklist = append(params.Checklist, model.ChecklistItemDescription{
+			Text: item.Text,
+			Done: item.Done,
+		})
+	}
+	for _, assignee := range task.Assignees {
+		if task.PersonInCharge == nil || assignee.ID != task.PersonInCharge.ID {
+			params.AssigneeIDs = append(params.AssigneeIDs, assignee.ID)
+		}
+	}
+	for _, watcher := range task.Watchers {
+		params.WatcherIDs = append(params.WatcherIDs, watcher.ID)
+	}
+	return params
+}
+
+// update / 更新内容を検証してタスクを更新する
+func (u *taskUsecase) update(task *model.Task, params TaskUpdateParams) apperr.AppErr {
+	if err := u.applyUpdate(task, params); err != nil {
+		return err
+	}
+	return u.save(task)
+}
+
+// applyUpdate / 更新内容を検証してタスクに適用する。保存はしない。
+func (u *taskUsecase) applyUpdate(task *model.Task, params TaskUpdateParams) apperr.AppErr {
+	var err apperr.AppErr
 	var personInCharge, updator *model.User
 	if params.PersonInChargeID != nil {
 		personInCharge, err = u.userRepository.Get(*params.PersonInChargeID)
@@ -135,35 +540,130 @@ func (u *taskUsecase) Update(id model.TaskIdentifier, params TaskUpdateParams) a
 		return err
 	}
 
+	workflow, err := u.workflowRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+	status := workflow.FindStatusByName(params.Status)
+	if status == nil {
+		return apperr.NewBadRequestError().SetMessage("task status is not found")
+	}
+
+	priority, err := u.findPriority(task.Creator.Company.ID, params.Priority)
+	if err != nil {
+		return err
+	}
+
+	labels, err := u.findLabels(params.LabelIDs)
+	if err != nil {
+		return err
+	}
+
+	hierarchy, err := u.buildTaskHierarchy(updator.ID, task.ID, params.ParentID)
+	if err != nil {
+		return err
+	}
+
+	assignees, err := u.findUsers(params.AssigneeIDs)
+	if err != nil {
+		return err
+	}
+	watchers, err := u.findUsers(params.WatcherIDs)
+	if err != nil {
+		return err
+	}
+
+	setting, err := u.companySettingRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+
 	desc := model.TaskDescription{
 		Title:          params.Title,
 		Detail:         params.Detail,
-		Status:         params.Status,
+		Status:         *status,
 		Visibility:     params.Visibility,
 		PersonInCharge: personInCharge,
+		Priority:       priority,
+		StartDate:      params.StartDate,
 		LimitDate:      params.LimitDate,
+		Labels:         labels,
+		Checklist:      params.Checklist,
+		Assignees:      assignees,
+		Watchers:       watchers,
+		Hierarchy:      hierarchy,
 		Updator:        updator,
+		Workflow:       workflow,
+		Setting:        setting,
 	}
-	if err = task.Update(desc); err != nil {
-		return err
-	}
+	return task.Update(desc)
+}
 
+// save / タスクを保存し、変更に応じた出来事を記録する
+func (u *taskUsecase) save(task *model.Task) apperr.AppErr {
+	changes := task.Changes
 	if err := u.taskRepository.Update(task); err != nil {
 		return err
 	}
 
+	return u.afterSave(task, changes)
+}
+
+// afterSave / 繰り返しのタスクが完了した場合は次の回を生成する。
+// タイムライン、通知、Webhook は保存と同じトランザクションで記録した出来事から配信する。
+func (u *taskUsecase) afterSave(task *model.Task, changes []*model.TaskChange) apperr.AppErr {
+	if isClosedBy(task, changes) {
+		if _, err := u.generateNextOccurrence(task); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (u *taskUsecase) UpdateStatus(id model.TaskIdentifier, status model.TaskStatus) apperr.AppErr {
-	_, err := u.taskRepository.Get(id)
+func (u *taskUsecase) UpdateStatus(id model.TaskIdentifier, params TaskUpdateStatusParams) apperr.AppErr {
+	task, err := u.findEditable(params.UpdatorID, params.CompanyID, id)
 	if err != nil {
 		return err
 	}
+	if err = task.Version.Validate(params.Version); err != nil {
+		return err
+	}
 
-	if err = u.taskRepository.UpdateStatus(id, status); err != nil {
+	updator, err := u.userRepository.Get(params.UpdatorID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	workflow, err := u.workflowRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+	status := workflow.FindStatusByName(params.Status)
+	if status == nil {
+		return apperr.NewBadRequestError().SetMessage("task status is not found")
+	}
+
+	setting, err := u.companySettingRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+
+	desc := model.TaskStatusUpdateDescription{
+		Status:   status,
+		Workflow: workflow,
+		Setting:  setting,
+		Updator:  updator,
+		Force:    params.Force,
+	}
+	if err = task.UpdateStatus(desc); err != nil {
+		return err
+	}
+
+	changes := task.Changes
+	if err = u.taskRepository.UpdateStatus(task); err != nil {
+		return err
+	}
+
+	return u.afterSave(task, changes)
 }
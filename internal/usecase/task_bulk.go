@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// maxTaskBulkItems / 一度に操作できる項目の上限
+const maxTaskBulkItems = 100
+
+// TaskBulkOperation / 一括操作の種類
+type TaskBulkOperation int
+
+const (
+	TaskBulkOperationSetStatus TaskBulkOperation = iota + 1
+	TaskBulkOperationReassign
+	TaskBulkOperationAddLabel
+	TaskBulkOperationRemoveLabel
+	TaskBulkOperationSetVisibility
+	TaskBulkOperationDelete
+)
+
+// TaskBulkMode / 一括操作の失敗時の扱い
+type TaskBulkMode int
+
+const (
+	// TaskBulkModeAtomic / いずれかの項目が失敗した場合はすべて保存しない
+	TaskBulkModeAtomic TaskBulkMode = iota + 1
+	// TaskBulkModeBestEffort / 成功した項目のみ保存する
+	TaskBulkModeBestEffort
+)
+
+// TaskBulkItem / 一つのタスクへの操作。操作の種類に応じた項目のみを利用する。
+type TaskBulkItem struct {
+	TaskID    model.TaskIdentifier
+	Operation TaskBulkOperation
+	// Status / set_status で遷移するステータスの名前
+	Status string
+	// Force / set_status で未完了のブロッカーがあっても開始・完了する
+	Force bool
+	// PersonInChargeID / reassign の担当者。nil の場合は担当者を外す。
+	PersonInChargeID *model.UserIdentifier
+	// LabelID / add_label と remove_label のラベル
+	LabelID model.LabelIdentifier
+	// Visibility / set_visibility の公開範囲
+	Visibility model.TaskVisibility
+}
+
+// TaskBulkParams / 一括操作に必要な情報。項目は指定された順に適用する。
+type TaskBulkParams struct {
+	CompanyID model.CompanyIdentifier
+	Mode      TaskBulkMode
+	Items     []TaskBulkItem
+	UpdatorID model.UserIdentifier
+}
+
+// TaskBulkResult / 一括操作の結果
+type TaskBulkResult struct {
+	// Applied / 保存されたかどうか。一括モードでいずれかの項目が失敗した場合は false となる。
+	Applied bool
+	Items   []*TaskBulkItemResult
+}
+
+// TaskBulkItemResult / 項目ごとの結果。Err が nil の場合は成功。
+type TaskBulkItemResult struct {
+	TaskID model.TaskIdentifier
+	Err    apperr.AppErr
+}
+
+// Failed / 失敗した最初の項目の結果を返す。すべて成功した場合は nil。
+func (r *TaskBulkResult) Failed() *TaskBulkItemResult {
+	for _, item := range r.Items {
+		if item.Err != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+func (u *taskUsecase) Bulk(params TaskBulkParams) (*TaskBulkResult, apperr.AppErr) {
+	if len(params.Items) == 0 || len(params.Items) > maxTaskBulkItems {
+		return nil, apperr.NewBadRequestError().SetMessage(fmt.Sprintf("items must be 1 to %d", maxTaskBulkItems))
+	}
+
+	updator, err := u.userRepository.Get(params.UpdatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Mode == TaskBulkModeAtomic {
+		return u.bulkAtomic(updator, params)
+	}
+	return u.bulkBestEffort(updator, params)
+}
+
+// bulkAtomic / すべての項目を適用してから一つのトランザクションで保存する
+func (u *taskUsecase) bulkAtomic(updator *model.User, params TaskBulkParams) (*TaskBulkResult, apperr.AppErr) {
+	result := &TaskBulkResult{}
+	tasks := make(map[model.TaskIdentifier]*model.Task)
+	var updated []*model.Task
+	for _, item := range params.Items {
+		task, err := u.applyBulkItem(tasks, updator, params.CompanyID, item)
+		result.Items = append(result.Items, &TaskBulkItemResult{TaskID: item.TaskID, Err: err})
+		if err == nil && !slices.Contains(updated, task) {
+			updated = append(updated, task)
+		}
+	}
+	if result.Failed() != nil {
+		return result, nil
+	}
+
+	changes := make([][]*model.TaskChange, len(updated))
+	for i, task := range updated {
+		changes[i] = task.Changes
+	}
+	if err := u.taskRepository.UpdateAll(updated...); err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	for i, task := range updated {
+		if err := u.afterSave(task, changes[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// bulkBestEffort / 項目ごとに適用して保存する。失敗した項目は結果に記録して次に進む。
+func (u *taskUsecase) bulkBestEffort(updator *model.User, params TaskBulkParams) (*TaskBulkResult, apperr.AppErr) {
+	result := &TaskBulkResult{Applied: true}
+	tasks := make(map[model.TaskIdentifier]*model.Task)
+	for _, item := range params.Items {
+		task, err := u.applyBulkItem(tasks, updator, params.CompanyID, item)
+		if err == nil {
+			if err = u.save(task); err != nil {
+				// 保存に失敗したタスクは次の項目で読み込み直す
+				delete(tasks, item.TaskID)
+			}
+		}
+		result.Items = append(result.Items, &TaskBulkItemResult{TaskID: item.TaskID, Err: err})
+	}
+	return result, nil
+}
+
+// applyBulkItem / 項目のタスクを取得して操作を適用する。同じタスクへの操作は取得済みのタスクに重ねて適用する。
+func (u *taskUsecase) applyBulkItem(
+	tasks map[model.TaskIdentifier]*model.Task,
+	updator *model.User,
+	companyID model.CompanyIdentifier,
+	item TaskBulkItem,
+) (*model.Task, apperr.AppErr) {
+	task, ok := tasks[item.TaskID]
+	if !ok {
+		// 個別の更新と同じく閲覧できる自社のタスクのみ操作できる
+		var err apperr.AppErr
+		task, err = u.findEditable(updator.ID, companyID, item.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		tasks[item.TaskID] = task
+	}
+	if task.DeleteAt != nil {
+		return nil, apperr.NewNotFoundError().SetMessage("task is deleted")
+	}
+
+	switch item.Operation {
+	case TaskBulkOperationSetStatus:
+		return task, u.applyBulkStatus(task, updator, item)
+	case TaskBulkOperationDelete:
+		return task, task.Delete(updator, time.Now())
+	}
+
+	params := newTaskUpdateParams(task)
+	params.UpdatorID = updator.ID
+	switch item.Operation {
+	case TaskBulkOperationReassign:
+		params.PersonInChargeID = item.PersonInChargeID
+	case TaskBulkOperationAddLabel:
+		params.LabelIDs = append(params.LabelIDs, item.LabelID)
+	case TaskBulkOperationRemoveLabel:
+		params.LabelIDs = slices.DeleteFunc(params.LabelIDs, func(id model.LabelIdentifier) bool {
+			return id == item.LabelID
+		})
+	case TaskBulkOperationSetVisibility:
+		params.Visibility = item.Visibility
+	default:
+		return nil, apperr.NewBadRequestError().SetMessage("bulk operation is invalid")
+	}
+	return task, u.applyUpdate(task, params)
+}
+
+// applyBulkStatus / ワークフローに従ってステータスを遷移する
+func (u *taskUsecase) applyBulkStatus(task *model.Task, updator *model.User, item TaskBulkItem) apperr.AppErr {
+	workflow, err := u.workflowRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+	status := workflow.FindStatusByName(item.Status)
+	if status == nil {
+		return apperr.NewBadRequestError().SetMessage("task status is not found")
+	}
+
+	setting, err := u.companySettingRepository.Get(task.Creator.Company.ID)
+	if err != nil {
+		return err
+	}
+
+	desc := model.TaskStatusUpdateDescription{
+		Status:   status,
+		Workflow: workflow,
+		Setting:  setting,
+		Updator:  updator,
+		Force:    item.Force,
+	}
+	return task.UpdateStatus(desc)
+}
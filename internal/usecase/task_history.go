@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskHistoryUsecase interface {
+	// ListByTaskID / 閲覧可能なタスクの変更履歴を取得する。全件数も返す。
+	ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier, page model.Page) ([]*model.TaskHistory, int, apperr.AppErr)
+}
+
+type taskHistoryUsecase struct {
+	taskRepository        repository.TaskRepository
+	taskHistoryRepository repository.TaskHistoryRepository
+}
+
+func NewTaskHistoryUsecase(
+	taskRepository repository.TaskRepository,
+	taskHistoryRepository repository.TaskHistoryRepository,
+) TaskHistoryUsecase {
+	return &taskHistoryUsecase{
+		taskRepository,
+		taskHistoryRepository,
+	}
+}
+
+func (u *taskHistoryUsecase) ListByTaskID(userID model.UserIdentifier, taskID model.TaskIdentifier, page model.Page) ([]*model.TaskHistory, int, apperr.AppErr) {
+	// 変更履歴はタスクの公開範囲に従う
+	if _, err := u.taskRepository.Find(userID, taskID); err != nil {
+		return nil, 0, err
+	}
+
+	histories, total, err := u.taskHistoryRepository.ListByTaskID(taskID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
@@ -0,0 +1,466 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+const (
+	// taskImportSyncLimit / その場で取り込む行の件数の上限。超える場合は非同期に取り込む。
+	taskImportSyncLimit = 100
+	// taskImportLabelColor / 他のツールからの取り込みで作成するラベルの色
+	taskImportLabelColor = "#808080"
+	// maxTaskImportCommentLength / 取り込むコメントの本文の長さの上限。超えた分は切り捨てる。
+	maxTaskImportCommentLength = 2000
+)
+
+type TaskImportUsecase interface {
+	// Import / 行を取り込む。件数が少ない場合はその場で処理を終え、多い場合は非同期に処理するために登録する。
+	Import(userID model.UserIdentifier, companyID model.CompanyIdentifier, source model.TaskImportSource, rows []*model.TaskImportRow, dryRun bool) (*model.TaskImportJob, apperr.AppErr)
+	// Get / 取り込みの状態と進捗を取得する
+	Get(companyID model.CompanyIdentifier, id model.TaskImportJobIdentifier) (*model.TaskImportJob, apperr.AppErr)
+	// RunNext / 処理待ちまたは中断した取り込みを一件処理し、処理したかどうかを返す
+	RunNext(now time.Time) (bool, apperr.AppErr)
+}
+
+type taskImportUsecase struct {
+	taskImportJobRepository repository.TaskImportJobRepository
+	userRepository          repository.UserRepository
+	labelRepository         repository.LabelRepository
+	taskRepository          repository.TaskRepository
+	taskUsecase             TaskUsecase
+}
+
+func NewTaskImportUsecase(
+	taskImportJobRepository repository.TaskImportJobRepository,
+	userRepository repository.UserRepository,
+	labelRepository repository.LabelRepository,
+	taskRepository repository.TaskRepository,
+	taskUsecase TaskUsecase,
+) TaskImportUsecase {
+	return &taskImportUsecase{
+		taskImportJobRepository,
+		userRepository,
+		labelRepository,
+		taskRepository,
+		taskUsecase,
+	}
+}
+
+func (u *taskImportUsecase) Import(userID model.UserIdentifier, companyID model.CompanyIdentifier, source model.TaskImportSource, rows []*model.TaskImportRow, dryRun bool) (*model.TaskImportJob, apperr.AppErr) {
+	if len(rows) == 0 {
+		return nil, apperr.NewBadRequestError().SetMessage("no rows to import")
+	}
+	if len(rows) > model.MaxTaskImportRows {
+		return nil, apperr.NewBadRequestError().SetMessage("too many rows to import")
+	}
+
+	job := model.NewTaskImportJob(companyID, userID, source, rows, dryRun, time.Now())
+	if len(rows) > taskImportSyncLimit {
+		if _, err := u.taskImportJobRepository.Create(job); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	// 件数が少ない場合は進捗を記録せずにその場で処理し、結果のみを記録する
+	job.Start(time.Now())
+	resolver := newTaskImportResolver(u.userRepository, u.labelRepository, job)
+	for _, row := range job.Remaining() {
+		if err := u.process(job, row, resolver); err != nil {
+			return nil, err
+		}
+	}
+	job.Finish(time.Now())
+	if _, err := u.taskImportJobRepository.Create(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (u *taskImportUsecase) Get(companyID model.CompanyIdentifier, id model.TaskImportJobIdentifier) (*model.TaskImportJob, apperr.AppErr) {
+	job, err := u.taskImportJobRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	// 他社の取り込みは存在しないものとして扱う
+	if job.CompanyID != companyID {
+		return nil, apperr.NewNotFoundError()
+	}
+
+	return job, nil
+}
+
+func (u *taskImportUsecase) RunNext(now time.Time) (bool, apperr.AppErr) {
+	job, err := u.taskImportJobRepository.GetNext()
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	job.Start(now)
+	if err := u.taskImportJobRepository.UpdateProgress(job); err != nil {
+		return true, err
+	}
+	resolver := newTaskImportResolver(u.userRepository, u.labelRepository, job)
+	for _, row := range job.Remaining() {
+		if err := u.process(job, row, resolver); err != nil {
+			job.Fail(err.Message(), time.Now())
+			if uerr := u.taskImportJobRepository.UpdateProgress(job); uerr != nil {
+				return true, uerr
+			}
+			return true, err
+		}
+		// 中断した場合に続きから再開できるよう、行ごとに進捗を記録する
+		if err := u.taskImportJobRepository.UpdateProgress(job); err != nil {
+			return true, err
+		}
+	}
+	job.Finish(time.Now())
+	if err := u.taskImportJobRepository.UpdateProgress(job); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// process / 1行を検証し、検証のみでない場合はタスクを作成する。行の誤りは記録し、それ以外の失敗はエラーを返す。
+func (u *taskImportUsecase) process(job *model.TaskImportJob, row *model.TaskImportRow, resolver *taskImportResolver) apperr.AppErr {
+	if len(row.Errors) > 0 {
+		job.Reject(row.Errors...)
+		return nil
+	}
+
+	// 取り込み済みの課題は作成しない
+	var ref *model.TaskExternalRef
+	if row.ExternalID != nil {
+		ref = &model.TaskExternalRef{CompanyID: job.CompanyID, Source: job.Source, ExternalID: *row.ExternalID}
+		taskID, err := u.taskRepository.FindByExternalRef(*ref)
+		if err != nil {
+			return err
+		}
+		if taskID != nil {
+			job.Skip()
+			return nil
+		}
+	}
+
+	// ラベルを作成する前に、親が取り込まれていることを確かめる
+	var parentID *model.TaskIdentifier
+	if row.ParentExternalID != nil {
+		var err apperr.AppErr
+		parentID, err = u.taskRepository.FindByExternalRef(model.TaskExternalRef{CompanyID: job.CompanyID, Source: job.Source, ExternalID: *row.ParentExternalID})
+		if err != nil {
+			return err
+		}
+		// 検証のみの場合は親が同じファイルにあり、まだ作成されていないことがあるため検証しない
+		if parentID == nil && !job.DryRun {
+			job.Reject(&model.TaskImportError{Line: row.Line, Field: "parent", Message: "parent is not imported: " + *row.ParentExternalID})
+			return nil
+		}
+	}
+
+	params, errs, err := resolver.resolve(job.UserID, row)
+	if err != nil {
+		return err
+	}
+	params.ExternalRef = ref
+	if parentID != nil {
+		params.ParentID = parentID
+	}
+	if len(errs) > 0 {
+		job.Reject(errs...)
+		return nil
+	}
+
+	task, err := u.taskUsecase.NewTask(*params)
+	var comments []*model.Comment
+	if err == nil {
+		comments, err = u.newComments(task, row.Comments, resolver)
+	}
+	if err != nil {
+		if !isTaskImportRowError(err) {
+			return err
+		}
+		job.Reject(&model.TaskImportError{Line: row.Line, Message: err.Message()})
+		return nil
+	}
+	if job.DryRun {
+		job.Succeed()
+		return nil
+	}
+
+	// 取り込みで通知が大量に送られないよう、担当者の設定やメンションを通知しない
+	task.MarkImported()
+	for _, comment := range comments {
+		comment.MarkImported()
+	}
+	// 作成に失敗した行を処理済みとしないよう、行の成功を含めた進捗を作成とともに保存してから反映する
+	progress := *job
+	progress.Succeed()
+	if err := u.taskImportJobRepository.CreateTask(&progress, task, comments); err != nil {
+		return err
+	}
+	*job = progress
+	return nil
+}
+
+// newComments / 取り込んだユーザの投稿としてコメントを生成する。元の投稿者は本文の先頭に、元の日時は投稿日時とする。
+// 本文中の @ユーザ名 はメンションとして扱わない。
+func (u *taskImportUsecase) newComments(task *model.Task, comments []*model.TaskImportComment, resolver *taskImportResolver) ([]*model.Comment, apperr.AppErr) {
+	if len(comments) == 0 {
+		return nil, nil
+	}
+	author, err := resolver.creator()
+	if err != nil {
+		return nil, err
+	}
+	var created []*model.Comment
+	for _, c := range comments {
+		body := strings.TrimSpace(c.Body)
+		if body == "" {
+			continue
+		}
+		if c.Author != "" {
+			body = fmt.Sprintf("%s:\n%s", c.Author, body)
+		}
+		if runes := []rune(body); len(runes) > maxTaskImportCommentLength {
+			body = string(runes[:maxTaskImportCommentLength])
+		}
+		comment, err := model.NewComment(task, author, model.CommentDescription{Body: body})
+		if err != nil {
+			return nil, err
+		}
+		if c.CreateAt != nil {
+			comment.CreateAt = *c.CreateAt
+		}
+		created = append(created, comment)
+	}
+	return created, nil
+}
+
+// isTaskImportRowError / 行の内容による誤りかどうか
+func isTaskImportRowError(err apperr.AppErr) bool {
+	switch err.Code() {
+	case apperr.ErrorCodeBadRequest, apperr.ErrorCodeNotFound, apperr.ErrorCodeForbidden:
+		return true
+	default:
+		return false
+	}
+}
+
+// taskImportResolver / 行のユーザとラベルを企業の定義から解決する。一度の取り込みの間は結果を使い回す。
+// 他のツールからの取り込みでは、一致しないユーザは割り当てずに取り込みに記録し、存在しないラベルは作成する。
+type taskImportResolver struct {
+	userRepository  repository.UserRepository
+	labelRepository repository.LabelRepository
+	job             *model.TaskImportJob
+	author          *model.User
+	users           map[string]taskImportUser
+	labels          map[string]model.LabelIdentifier
+}
+
+func newTaskImportResolver(userRepository repository.UserRepository, labelRepository repository.LabelRepository, job *model.TaskImportJob) *taskImportResolver {
+	return &taskImportResolver{
+		userRepository:  userRepository,
+		labelRepository: labelRepository,
+		job:             job,
+		users:           map[string]taskImportUser{},
+	}
+}
+
+// resolve / 行から作成の情報を組み立てる。解決できない値は行の誤りとして返す。
+func (r *taskImportResolver) resolve(creatorID model.UserIdentifier, row *model.TaskImportRow) (*TaskCreateParams, []*model.TaskImportError, apperr.AppErr) {
+	var errs []*model.TaskImportError
+	reject := func(field, message string) {
+		errs = append(errs, &model.TaskImportError{Line: row.Line, Field: field, Message: message})
+	}
+	external := r.job.Source != model.TaskImportSourceFile
+
+	params := &TaskCreateParams{
+		Title:      row.Title,
+		Detail:     row.Detail,
+		Visibility: row.Visibility,
+		Priority:   row.Priority,
+		StartDate:  row.StartDate,
+		LimitDate:  row.LimitDate,
+		ParentID:   row.ParentID,
+		Checklist:  row.Checklist,
+		CreatorID:  creatorID,
+		Status:     row.Status,
+	}
+	if params.Visibility == 0 {
+		params.Visibility = model.TaskVisibilityCompany
+	}
+
+	if row.PersonInCharge != nil {
+		user, message, err := r.findUser(*row.PersonInCharge)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case user != nil:
+			params.PersonInChargeID = &user.ID
+		case external:
+			r.job.Unmatch(*row.PersonInCharge)
+		default:
+			reject("person_in_charge", message)
+		}
+	}
+	for _, field := range []struct {
+		name   string
+		values []string
+		ids    *[]model.UserIdentifier
+	}{
+		{"assignees", row.Assignees, &params.AssigneeIDs},
+		{"watchers", row.Watchers, &params.WatcherIDs},
+	} {
+		for _, value := range field.values {
+			user, message, err := r.findUser(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch {
+			case user != nil:
+				*field.ids = append(*field.ids, user.ID)
+			case external:
+				r.job.Unmatch(value)
+			default:
+				reject(field.name, message)
+			}
+		}
+	}
+
+	for _, name := range row.Labels {
+		id, err := r.findLabel(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id == nil && external {
+			// 誤りのある行は作成しないため、ラベルも作成しない
+			if len(errs) > 0 {
+				continue
+			}
+			if id, err = r.createLabel(name); err != nil {
+				if !isTaskImportRowError(err) {
+					return nil, nil, err
+				}
+				reject("labels", err.Message())
+				continue
+			}
+			// 検証のみの場合はラベルを作成しない
+			if id == nil {
+				continue
+			}
+		}
+		if id == nil {
+			reject("labels", "label is not found: "+name)
+			continue
+		}
+		params.LabelIDs = append(params.LabelIDs, *id)
+	}
+
+	return params, errs, nil
+}
+
+// creator / 取り込みを依頼したユーザ
+func (r *taskImportResolver) creator() (*model.User, apperr.AppErr) {
+	if r.author == nil {
+		author, err := r.userRepository.Get(r.job.UserID)
+		if err != nil {
+			return nil, err
+		}
+		r.author = author
+	}
+	return r.author, nil
+}
+
+// taskImportUser / ユーザの解決結果。解決できない場合は user が nil で message に理由を持つ。
+type taskImportUser struct {
+	user    *model.User
+	message string
+}
+
+// findUser / 企業のユーザをメールアドレスまたは名前から探す。ファイルからの取り込みではユーザIDでも探す。
+// 見つからないか一致するユーザが複数いる場合は nil と理由を返す。
+func (r *taskImportResolver) findUser(value string) (*model.User, string, apperr.AppErr) {
+	if found, ok := r.users[value]; ok {
+		return found.user, found.message, nil
+	}
+
+	var users []*model.User
+	var err apperr.AppErr
+	id, perr := strconv.ParseUint(value, 10, 64)
+	switch {
+	case perr == nil && r.job.Source == model.TaskImportSourceFile:
+		var user *model.User
+		user, err = r.userRepository.Get(model.UserIdentifier(id))
+		if err != nil && err.Code() == apperr.ErrorCodeNotFound {
+			err = nil
+		}
+		if user != nil && user.Company.ID == r.job.CompanyID {
+			users = append(users, user)
+		}
+	case strings.Contains(value, "@"):
+		users, err = r.userRepository.ListByEmails(r.job.CompanyID, []string{value})
+	default:
+		users, err = r.userRepository.ListByNames(r.job.CompanyID, []string{value})
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var found taskImportUser
+	switch len(users) {
+	case 0:
+		found.message = "user is not found: " + value
+	case 1:
+		found.user = users[0]
+	default:
+		found.message = "user name is ambiguous, specify the user ID: " + value
+	}
+	r.users[value] = found
+	return found.user, found.message, nil
+}
+
+// findLabel / 企業のラベルを名前から探す。見つからない場合は nil を返す。
+func (r *taskImportResolver) findLabel(name string) (*model.LabelIdentifier, apperr.AppErr) {
+	if r.labels == nil {
+		labels, err := r.labelRepository.ListByCompanyID(r.job.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		r.labels = map[string]model.LabelIdentifier{}
+		for _, label := range labels {
+			r.labels[label.Name] = label.ID
+		}
+	}
+	id, ok := r.labels[name]
+	if !ok {
+		return nil, nil
+	}
+	return &id, nil
+}
+
+// createLabel / 他のツールのラベルを企業のラベルとして作成する。検証のみの場合は作成せずに nil を返す。
+func (r *taskImportResolver) createLabel(name string) (*model.LabelIdentifier, apperr.AppErr) {
+	label, err := model.NewLabel(r.job.CompanyID, model.LabelDescription{Name: name, Color: taskImportLabelColor})
+	if err != nil {
+		return nil, err
+	}
+	if r.job.DryRun {
+		return nil, nil
+	}
+	id, err := r.labelRepository.Create(label)
+	if err != nil {
+		return nil, err
+	}
+	r.labels[name] = *id
+	return id, nil
+}
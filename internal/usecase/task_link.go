@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskLinkUsecase interface {
+	// GetDependencies / タスクの依存関係のグラフを閲覧可能なタスクのみで取得する
+	GetDependencies(userID model.UserIdentifier, taskID model.TaskIdentifier) (*model.TaskGraph, apperr.AppErr)
+
+	Create(taskID model.TaskIdentifier, params TaskLinkCreateParams) (*model.TaskLinkIdentifier, apperr.AppErr)
+	Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.TaskLinkIdentifier) apperr.AppErr
+}
+
+// TaskLinkCreateParams / タスクから TargetID のタスクへの関連の作成に必要な情報
+type TaskLinkCreateParams struct {
+	Type      model.TaskLinkType
+	TargetID  model.TaskIdentifier
+	CreatorID model.UserIdentifier
+}
+
+type taskLinkUsecase struct {
+	userRepository     repository.UserRepository
+	taskRepository     repository.TaskRepository
+	taskLinkRepository repository.TaskLinkRepository
+}
+
+func NewTaskLinkUsecase(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	taskLinkRepository repository.TaskLinkRepository,
+) TaskLinkUsecase {
+	return &taskLinkUsecase{
+		userRepository,
+		taskRepository,
+		taskLinkRepository,
+	}
+}
+
+func (u *taskLinkUsecase) GetDependencies(userID model.UserIdentifier, taskID model.TaskIdentifier) (*model.TaskGraph, apperr.AppErr) {
+	user, err := u.userRepository.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := u.taskRepository.Find(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := u.taskLinkRepository.ListByCompanyID(task.Creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := links.Dependencies(task.ID)
+
+	tasks := []*model.Task{task}
+	var ids []model.TaskIdentifier
+	for _, id := range dependencies.TaskIDs() {
+		if id != task.ID {
+			ids = append(ids, id)
+		}
+	}
+	others, err := u.taskRepository.ListByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	tasks = append(tasks, others...)
+
+	return model.NewTaskGraph(task.ID, dependencies, tasks, user), nil
+}
+
+func (u *taskLinkUsecase) Create(taskID model.TaskIdentifier, params TaskLinkCreateParams) (*model.TaskLinkIdentifier, apperr.AppErr) {
+	creator, err := u.userRepository.Get(params.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	// 関連の両端のタスクを閲覧できる必要がある
+	source, err := u.taskRepository.Find(creator.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := u.taskRepository.Find(creator.ID, params.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := u.taskLinkRepository.ListByCompanyID(source.Creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := model.TaskLinkDescription{
+		Type:    params.Type,
+		Source:  source,
+		Target:  target,
+		Creator: creator,
+		Links:   links,
+	}
+	link, err := model.NewTaskLink(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.taskLinkRepository.Create(link)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *taskLinkUsecase) Delete(userID model.UserIdentifier, taskID model.TaskIdentifier, id model.TaskLinkIdentifier) apperr.AppErr {
+	task, err := u.taskRepository.Find(userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	link, err := u.taskLinkRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	// 他のタスクの関連は存在しないものとして扱う
+	if !link.Touches(task.ID) {
+		return apperr.NewNotFoundError()
+	}
+
+	if err = u.taskLinkRepository.Delete(id); err != nil {
+		return err
+	}
+
+	return nil
+}
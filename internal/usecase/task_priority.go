@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type TaskPriorityUsecase interface {
+	ListByCompanyID(companyID model.CompanyIdentifier) (model.TaskPriorities, apperr.AppErr)
+
+	Create(companyID model.CompanyIdentifier, params TaskPriorityParams) (*model.TaskPriorityIdentifier, apperr.AppErr)
+	Update(companyID model.CompanyIdentifier, id model.TaskPriorityIdentifier, params TaskPriorityParams) apperr.AppErr
+	Delete(companyID model.CompanyIdentifier, id model.TaskPriorityIdentifier) apperr.AppErr
+}
+
+type TaskPriorityParams struct {
+	Name  string
+	Level int
+}
+
+type taskPriorityUsecase struct {
+	taskPriorityRepository repository.TaskPriorityRepository
+}
+
+func NewTaskPriorityUsecase(taskPriorityRepository repository.TaskPriorityRepository) TaskPriorityUsecase {
+	return &taskPriorityUsecase{
+		taskPriorityRepository,
+	}
+}
+
+func (u *taskPriorityUsecase) ListByCompanyID(companyID model.CompanyIdentifier) (model.TaskPriorities, apperr.AppErr) {
+	priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return priorities, nil
+}
+
+func (u *taskPriorityUsecase) Create(companyID model.CompanyIdentifier, params TaskPriorityParams) (*model.TaskPriorityIdentifier, apperr.AppErr) {
+	priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if priorities.FindByName(params.Name) != nil {
+		return nil, apperr.NewBadRequestError().SetMessage("task priority name is already used")
+	}
+
+	desc := model.TaskPriorityDescription{
+		Name:  params.Name,
+		Level: params.Level,
+	}
+	priority, err := model.NewTaskPriority(companyID, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.taskPriorityRepository.Create(priority)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *taskPriorityUsecase) Update(companyID model.CompanyIdentifier, id model.TaskPriorityIdentifier, params TaskPriorityParams) apperr.AppErr {
+	priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return err
+	}
+	priority := priorities.Find(id)
+	if priority == nil {
+		return apperr.NewNotFoundError()
+	}
+	if same := priorities.FindByName(params.Name); same != nil && same.ID != id {
+		return apperr.NewBadRequestError().SetMessage("task priority name is already used")
+	}
+
+	desc := model.TaskPriorityDescription{
+		Name:  params.Name,
+		Level: params.Level,
+	}
+	if err = priority.Update(desc); err != nil {
+		return err
+	}
+
+	if err = u.taskPriorityRepository.Update(priority); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *taskPriorityUsecase) Delete(companyID model.CompanyIdentifier, id model.TaskPriorityIdentifier) apperr.AppErr {
+	priorities, err := u.taskPriorityRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return err
+	}
+	if priorities.Find(id) == nil {
+		return apperr.NewNotFoundError()
+	}
+
+	if err = u.taskPriorityRepository.Delete(id); err != nil {
+		return err
+	}
+
+	return nil
+}
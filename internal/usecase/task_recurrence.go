@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/lib/apperr"
+)
+
+// TaskEditScope / 繰り返しのタスクの編集の範囲
+type TaskEditScope int
+
+const (
+	// TaskEditScopeThis / この回のみを編集する（既定）
+	TaskEditScopeThis TaskEditScope = iota + 1
+	// TaskEditScopeFuture / この回と以降のすべての回を編集する
+	TaskEditScopeFuture
+)
+
+// TaskRecurrenceParams / 繰り返しの規則の設定に必要な情報
+type TaskRecurrenceParams struct {
+	Frequency model.RecurrenceFrequency
+	Interval  int
+	Weekdays  []time.Weekday
+	Until     *time.Time
+	Count     *int
+	UpdatorID model.UserIdentifier
+}
+
+func (u *taskUsecase) SetRecurrence(id model.TaskIdentifier, params TaskRecurrenceParams) apperr.AppErr {
+	task, err := u.taskRepository.Find(params.UpdatorID, id)
+	if err != nil {
+		return err
+	}
+
+	recurrence := model.Recurrence{
+		Frequency: params.Frequency,
+		Interval:  params.Interval,
+		Weekdays:  params.Weekdays,
+		Until:     params.Until,
+		Count:     params.Count,
+	}
+	if task.Series != nil {
+		if err = task.Series.Update(recurrence); err != nil {
+			return err
+		}
+		return u.taskSeriesRepository.Update(task.Series)
+	}
+
+	series, err := model.NewTaskSeries(task, recurrence)
+	if err != nil {
+		return err
+	}
+	updator, err := u.userRepository.Get(params.UpdatorID)
+	if err != nil {
+		return err
+	}
+	task.AttachSeries(series, updator)
+	if _, err = u.taskSeriesRepository.Create(series, task); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *taskUsecase) DeleteRecurrence(userID model.UserIdentifier, id model.TaskIdentifier) apperr.AppErr {
+	task, err := u.taskRepository.Find(userID, id)
+	if err != nil {
+		return err
+	}
+	if task.Series == nil {
+		return apperr.NewNotFoundError().SetMessage("task does not recur")
+	}
+	updator, err := u.userRepository.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	// 生成済みの回をすべて系列から外し、繰り返しの終了として記録する
+	series := task.Series
+	occurrences, err := u.taskRepository.ListBySeriesID(series.ID)
+	if err != nil {
+		return err
+	}
+	for _, occurrence := range occurrences {
+		occurrence.DetachSeries(updator)
+	}
+
+	return u.taskSeriesRepository.Delete(series, occurrences)
+}
+
+func (u *taskUsecase) GenerateDueOccurrences(now time.Time) (int, apperr.AppErr) {
+	tasks, err := u.taskRepository.ListDueOccurrences(now)
+	if err != nil {
+		return 0, err
+	}
+
+	// 一つの系列の失敗で他の系列の生成を止めない
+	var firstErr apperr.AppErr
+	generated := 0
+	for _, task := range tasks {
+		created, err := u.generateNextOccurrence(task)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if created {
+			generated++
+		}
+	}
+
+	return generated, firstErr
+}
+
+// generateNextOccurrence / 系列の次の回を生成する。系列が終了している場合と生成済みの場合は false を返す。
+func (u *taskUsecase) generateNextOccurrence(previous *model.Task) (bool, apperr.AppErr) {
+	series := previous.Series
+	if series == nil {
+		return false, nil
+	}
+	occurrence, ok := series.NextOccurrence(previous)
+	if !ok {
+		return false, nil
+	}
+
+	// 内容は「以降のすべて」で最後に編集された回を元にする。削除されている場合は前の回を元にする。
+	template := previous
+	if series.TemplateTaskID != 0 && series.TemplateTaskID != previous.ID {
+		task, err := u.taskRepository.Get(series.TemplateTaskID)
+		if err != nil && err.Code() != apperr.ErrorCodeNotFound {
+			return false, err
+		}
+		if task != nil {
+			template = task
+		}
+	}
+
+	desc, err := u.newOccurrenceDescription(template)
+	if err != nil {
+		return false, err
+	}
+	task, err := model.NewTaskOccurrence(series, occurrence, *desc)
+	if err != nil {
+		return false, err
+	}
+
+	return u.taskRepository.CreateOccurrence(task)
+}
+
+// newOccurrenceDescription / 元のタスクの内容から次の回の作成に必要な情報を生成する。
+// ステータスは初期ステータス、チェックリストは未完了に戻す。作成者は元のタスクの作成者とする。
+func (u *taskUsecase) newOccurrenceDescription(template *model.Task) (*model.TaskDescription, apperr.AppErr) {
+	creator := template.Creator
+	workflow, err := u.workflowRepository.Get(creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+	status := workflow.InitialStatus()
+	if status == nil {
+		return nil, apperr.NewInternalServerError().SetMessage("workflow has no OPEN status")
+	}
+
+	setting, err := u.companySettingRepository.Get(creator.Company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 親タスクが削除された場合などは最上位のタスクとして生成する
+	hierarchy, err := u.buildTaskHierarchy(creator.ID, 0, template.ParentID)
+	if err != nil {
+		if err.Code() != apperr.ErrorCodeNotFound && err.Code() != apperr.ErrorCodeForbidden {
+			return nil, err
+		}
+	}
+
+	var checklist []model.ChecklistItemDescription
+	for _, item := range template.Checklist {
+		checklist = append(checklist, model.ChecklistItemDescription{Text: item.Text})
+	}
+
+	return &model.TaskDescription{
+		Title:          template.Title,
+		Detail:         template.Detail,
+		Status:         *status,
+		Visibility:     template.Visibility,
+		PersonInCharge: template.PersonInCharge,
+		Priority:       template.Priority,
+		Labels:         template.Labels,
+		Checklist:      checklist,
+		Assignees:      template.Assignees,
+		Watchers:       template.Watchers,
+		Hierarchy:      hierarchy,
+		Creator:        &creator,
+		Updator:        &creator,
+		Workflow:       workflow,
+		Setting:        setting,
+	}, nil
+}
+
+// updateFutureOccurrences / 回を更新して以降の回の元とし、生成済みの未完了の以降の回にも日付とステータス以外の内容を反映する。
+// 編集した回と系列、以降の回は同じトランザクションで保存し、いずれかが失敗した場合はすべて保存しない。
+func (u *taskUsecase) updateFutureOccurrences(task *model.Task, params TaskUpdateParams) apperr.AppErr {
+	if err := u.applyUpdate(task, params); err != nil {
+		return err
+	}
+	changes := task.Changes
+
+	series := task.Series
+	series.TemplateTaskID = task.ID
+	occurrences, err := u.taskRepository.ListBySeriesID(series.ID)
+	if err != nil {
+		return err
+	}
+	tasks := []*model.Task{task}
+	source := newTaskUpdateParams(task)
+	for _, occurrence := range occurrences {
+		if occurrence.Occurrence <= task.Occurrence || occurrence.Status.IsClosed() {
+			continue
+		}
+		update := newTaskUpdateParams(occurrence)
+		update.Title = source.Title
+		update.Detail = source.Detail
+		update.Visibility = source.Visibility
+		update.PersonInChargeID = source.PersonInChargeID
+		update.Priority = source.Priority
+		update.LabelIDs = source.LabelIDs
+		update.ParentID = source.ParentID
+		update.Checklist = source.Checklist
+		update.AssigneeIDs = source.AssigneeIDs
+		update.WatcherIDs = source.WatcherIDs
+		update.UpdatorID = params.UpdatorID
+		if err = u.applyUpdate(occurrence, update); err != nil {
+			return err
+		}
+		tasks = append(tasks, occurrence)
+	}
+	if err = u.taskSeriesRepository.Update(series, tasks...); err != nil {
+		return err
+	}
+
+	return u.afterSave(task, changes)
+}
+
+// isClosedBy / 変更によってタスクが完了したかどうか
+func isClosedBy(task *model.Task, changes []*model.TaskChange) bool {
+	if !task.Status.IsClosed() {
+		return false
+	}
+	for _, change := range changes {
+		if change.Field == model.TaskFieldStatus {
+			return true
+		}
+	}
+	return false
+}
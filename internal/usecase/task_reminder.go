@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"slices"
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// reminderLookback / 通知時機を過ぎてから送るまでの猶予。停止中に迎えた通知時機はこの期間内であれば再開後に送る。
+const reminderLookback = 24 * time.Hour
+
+type TaskReminderUsecase interface {
+	// SendDueReminders / 通知時機を迎えたリマインダーを送り、送った件数を返す
+	SendDueReminders(now time.Time) (int, apperr.AppErr)
+}
+
+type taskReminderUsecase struct {
+	taskRepository         repository.TaskRepository
+	taskReminderRepository repository.TaskReminderRepository
+	notifier               Notifier
+	// offsets / 期限のどれだけ前に送るか。0 は期限ちょうど。
+	offsets []time.Duration
+}
+
+func NewTaskReminderUsecase(
+	taskRepository repository.TaskRepository,
+	taskReminderRepository repository.TaskReminderRepository,
+	notifier Notifier,
+	offsets []time.Duration,
+) TaskReminderUsecase {
+	return &taskReminderUsecase{
+		taskRepository,
+		taskReminderRepository,
+		notifier,
+		offsets,
+	}
+}
+
+func (u *taskReminderUsecase) SendDueReminders(now time.Time) (int, apperr.AppErr) {
+	if len(u.offsets) == 0 {
+		return 0, nil
+	}
+	maxOffset := slices.Max(u.offsets)
+	minOffset := slices.Min(u.offsets)
+	tasks, err := u.taskRepository.ListOpenByLimitDate(now.Add(minOffset-reminderLookback), now.Add(maxOffset))
+	if err != nil {
+		return 0, err
+	}
+
+	// 一つのリマインダーの失敗で他のリマインダーを止めない
+	var firstErr apperr.AppErr
+	sent := 0
+	for _, task := range tasks {
+		for _, reminder := range model.NewTaskReminders(task, u.offsets, now, reminderLookback) {
+			ok, err := u.send(reminder)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+	}
+
+	return sent, firstErr
+}
+
+// send / 送信済みとして記録してから担当者に通知する。記録済みの場合は送らずに false を返す。
+func (u *taskReminderUsecase) send(reminder *model.TaskReminder) (bool, apperr.AppErr) {
+	created, err := u.taskReminderRepository.Create(reminder)
+	if err != nil || !created {
+		return false, err
+	}
+	if err = u.notifier.Notify(model.NewDueSoonNotification(reminder)); err != nil {
+		// 次の実行で送り直せるように記録を取り消す
+		if derr := u.taskReminderRepository.Delete(reminder.ID); derr != nil {
+			return false, derr
+		}
+		return false, err
+	}
+	return true, nil
+}
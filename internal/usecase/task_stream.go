@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"sync"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// taskStreamSubscriberName / 出来事の配信済みの記録に使う購読者の名前
+const taskStreamSubscriberName = "task_stream"
+
+type TaskStreamUsecase interface {
+	// Subscribe / ユーザが閲覧できる企業のタスクの変更の購読を始める。
+	// lastEventID を指定した場合は、それより後の保持している変更を先に送る。
+	Subscribe(userID model.UserIdentifier, companyID model.CompanyIdentifier, lastEventID *model.TaskStreamEventIdentifier) (*TaskStream, apperr.AppErr)
+}
+
+// TaskStream / 購読中のタスクの変更
+type TaskStream struct {
+	// Events / 閲覧できる変更。購読が打ち切られた場合は閉じられ、クライアントは再接続する必要がある。
+	Events <-chan *model.TaskStreamEvent
+	// Reset / lastEventID より後の変更の一部をすでに保持していないため、クライアントはタスクを取得し直す必要がある
+	Reset bool
+	// Close / 購読をやめる
+	Close func()
+}
+
+type taskStreamUsecase struct {
+	userRepository   repository.UserRepository
+	taskStreamBroker repository.TaskStreamBroker
+}
+
+func NewTaskStreamUsecase(
+	userRepository repository.UserRepository,
+	taskStreamBroker repository.TaskStreamBroker,
+) TaskStreamUsecase {
+	return &taskStreamUsecase{
+		userRepository,
+		taskStreamBroker,
+	}
+}
+
+func (u *taskStreamUsecase) Subscribe(userID model.UserIdentifier, companyID model.CompanyIdentifier, lastEventID *model.TaskStreamEventIdentifier) (*TaskStream, apperr.AppErr) {
+	user, err := u.userRepository.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 購読を始めてから保持している変更を読むことで、間に届いた変更を取りこぼさない
+	events, cancel, err := u.taskStreamBroker.Subscribe(companyID)
+	if err != nil {
+		return nil, err
+	}
+	var replay []*model.TaskStreamEvent
+	reset := false
+	if lastEventID != nil {
+		var complete bool
+		replay, complete, err = u.taskStreamBroker.ListAfter(companyID, *lastEventID)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		reset = !complete
+	}
+
+	out := make(chan *model.TaskStreamEvent)
+	done := make(chan struct{})
+	var once sync.Once
+	stream := &TaskStream{
+		Events: out,
+		Reset:  reset,
+		Close: func() {
+			once.Do(func() {
+				close(done)
+				cancel()
+			})
+		},
+	}
+
+	go func() {
+		defer close(out)
+		var sent model.TaskStreamEventIdentifier
+		if lastEventID != nil && !reset {
+			sent = *lastEventID
+		}
+		send := func(event *model.TaskStreamEvent) bool {
+			// 再送した変更と購読で届いた変更の重複は送らない
+			if event.ID <= sent {
+				return true
+			}
+			sent = event.ID
+			if !event.IsVisibleTo(user) {
+				// 公開範囲の変更で閲覧できなくなった場合は、端末から削除させるために知らせる
+				if !event.WasVisibleTo(user) {
+					return true
+				}
+				event = event.Removal()
+			}
+			select {
+			case out <- event:
+				return true
+			case <-done:
+				return false
+			}
+		}
+		for _, event := range replay {
+			if !send(event) {
+				return
+			}
+		}
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok || !send(event) {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// taskStreamSubscriber / タスクの出来事を購読し、変更としてブローカーに送る
+type taskStreamSubscriber struct {
+	taskRepository   repository.TaskRepository
+	taskStreamBroker repository.TaskStreamBroker
+}
+
+func NewTaskStreamSubscriber(
+	taskRepository repository.TaskRepository,
+	taskStreamBroker repository.TaskStreamBroker,
+) DomainEventSubscriber {
+	return &taskStreamSubscriber{
+		taskRepository,
+		taskStreamBroker,
+	}
+}
+
+func (s *taskStreamSubscriber) Name() string {
+	return taskStreamSubscriberName
+}
+
+func (s *taskStreamSubscriber) Handle(event *model.DomainEvent) apperr.AppErr {
+	if event.AggregateType != model.AggregateTypeTask {
+		return nil
+	}
+	// 閲覧できるかどうかは配信する時点のタスクで判定する
+	task, err := s.taskRepository.GetIncludingDeleted(model.TaskIdentifier(event.AggregateID))
+	if err != nil {
+		if err.Code() == apperr.ErrorCodeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return s.taskStreamBroker.Publish(model.NewTaskStreamEvent(event, task))
+}
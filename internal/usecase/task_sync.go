@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// taskSyncPageSize / 一度の同期で返すタスクの件数の上限
+const taskSyncPageSize = 500
+
+type TaskSyncUsecase interface {
+	// ListChanges / カーソルより後に変更された企業のタスクを取得する。
+	// カーソルが0の場合は閲覧できるすべてのタスクを返し、削除されたタスクの ID は返さない。
+	ListChanges(userID model.UserIdentifier, companyID model.CompanyIdentifier, since uint64) (*model.TaskChangeSet, apperr.AppErr)
+}
+
+type taskSyncUsecase struct {
+	userRepository repository.UserRepository
+	taskRepository repository.TaskRepository
+}
+
+func NewTaskSyncUsecase(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+) TaskSyncUsecase {
+	return &taskSyncUsecase{
+		userRepository,
+		taskRepository,
+	}
+}
+
+func (u *taskSyncUsecase) ListChanges(userID model.UserIdentifier, companyID model.CompanyIdentifier, since uint64) (*model.TaskChangeSet, apperr.AppErr) {
+	user, err := u.userRepository.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 確定した通し番号を先に読み、それ以下の変更のみを返すことで、書き込み中の変更を飛ばさない
+	until, err := u.taskRepository.GetChangeSeq(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if since > until {
+		return nil, apperr.NewBadRequestError().SetMessage("cursor is newer than the latest change")
+	}
+
+	tasks, err := u.taskRepository.ListChanged(companyID, since, until, taskSyncPageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	changeSet := &model.TaskChangeSet{
+		Cursor: until,
+	}
+	if len(tasks) > taskSyncPageSize {
+		tasks = tasks[:taskSyncPageSize]
+		changeSet.Cursor = tasks[len(tasks)-1].ChangeSeq
+		changeSet.HasMore = true
+	}
+	for _, task := range tasks {
+		changeSet.Add(task, user, since > 0)
+	}
+
+	return changeSet, nil
+}
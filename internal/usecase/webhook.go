@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// webhookBatchSize / 一度の実行で送る配信の件数の上限
+const webhookBatchSize = 100
+
+type WebhookUsecase interface {
+	ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Webhook, apperr.AppErr)
+	Get(companyID model.CompanyIdentifier, id model.WebhookIdentifier) (*model.Webhook, apperr.AppErr)
+
+	Create(companyID model.CompanyIdentifier, params WebhookParams) (*model.WebhookIdentifier, apperr.AppErr)
+	Update(companyID model.CompanyIdentifier, id model.WebhookIdentifier, params WebhookParams) apperr.AppErr
+	Delete(companyID model.CompanyIdentifier, id model.WebhookIdentifier) apperr.AppErr
+
+	// ListDeliveries / Webhook の配信の記録を新しい順に取得する。全件数も返す。
+	ListDeliveries(companyID model.CompanyIdentifier, id model.WebhookIdentifier, page model.Page) ([]*model.WebhookDelivery, int, apperr.AppErr)
+	// Redeliver / 配信と同じペイロードを新しい配信として送信待ちにする
+	Redeliver(companyID model.CompanyIdentifier, id model.WebhookIdentifier, deliveryID model.WebhookDeliveryIdentifier) (*model.WebhookDeliveryIdentifier, apperr.AppErr)
+	// DeliverPending / 送信待ちの配信を送り、成功した件数を返す。失敗した配信は間隔を空けて再送する。
+	DeliverPending(now time.Time) (int, apperr.AppErr)
+}
+
+type WebhookParams struct {
+	URL        string
+	EventTypes []model.WebhookEventType
+	Active     bool
+}
+
+type webhookUsecase struct {
+	webhookRepository         repository.WebhookRepository
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+	webhookSender             repository.WebhookSender
+}
+
+func NewWebhookUsecase(
+	webhookRepository repository.WebhookRepository,
+	webhookDeliveryRepository repository.WebhookDeliveryRepository,
+	webhookSender repository.WebhookSender,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+	}
+}
+
+func (u *webhookUsecase) ListByCompanyID(companyID model.CompanyIdentifier) ([]*model.Webhook, apperr.AppErr) {
+	webhooks, err := u.webhookRepository.ListByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (u *webhookUsecase) Get(companyID model.CompanyIdentifier, id model.WebhookIdentifier) (*model.Webhook, apperr.AppErr) {
+	webhook, err := u.webhookRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	// 他社の Webhook は存在しないものとして扱う
+	if webhook.CompanyID != companyID {
+		return nil, apperr.NewNotFoundError()
+	}
+
+	return webhook, nil
+}
+
+func (u *webhookUsecase) Create(companyID model.CompanyIdentifier, params WebhookParams) (*model.WebhookIdentifier, apperr.AppErr) {
+	desc := model.WebhookDescription{
+		URL:        params.URL,
+		EventTypes: params.EventTypes,
+		Active:     params.Active,
+	}
+	webhook, err := model.NewWebhook(companyID, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.webhookRepository.Create(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *webhookUsecase) Update(companyID model.CompanyIdentifier, id model.WebhookIdentifier, params WebhookParams) apperr.AppErr {
+	webhook, err := u.Get(companyID, id)
+	if err != nil {
+		return err
+	}
+
+	desc := model.WebhookDescription{
+		URL:        params.URL,
+		EventTypes: params.EventTypes,
+		Active:     params.Active,
+	}
+	if err = webhook.Update(desc); err != nil {
+		return err
+	}
+
+	if err = u.webhookRepository.Update(webhook); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *webhookUsecase) Delete(companyID model.CompanyIdentifier, id model.WebhookIdentifier) apperr.AppErr {
+	if _, err := u.Get(companyID, id); err != nil {
+		return err
+	}
+
+	if err := u.webhookRepository.Delete(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *webhookUsecase) ListDeliveries(companyID model.CompanyIdentifier, id model.WebhookIdentifier, page model.Page) ([]*model.WebhookDelivery, int, apperr.AppErr) {
+	if _, err := u.Get(companyID, id); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := u.webhookDeliveryRepository.ListByWebhookID(id, page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (u *webhookUsecase) Redeliver(companyID model.CompanyIdentifier, id model.WebhookIdentifier, deliveryID model.WebhookDeliveryIdentifier) (*model.WebhookDeliveryIdentifier, apperr.AppErr) {
+	webhook, err := u.Get(companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Active {
+		return nil, apperr.NewBadRequestError().SetMessage("webhook is disabled")
+	}
+	delivery, err := u.webhookDeliveryRepository.Get(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhook.ID {
+		return nil, apperr.NewNotFoundError()
+	}
+
+	redelivery := delivery.Redeliver(time.Now())
+	if err = u.webhookDeliveryRepository.Create(redelivery); err != nil {
+		return nil, err
+	}
+
+	return &redelivery.ID, nil
+}
+
+func (u *webhookUsecase) DeliverPending(now time.Time) (int, apperr.AppErr) {
+	deliveries, err := u.webhookDeliveryRepository.ListDue(now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[model.WebhookIdentifier]*model.Webhook)
+	sent := 0
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = u.webhookRepository.Get(delivery.WebhookID); err != nil {
+				return sent, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if !webhook.Active {
+			delivery.Abandon("webhook is disabled")
+			if err := u.webhookDeliveryRepository.Update(delivery); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		failures := webhook.Failures
+		code, serr := u.webhookSender.Send(webhook, delivery)
+		if serr != nil {
+			var responseCode *int
+			if code != 0 {
+				responseCode = &code
+			}
+			delivery.Fail(responseCode, serr.Message(), time.Now())
+			webhook.Fail(time.Now())
+		} else {
+			delivery.Succeed(code, time.Now())
+			webhook.Succeed()
+			sent++
+		}
+
+		if err := u.webhookDeliveryRepository.Update(delivery); err != nil {
+			return sent, err
+		}
+		if webhook.Failures != failures {
+			if err := u.webhookRepository.UpdateFailures(webhook); err != nil {
+				return sent, err
+			}
+		}
+	}
+
+	return sent, nil
+}
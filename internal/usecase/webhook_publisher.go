@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"time"
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+// webhookSubscriberName / 出来事の配信済みの記録に使う購読者の名前
+const webhookSubscriberName = "webhook"
+
+// WebhookPublisher / 出来事を購読している企業の Webhook への配信を送信待ちにする
+type WebhookPublisher interface {
+	Publish(events ...*model.WebhookEvent) apperr.AppErr
+}
+
+type webhookPublisher struct {
+	webhookRepository         repository.WebhookRepository
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+	webhookEncoder            repository.WebhookEncoder
+}
+
+func NewWebhookPublisher(
+	webhookRepository repository.WebhookRepository,
+	webhookDeliveryRepository repository.WebhookDeliveryRepository,
+	webhookEncoder repository.WebhookEncoder,
+) WebhookPublisher {
+	return &webhookPublisher{
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookEncoder,
+	}
+}
+
+func (p *webhookPublisher) Publish(events ...*model.WebhookEvent) apperr.AppErr {
+	webhooks := make(map[model.CompanyIdentifier][]*model.Webhook)
+	var deliveries []*model.WebhookDelivery
+	for _, event := range events {
+		active, ok := webhooks[event.CompanyID]
+		if !ok {
+			var err apperr.AppErr
+			if active, err = p.webhookRepository.ListActive(event.CompanyID); err != nil {
+				return err
+			}
+			webhooks[event.CompanyID] = active
+		}
+
+		// ペイロードは購読している Webhook がある場合のみ一度だけ生成する
+		var payload *string
+		for _, webhook := range active {
+			if !webhook.Subscribes(event.Type) {
+				continue
+			}
+			if payload == nil {
+				encoded, err := p.webhookEncoder.Encode(event)
+				if err != nil {
+					return err
+				}
+				payload = &encoded
+			}
+			deliveries = append(deliveries, model.NewWebhookDelivery(webhook, event, *payload, time.Now()))
+		}
+	}
+	return p.webhookDeliveryRepository.Create(deliveries...)
+}
+
+// webhookSubscriber / 出来事を購読し、Webhook への配信を送信待ちにする
+type webhookSubscriber struct {
+	domainEventLoader
+	webhookPublisher WebhookPublisher
+}
+
+func NewWebhookSubscriber(
+	userRepository repository.UserRepository,
+	taskRepository repository.TaskRepository,
+	commentRepository repository.CommentRepository,
+	webhookPublisher WebhookPublisher,
+) DomainEventSubscriber {
+	return &webhookSubscriber{
+		domainEventLoader{userRepository, taskRepository, commentRepository},
+		webhookPublisher,
+	}
+}
+
+func (s *webhookSubscriber) Name() string {
+	return webhookSubscriberName
+}
+
+func (s *webhookSubscriber) Handle(event *model.DomainEvent) apperr.AppErr {
+	var webhookEvent *model.WebhookEvent
+	switch event.Type {
+	case model.DomainEventTypeTaskCreated, model.DomainEventTypeTaskUpdated,
+		model.DomainEventTypeTaskStatusChanged, model.DomainEventTypeTaskDeleted:
+		task, err := s.task(model.TaskIdentifier(event.AggregateID))
+		if err != nil || task == nil {
+			return err
+		}
+		actor, err := s.actor(event)
+		if err != nil || actor == nil {
+			return err
+		}
+		webhookEvent = model.NewTaskWebhookEvent(event, task, *actor)
+	case model.DomainEventTypeCommentCreated:
+		comment, task, err := s.comment(event)
+		if err != nil || comment == nil {
+			return err
+		}
+		webhookEvent = model.NewCommentWebhookEvent(event, task, comment)
+	case model.DomainEventTypeUserCreated:
+		user, err := s.user(model.UserIdentifier(event.AggregateID))
+		if err != nil || user == nil {
+			return err
+		}
+		actor, err := s.actor(event)
+		if err != nil || actor == nil {
+			return err
+		}
+		webhookEvent = model.NewUserWebhookEvent(event, user, *actor)
+	}
+	if webhookEvent == nil {
+		return nil
+	}
+	return s.webhookPublisher.Publish(webhookEvent)
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"todo_api/internal/domain/model"
+	"todo_api/internal/domain/repository"
+	"todo_api/internal/lib/apperr"
+)
+
+type WorkflowUsecase interface {
+	Get(companyID model.CompanyIdentifier) (*model.Workflow, apperr.AppErr)
+
+	CreateStatus(companyID model.CompanyIdentifier, params TaskStatusParams) (*model.TaskStatusIdentifier, apperr.AppErr)
+	UpdateStatus(companyID model.CompanyIdentifier, id model.TaskStatusIdentifier, params TaskStatusParams) apperr.AppErr
+	DeleteStatus(companyID model.CompanyIdentifier, id model.TaskStatusIdentifier) apperr.AppErr
+
+	CreateTransition(companyID model.CompanyIdentifier, params TaskStatusTransitionCreateParams) (*model.TaskStatusTransitionIdentifier, apperr.AppErr)
+	DeleteTransition(companyID model.CompanyIdentifier, id model.TaskStatusTransitionIdentifier) apperr.AppErr
+}
+
+type TaskStatusParams struct {
+	Name      string
+	Category  model.TaskStatusCategory
+	SortOrder int
+}
+
+type TaskStatusTransitionCreateParams struct {
+	FromStatusID model.TaskStatusIdentifier
+	ToStatusID   model.TaskStatusIdentifier
+	Role         *model.UserRole
+	UserType     *model.UserType
+}
+
+type workflowUsecase struct {
+	workflowRepository repository.WorkflowRepository
+}
+
+func NewWorkflowUsecase(workflowRepository repository.WorkflowRepository) WorkflowUsecase {
+	return &workflowUsecase{
+		workflowRepository,
+	}
+}
+
+func (u *workflowUsecase) Get(companyID model.CompanyIdentifier) (*model.Workflow, apperr.AppErr) {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (u *workflowUsecase) CreateStatus(companyID model.CompanyIdentifier, params TaskStatusParams) (*model.TaskStatusIdentifier, apperr.AppErr) {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.FindStatusByName(params.Name) != nil {
+		return nil, apperr.NewBadRequestError().SetMessage("task status name is already used")
+	}
+
+	desc := model.TaskStatusDescription{
+		Name:      params.Name,
+		Category:  params.Category,
+		SortOrder: params.SortOrder,
+	}
+	status, err := model.NewTaskStatus(companyID, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.workflowRepository.CreateStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *workflowUsecase) UpdateStatus(companyID model.CompanyIdentifier, id model.TaskStatusIdentifier, params TaskStatusParams) apperr.AppErr {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return err
+	}
+	status := workflow.FindStatus(id)
+	if status == nil {
+		return apperr.NewNotFoundError()
+	}
+	if same := workflow.FindStatusByName(params.Name); same != nil && same.ID != id {
+		return apperr.NewBadRequestError().SetMessage("task status name is already used")
+	}
+
+	desc := model.TaskStatusDescription{
+		Name:      params.Name,
+		Category:  params.Category,
+		SortOrder: params.SortOrder,
+	}
+	if err = status.Update(desc); err != nil {
+		return err
+	}
+	// 未着手のステータスが無くなるとタスクを作成できなくなる
+	if workflow.InitialStatus() == nil {
+		return apperr.NewBadRequestError().SetMessage("workflow must have at least one OPEN status")
+	}
+
+	if err = u.workflowRepository.UpdateStatus(status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *workflowUsecase) DeleteStatus(companyID model.CompanyIdentifier, id model.TaskStatusIdentifier) apperr.AppErr {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return err
+	}
+	status := workflow.FindStatus(id)
+	if status == nil {
+		return apperr.NewNotFoundError()
+	}
+	if !workflow.CanRemoveStatus(id) {
+		return apperr.NewBadRequestError().SetMessage("workflow must have at least one OPEN status")
+	}
+
+	if err = u.workflowRepository.DeleteStatus(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *workflowUsecase) CreateTransition(companyID model.CompanyIdentifier, params TaskStatusTransitionCreateParams) (*model.TaskStatusTransitionIdentifier, apperr.AppErr) {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return nil, err
+	}
+	from := workflow.FindStatus(params.FromStatusID)
+	to := workflow.FindStatus(params.ToStatusID)
+	if from == nil || to == nil {
+		return nil, apperr.NewBadRequestError().SetMessage("task status is not found")
+	}
+
+	desc := model.TaskStatusTransitionDescription{
+		From:     from,
+		To:       to,
+		Role:     params.Role,
+		UserType: params.UserType,
+	}
+	transition, err := model.NewTaskStatusTransition(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.workflowRepository.CreateTransition(transition)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (u *workflowUsecase) DeleteTransition(companyID model.CompanyIdentifier, id model.TaskStatusTransitionIdentifier) apperr.AppErr {
+	workflow, err := u.workflowRepository.Get(companyID)
+	if err != nil {
+		return err
+	}
+	if workflow.FindTransition(id) == nil {
+		return apperr.NewNotFoundError()
+	}
+
+	if err = u.workflowRepository.DeleteTransition(id); err != nil {
+		return err
+	}
+
+	return nil
+}